@@ -1,20 +1,28 @@
 package nep_test
 
 import (
+	"errors"
 	"io"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNEP17Balance(t *testing.T) {
@@ -179,6 +187,60 @@ func TestNEP17Transfer(t *testing.T) {
 		require.Equal(t, big.NewInt(2), b)
 	})
 
+	t.Run("with remote signer", func(t *testing.T) {
+		srv := httptest.NewServer(remotesigner.NewHandler(testcli.ValidatorPriv))
+		t.Cleanup(srv.Close)
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		cfg := config.Wallet{
+			Path:         testcli.ValidatorWallet,
+			Password:     "wrong", // Not used, keys are not decrypted.
+			RemoteSigner: srv.URL,
+		}
+		res, err := yaml.Marshal(cfg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(configPath, res, 0666))
+
+		to, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		as := append([]string{}, args[:6]...)
+		as = append(as, "--wallet-config", configPath, "--to", to.Address())
+		as = append(as, args[10:]...)
+		e.Run(t, append(as, "--force")...)
+		e.CheckTxPersisted(t)
+
+		b, _ := e.Chain.GetGoverningTokenBalance(to.GetScriptHash())
+		require.Equal(t, big.NewInt(1), b)
+
+		t.Run("failing", func(t *testing.T) {
+			h := remotesigner.NewHandler(testcli.ValidatorPriv)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/sign" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				h.ServeHTTP(w, r)
+			}))
+			t.Cleanup(srv.Close)
+			cfg.RemoteSigner = srv.URL
+			res, err := yaml.Marshal(cfg)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(configPath, res, 0666))
+
+			// Nothing is saved without a signature.
+			txPath := filepath.Join(t.TempDir(), "tx.json")
+			e.RunWithError(t, append(as, "--force", "--out", txPath)...)
+			_, err = os.Stat(txPath)
+			require.True(t, errors.Is(err, os.ErrNotExist))
+
+			// And nothing is added to the existing context.
+			e.In.WriteString("one\r")
+			e.Run(t, append(args, "--force", "--out", txPath)...)
+			e.RunWithError(t, "neo-go", "wallet", "sign",
+				"--wallet-config", configPath, "--address", testcli.ValidatorAddr,
+				"--in", txPath, "--out", filepath.Join(t.TempDir(), "signed.json"))
+		})
+	})
+
 	hVerify := deployVerifyContract(t, e)
 	const validatorDefault = "Nhfg3TbpwogLvDGVvAvqyThbsHgoSUKwtn"
 
@@ -26,6 +26,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"github.com/urfave/cli"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -113,6 +114,10 @@ var errInvalidHistoric = errors.New("invalid 'historic' parameter, neither a blo
 var errNoWallet = errors.New("no wallet parameter found, specify it with the '--wallet' or '-w' flag or specify wallet config file with the '--wallet-config' flag")
 var errConflictingWalletFlags = errors.New("--wallet flag conflicts with --wallet-config flag, please, provide one of them to specify wallet location")
 
+// ErrWatchOnlyAccount is returned by GetUnlockedAccount and GetAccFromContext
+// for accounts that can't be used for signing.
+var ErrWatchOnlyAccount = errors.New("watch-only account can't sign")
+
 // GetNetwork examines Context's flags and returns the appropriate network. It
 // defaults to PrivNet if no flags are given.
 func GetNetwork(ctx *cli.Context) netmode.Magic {
@@ -314,6 +319,7 @@ func GetRPCWithActor(gctx context.Context, ctx *cli.Context, signers []actor.Sig
 }
 
 // GetAccFromContext returns account and wallet from context. If address is not set, default address is used.
+// Account is checked with GetAccountForTx.
 func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error) {
 	var addr util.Uint160
 
@@ -325,9 +331,13 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 	if len(wPath) == 0 && len(walletConfigPath) == 0 {
 		return nil, nil, errNoWallet
 	}
-	var pass *string
+	var (
+		pass *string
+		cfg  *config.Wallet
+		err  error
+	)
 	if len(walletConfigPath) != 0 {
-		cfg, err := ReadWalletConfig(walletConfigPath)
+		cfg, err = ReadWalletConfig(walletConfigPath)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -339,6 +349,11 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 	if err != nil {
 		return nil, nil, err
 	}
+	if cfg != nil {
+		if err := attachRemoteSigner(wall, cfg); err != nil {
+			return nil, wall, err
+		}
+	}
 	addrFlag := ctx.Generic("address").(*flags.Address)
 	if addrFlag.IsSet {
 		addr = addrFlag.Uint160()
@@ -349,17 +364,35 @@ func GetAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 		}
 	}
 
-	acc, err := GetUnlockedAccount(wall, addr, pass)
+	acc, err := GetAccountForTx(ctx, wall, addr, pass)
 	return acc, wall, err
 }
 
+// GetAccountForTx is the same as GetUnlockedAccount, but if the transaction is
+// only to be saved for signing elsewhere (--out flag is set) it also returns
+// watch-only accounts having a verification contract.
+func GetAccountForTx(ctx *cli.Context, wall *wallet.Wallet, addr util.Uint160, pass *string) (*wallet.Account, error) {
+	if ctx.String("out") != "" {
+		acc := wall.GetAccount(addr)
+		if acc != nil && acc.IsWatchOnly() && acc.Contract != nil {
+			return acc, nil
+		}
+	}
+	return GetUnlockedAccount(wall, addr, pass)
+}
+
 // GetUnlockedAccount returns account from wallet, address and uses pass to unlock specified account if given.
-// If the password is not given, then it is requested from user.
+// If the password is not given, then it is requested from user. Watch-only
+// accounts (and accounts without a verification contract) can't be used
+// for signing, so ErrWatchOnlyAccount is returned for them.
 func GetUnlockedAccount(wall *wallet.Wallet, addr util.Uint160, pass *string) (*wallet.Account, error) {
 	acc := wall.GetAccount(addr)
 	if acc == nil {
 		return nil, fmt.Errorf("wallet contains no account for '%s'", address.Uint160ToString(addr))
 	}
+	if acc.IsWatchOnly() || acc.Contract == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrWatchOnlyAccount, address.Uint160ToString(addr))
+	}
 
 	if acc.CanSign() || acc.EncryptedWIF == "" {
 		return acc, nil
@@ -381,6 +414,35 @@ func GetUnlockedAccount(wall *wallet.Wallet, addr util.Uint160, pass *string) (*
 	return acc, nil
 }
 
+// AttachRemoteSigner attaches signers of the remote signing service specified
+// in the wallet configuration file (if it's used) to wallet accounts, so that
+// GetUnlockedAccount doesn't need to decrypt them.
+func AttachRemoteSigner(ctx *cli.Context, wall *wallet.Wallet) error {
+	configPath := ctx.String("wallet-config")
+	if len(configPath) == 0 {
+		return nil
+	}
+	cfg, err := ReadWalletConfig(configPath)
+	if err != nil {
+		return err
+	}
+	return attachRemoteSigner(wall, cfg)
+}
+
+func attachRemoteSigner(wall *wallet.Wallet, cfg *config.Wallet) error {
+	if len(cfg.RemoteSigner) == 0 {
+		return nil
+	}
+	c, err := remotesigner.New(cfg.RemoteSigner, remotesigner.Options{})
+	if err != nil {
+		return err
+	}
+	if _, err = c.Attach(wall); err != nil {
+		return fmt.Errorf("failed to get remote signer keys: %w", err)
+	}
+	return nil
+}
+
 // ReadWalletConfig reads wallet config from the given path.
 func ReadWalletConfig(configPath string) (*config.Wallet, error) {
 	file, err := os.Open(configPath)
@@ -0,0 +1,260 @@
+package paramcontext
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/input"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	nio "github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"rsc.io/qr"
+)
+
+// qrPrefix is a prefix of every QR code chunk.
+const qrPrefix = "NEOCTX"
+
+// DefaultQRChunkSize is the default size of binary context data encoded into
+// a single QR code, it's small enough for codes to be easily scanned from
+// the screen.
+const DefaultQRChunkSize = 256
+
+// MinQRChunkSize is the minimal size of binary context data encoded into a
+// single QR code.
+const MinQRChunkSize = 16
+
+// maxQRContextSize is the maximum size of encoded context accepted from QR
+// codes. Context contains a transaction without witnesses and signatures with
+// verification scripts, they can't take more than the transaction itself.
+const maxQRContextSize = 2 * transaction.MaxTransactionSize
+
+// maxQRChunks is the maximum number of chunks accepted from QR codes.
+const maxQRChunks = (maxQRContextSize + MinQRChunkSize - 1) / MinQRChunkSize
+
+// qrEncoding is used for chunk data, base32 fits into QR alphanumeric mode
+// which is more compact than byte mode.
+var qrEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EncodeQRChunks encodes the context into its binary form and splits it into
+// text chunks (of at most chunkSize bytes of binary data each) suitable for
+// QR codes. Every chunk has NEOCTX:<index>/<total>:<checksum>:<data> format,
+// the checksum allows to detect chunks of different contexts.
+func EncodeQRChunks(c *context.ParameterContext, chunkSize int) ([]string, error) {
+	if chunkSize < MinQRChunkSize {
+		return nil, fmt.Errorf("invalid chunk size: should be at least %d", MinQRChunkSize)
+	}
+	w := nio.NewBufBinWriter()
+	c.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return nil, fmt.Errorf("can't encode context: %w", w.Err)
+	}
+	data := w.Bytes()
+	sum := qrChecksum(data)
+	n := (len(data) + chunkSize - 1) / chunkSize
+	chunks := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, fmt.Sprintf("%s:%d/%d:%s:%s", qrPrefix, i+1, n, sum,
+			qrEncoding.EncodeToString(data[i*chunkSize:end])))
+	}
+	return chunks, nil
+}
+
+func qrChecksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return strings.ToUpper(hex.EncodeToString(h[:4]))
+}
+
+// QRCollector gathers QR code chunks (in any order) and restores the context
+// from them.
+type QRCollector struct {
+	sum    string
+	chunks [][]byte
+	left   int
+}
+
+// Add adds a chunk (scanned QR code contents) to the collector. Duplicate
+// chunks are ignored, chunks of different contexts lead to an error.
+func (q *QRCollector) Add(chunk string) error {
+	parts := strings.SplitN(strings.TrimSpace(chunk), ":", 4)
+	if len(parts) != 4 || parts[0] != qrPrefix {
+		return errors.New("not a context QR code")
+	}
+	idx := strings.SplitN(parts[1], "/", 2)
+	if len(idx) != 2 {
+		return fmt.Errorf("invalid chunk index: %s", parts[1])
+	}
+	i, err := strconv.Atoi(idx[0])
+	if err != nil {
+		return fmt.Errorf("invalid chunk index: %w", err)
+	}
+	n, err := strconv.Atoi(idx[1])
+	if err != nil || n <= 0 || i <= 0 || i > n {
+		return fmt.Errorf("invalid chunk index: %s", parts[1])
+	}
+	if n > maxQRChunks {
+		return fmt.Errorf("too many chunks: %d (at most %d are allowed)", n, maxQRChunks)
+	}
+	data, err := qrEncoding.DecodeString(parts[3])
+	if err != nil {
+		return fmt.Errorf("invalid chunk data: %w", err)
+	}
+	// All chunks except the last one have the same size.
+	if i < n && (len(data) < MinQRChunkSize || (n-1)*len(data) > maxQRContextSize) {
+		return fmt.Errorf("invalid chunk size: %d", len(data))
+	}
+	if q.chunks == nil {
+		q.sum = parts[2]
+		q.chunks = make([][]byte, n)
+		q.left = n
+	} else if q.sum != parts[2] || len(q.chunks) != n {
+		return errors.New("chunk belongs to a different context")
+	}
+	if q.chunks[i-1] == nil {
+		q.chunks[i-1] = data
+		q.left--
+	}
+	return nil
+}
+
+// Missing returns the number of chunks that are still missing (or -1 if
+// nothing was added yet).
+func (q *QRCollector) Missing() int {
+	if q.chunks == nil {
+		return -1
+	}
+	return q.left
+}
+
+// Context returns the context restored from all of the chunks.
+func (q *QRCollector) Context() (*context.ParameterContext, error) {
+	if q.chunks == nil || q.left != 0 {
+		return nil, errors.New("not all chunks are collected")
+	}
+	var data []byte
+	for _, c := range q.chunks {
+		data = append(data, c...)
+	}
+	if qrChecksum(data) != q.sum {
+		return nil, errors.New("checksum mismatch")
+	}
+	c := new(context.ParameterContext)
+	r := nio.NewBinReaderFromBuf(data)
+	c.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, fmt.Errorf("can't decode context: %w", r.Err)
+	}
+	return c, nil
+}
+
+// WriteQRTerminal renders the QR code with the given contents to the terminal
+// (using Unicode half blocks for light modules, so it's expected to be
+// displayed on a dark background).
+func WriteQRTerminal(w io.Writer, text string) error {
+	code, err := qr.Encode(text, qr.L)
+	if err != nil {
+		return err
+	}
+	const quiet = 2
+	var sb strings.Builder
+	for y := -quiet; y < code.Size+quiet; y += 2 {
+		for x := -quiet; x < code.Size+quiet; x++ {
+			top, bottom := !code.Black(x, y), !code.Black(x, y+1)
+			if y+1 >= code.Size+quiet {
+				bottom = false
+			}
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// SaveQRPNG saves the QR code with the given contents into a PNG file.
+func SaveQRPNG(filename string, text string) error {
+	code, err := qr.Encode(text, qr.L)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, code.PNG(), 0644); err != nil {
+		return fmt.Errorf("can't write QR code to file: %w", err)
+	}
+	return nil
+}
+
+// EnterQRPrompt is a prompt used to ask the user for QR code contents.
+const EnterQRPrompt = "Enter QR code data > "
+
+// ReadQR restores the context from the given QR code chunks, missing chunks
+// are read from the input interactively (one per line, that's what most of
+// QR code scanners emulating keyboards do).
+func ReadQR(chunks []string) (*context.ParameterContext, error) {
+	var q QRCollector
+	for _, c := range chunks {
+		if err := q.Add(c); err != nil {
+			return nil, err
+		}
+	}
+	for q.Missing() != 0 {
+		line, err := input.ReadLine(EnterQRPrompt)
+		if err != nil {
+			return nil, fmt.Errorf("can't read QR code data: %w", err)
+		}
+		if err := q.Add(line); err != nil {
+			return nil, err
+		}
+	}
+	return q.Context()
+}
+
+// WriteQR renders the context as a series of QR codes to the terminal.
+func WriteQR(w io.Writer, c *context.ParameterContext, chunkSize int) error {
+	chunks, err := EncodeQRChunks(c, chunkSize)
+	if err != nil {
+		return err
+	}
+	for i, chunk := range chunks {
+		fmt.Fprintf(w, "QR code %d/%d:\n", i+1, len(chunks))
+		if err := WriteQRTerminal(w, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveQR saves the context as a series of QR codes into PNG files named
+// <prefix>-<index>.png and returns the list of file names.
+func SaveQR(c *context.ParameterContext, prefix string, chunkSize int) ([]string, error) {
+	chunks, err := EncodeQRChunks(c, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		names[i] = prefix + "-" + strconv.Itoa(i+1) + ".png"
+		if err := SaveQRPNG(names[i], chunk); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
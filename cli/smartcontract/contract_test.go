@@ -286,8 +286,10 @@ func TestContractInitAndCompile(t *testing.T) {
 	require.FileExists(t, nefPath)
 	require.FileExists(t, manifestPath)
 
-	t.Run("output hex script with --verbose", func(t *testing.T) {
+	t.Run("output standards and hex script with --verbose", func(t *testing.T) {
 		e.Run(t, append(cmd, "--verbose")...)
+		e.CheckNextLine(t, "^Declared standards: none$")
+		e.CheckNextLine(t, "^Compliant standards: none$")
 		e.CheckNextLine(t, "^[0-9a-hA-H]+$")
 	})
 
@@ -992,6 +994,205 @@ func TestContractInspect(t *testing.T) {
 		e.Run(t, append(cmd, "--in", nefName)...)
 		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
 	})
+	t.Run("with manifest", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--manifest", filepath.Join(tmpDir, "not.exists"))...)
+		e.Run(t, append(cmd, "--manifest", manifestName)...)
+		e.CheckNextLine(t, "Declared standards: none")
+		e.CheckNextLine(t, "Compliant standards: none")
+		e.CheckEOF(t)
+
+		e.Run(t, append(cmd, "--manifest", manifestName, "--in", nefName)...)
+		e.CheckNextLine(t, "Declared standards: none")
+		e.CheckNextLine(t, "Compliant standards: none")
+		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
+	})
+	t.Run("with token manifest", func(t *testing.T) {
+		e.Run(t, append(cmd, "--manifest", filepath.Join("testdata", "nex", "nex.manifest.json"))...)
+		e.CheckNextLine(t, "Declared standards: NEP-17")
+		e.CheckNextLine(t, "Compliant standards: NEP-17")
+		e.CheckEOF(t)
+	})
+}
+
+func TestContractCompileGuessPermissions(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+
+	data, err := os.ReadFile("testdata/permissions/neo-go.yml")
+	require.NoError(t, err)
+	cfgPath := filepath.Join(tmpDir, "neo-go.yml")
+	require.NoError(t, os.WriteFile(cfgPath, data, os.ModePerm))
+	manifestPath := filepath.Join(tmpDir, "out.manifest.json")
+
+	cmd := []string{"neo-go", "contract", "compile",
+		"--in", "testdata/permissions/main.go",
+		"--out", filepath.Join(tmpDir, "out.nef"),
+		"--manifest", manifestPath,
+		"--config", cfgPath}
+	e.Run(t, append(cmd, "--guess-permissions")...)
+	require.Contains(t, e.Err.String(), "main.go:15:23: method of the contract call can't be determined")
+
+	data, err = os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	m := new(manifest.Manifest)
+	require.NoError(t, json.Unmarshal(data, m))
+	require.Equal(t, 1, len(m.Permissions))
+	require.Equal(t, manifest.PermissionHash, m.Permissions[0].Contract.Type)
+	require.Equal(t, []string{"transfer"}, m.Permissions[0].Methods.Value)
+
+	conf, err := smartcontract.ParseContractConfig(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, "Test permissions", conf.Name)
+	require.Equal(t, 1, len(conf.Permissions))
+	require.Equal(t, m.Permissions[0], manifest.Permission(conf.Permissions[0]))
+
+	data, err = os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "# Permissions are added by the compiler.")
+
+	// Permissions are already in the config.
+	e.Run(t, cmd...)
+
+	// Broad and unused configured permissions are replaced.
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`name: Test permissions
+permissions:
+  - methods: '*'
+  - hash: fffdc93764dbaddd97c48f252a53ea4643faa3fd
+    methods: ["update"]
+`), os.ModePerm))
+	e.Run(t, append(cmd, "--guess-permissions")...)
+	conf, err = smartcontract.ParseContractConfig(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(conf.Permissions))
+	require.Equal(t, m.Permissions[0], manifest.Permission(conf.Permissions[0]))
+}
+
+func TestContractDiff(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+
+	compile := func(t *testing.T, src string, config string, name string) (string, string, string) {
+		nefPath := filepath.Join(tmpDir, name+".nef")
+		manifestPath := filepath.Join(tmpDir, name+".manifest.json")
+		debugPath := filepath.Join(tmpDir, name+".debug.json")
+		e.Run(t, "neo-go", "contract", "compile",
+			"--in", src,
+			"--config", config,
+			"--out", nefPath, "--manifest", manifestPath, "--debug", debugPath)
+		return nefPath, manifestPath, debugPath
+	}
+	oldNEF, oldManifest, oldDebug := compile(t, "testdata/deploy/main.go", "testdata/deploy/neo-go.yml", "old")
+	newNEF, newManifest, newDebug := compile(t, "testdata/deploy/updated.go", "testdata/deploy/neo-go.yml", "new")
+
+	cmd := []string{"neo-go", "contract", "diff"}
+	t.Run("missing manifest", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--old-manifest", oldManifest)...)
+	})
+	t.Run("missing NEF", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", newManifest,
+			"--old-nef", oldNEF)...)
+	})
+	t.Run("debug without NEF", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", newManifest,
+			"--old-debug", oldDebug, "--new-debug", newDebug)...)
+	})
+	t.Run("no changes", func(t *testing.T) {
+		e.Run(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", oldManifest,
+			"--old-nef", oldNEF, "--new-nef", oldNEF, "--old-debug", oldDebug, "--new-debug", oldDebug)...)
+		e.CheckNextLine(t, "^No changes$")
+		e.CheckEOF(t)
+	})
+	t.Run("breaking", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", newManifest,
+			"--old-nef", oldNEF, "--new-nef", newNEF)...)
+		out := e.Out.String()
+		require.Contains(t, out, "[breaking] method update/2 removed")
+		require.Contains(t, out, "[compatible] method newMethod/0 added")
+		require.Contains(t, out, "[compatible] script changed")
+	})
+	t.Run("compatible", func(t *testing.T) {
+		data, err := os.ReadFile(oldManifest)
+		require.NoError(t, err)
+		m := new(manifest.Manifest)
+		require.NoError(t, json.Unmarshal(data, m))
+		gm := m.ABI.GetMethod("getValueWithKey", 1)
+		require.NotNil(t, gm)
+		extra := *gm
+		extra.Name = "extra"
+		extra.Parameters = []manifest.Parameter{{Name: "x", Type: gm.Parameters[0].Type}}
+		gm.Parameters[0].Name = "k"
+		m.ABI.Methods = append(m.ABI.Methods, extra)
+		m.SupportedStandards = append(m.SupportedStandards, "NEP-XX")
+		m.Trusts.Restrict()
+		m.Trusts.Add(manifest.PermissionDesc{Type: manifest.PermissionHash, Value: util.Uint160{1, 2, 3}})
+		data, err = json.Marshal(m)
+		require.NoError(t, err)
+		modified := filepath.Join(tmpDir, "modified.manifest.json")
+		require.NoError(t, os.WriteFile(modified, data, os.ModePerm))
+
+		e.Run(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", modified)...)
+		e.CheckNextLine(t, `\[compatible\] method getValueWithKey/1 parameter #0 renamed from key to k`)
+		e.CheckNextLine(t, `\[compatible\] method extra/1 added`)
+		e.CheckNextLine(t, `\[compatible\] supported standard NEP-XX added`)
+		e.CheckNextLine(t, `\[compatible\] trust 0000000000000000000000000000000000030201 added`)
+		e.CheckEOF(t)
+
+		e.RunWithError(t, append(cmd, "--old-manifest", modified, "--new-manifest", oldManifest)...)
+		e.CheckNextLine(t, `\[compatible\] method getValueWithKey/1 parameter #0 renamed from k to key`)
+		e.CheckNextLine(t, `\[breaking\] method extra/1 removed`)
+		e.CheckNextLine(t, `\[breaking\] supported standard NEP-XX removed`)
+		e.CheckNextLine(t, `\[compatible\] trust 0000000000000000000000000000000000030201 removed`)
+		e.CheckEOF(t)
+	})
+	t.Run("storage", func(t *testing.T) {
+		const cfg = "testdata/storagediff/storagediff.yml"
+		oldNEF, oldManifest, oldDebug := compile(t, "testdata/storagediff/old.go", cfg, "storage-old")
+		newNEF, newManifest, newDebug := compile(t, "testdata/storagediff/new.go", cfg, "storage-new")
+
+		e.RunWithError(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", newManifest,
+			"--old-nef", oldNEF, "--new-nef", newNEF)...)
+		e.CheckNextLine(t, `\[compatible\] script changed`)
+		e.CheckNextLine(t, `\[breaking\] storage key prefix "t" is no longer used$`)
+		e.CheckNextLine(t, `\[compatible\] storage key prefix "s" added$`)
+		e.CheckEOF(t)
+
+		// Reordered prefixes are only detected per method.
+		e.RunWithError(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", newManifest,
+			"--old-nef", oldNEF, "--new-nef", newNEF, "--old-debug", oldDebug, "--new-debug", newDebug)...)
+		out := e.Out.String()
+		e.Out.Reset()
+		require.Contains(t, out, `[breaking] storage key prefix "b" is no longer used by BalanceOf`)
+		require.Contains(t, out, `[breaking] storage key prefix "o" is no longer used by Owner`)
+		require.Contains(t, out, `[breaking] storage key prefix "o" is no longer used by SetOwner`)
+		require.Contains(t, out, `[breaking] storage key prefix "t" is no longer used by Total`)
+
+		e.Run(t, append(cmd, "--old-manifest", oldManifest, "--new-manifest", oldManifest,
+			"--old-nef", oldNEF, "--new-nef", oldNEF, "--old-debug", oldDebug, "--new-debug", oldDebug)...)
+		e.CheckNextLine(t, "^No changes$")
+		e.CheckEOF(t)
+	})
+}
+
+func TestContractLint(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	cmd := []string{"neo-go", "contract", "lint"}
+
+	t.Run("missing input", func(t *testing.T) {
+		e.RunWithError(t, cmd...)
+	})
+	t.Run("excessive parameters", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", "testdata/verify.go", "something")...)
+	})
+	t.Run("no issues", func(t *testing.T) {
+		e.Run(t, append(cmd, "--in", "testdata/verify.go", "--config", "testdata/verify.yml")...)
+		e.CheckEOF(t)
+	})
+	t.Run("issues", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", "testdata/deploy/main.go", "--config", "testdata/deploy/neo-go.yml")...)
+		e.CheckNextLine(t, `main.go:55:2: \[unchecked-call\] result of contract.Call is not checked`)
+		e.CheckNextLine(t, `main.go:75:2: \[missing-witness\] storage is modified in public method TestFind`)
+		e.CheckEOF(t)
+	})
 }
 
 func TestCompileExamples(t *testing.T) {
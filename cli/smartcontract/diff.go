@@ -0,0 +1,630 @@
+package smartcontract
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/urfave/cli"
+)
+
+var diffCmd = cli.Command{
+	Name:      "diff",
+	Usage:     "check contract update compatibility",
+	UsageText: "neo-go contract diff --old-manifest <file.json> --new-manifest <file.json> [--old-nef <file.nef> --new-nef <file.nef> [--old-debug <file.json> --new-debug <file.json>]]",
+	Description: `Compares two versions of the contract and reports all changes between them.
+   Changes that can break contract users (removed or changed methods and events,
+   methods that are no longer safe, removed groups and supported standards,
+   contract name change that is not allowed by update) are reported as
+   [breaking], the rest of changes (added methods and events, changed
+   permissions and trusts, changed script and method tokens) are reported as
+   [compatible]. If NEF files are given, they're compared too including
+   constant storage key prefixes used by the code (prefixes that are no longer
+   used are [breaking]) and if debug information files are also given, methods
+   with changed code or storage key prefixes are reported. The command fails
+   if there are breaking changes, so it can be used in CI.`,
+	Action: contractDiff,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "old-manifest",
+			Usage: "Manifest of the deployed contract version",
+		},
+		cli.StringFlag{
+			Name:  "new-manifest",
+			Usage: "Manifest of the new contract version",
+		},
+		cli.StringFlag{
+			Name:  "old-nef",
+			Usage: "NEF file of the deployed contract version",
+		},
+		cli.StringFlag{
+			Name:  "new-nef",
+			Usage: "NEF file of the new contract version",
+		},
+		cli.StringFlag{
+			Name:  "old-debug",
+			Usage: "Debug information file of the deployed contract version",
+		},
+		cli.StringFlag{
+			Name:  "new-debug",
+			Usage: "Debug information file of the new contract version",
+		},
+	},
+}
+
+// contractChange is a single difference between contract versions.
+type contractChange struct {
+	breaking bool
+	msg      string
+}
+
+func (c contractChange) String() string {
+	if c.breaking {
+		return "[breaking] " + c.msg
+	}
+	return "[compatible] " + c.msg
+}
+
+// contractChanges collects differences between contract versions.
+type contractChanges []contractChange
+
+func (cs *contractChanges) add(breaking bool, format string, args ...any) {
+	*cs = append(*cs, contractChange{breaking: breaking, msg: fmt.Sprintf(format, args...)})
+}
+
+func contractDiff(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	var (
+		oldNEF, newNEF     = ctx.String("old-nef"), ctx.String("new-nef")
+		oldDebug, newDebug = ctx.String("old-debug"), ctx.String("new-debug")
+	)
+	if len(ctx.String("old-manifest")) == 0 || len(ctx.String("new-manifest")) == 0 {
+		return cli.NewExitError(errors.New("both manifests should be provided"), 1)
+	}
+	if (len(oldNEF) == 0) != (len(newNEF) == 0) {
+		return cli.NewExitError(errors.New("both NEF files should be provided"), 1)
+	}
+	if (len(oldDebug) == 0) != (len(newDebug) == 0) {
+		return cli.NewExitError(errors.New("both debug information files should be provided"), 1)
+	}
+	if len(oldDebug) != 0 && len(oldNEF) == 0 {
+		return cli.NewExitError(errors.New("debug information files can only be compared with NEF files"), 1)
+	}
+	oldM, _, err := readManifest(ctx.String("old-manifest"), util.Uint160{})
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't read old manifest: %w", err), 1)
+	}
+	newM, _, err := readManifest(ctx.String("new-manifest"), util.Uint160{})
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't read new manifest: %w", err), 1)
+	}
+
+	var changes contractChanges
+	changes.diffManifests(oldM, newM)
+	if len(oldNEF) != 0 {
+		oldN, _, err := readNEFFile(oldNEF)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't read old NEF file: %w", err), 1)
+		}
+		newN, _, err := readNEFFile(newNEF)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't read new NEF file: %w", err), 1)
+		}
+		changes.diffNEFs(oldN, newN)
+		if err := changes.diffStorage(oldN.Script, newN.Script); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if len(oldDebug) != 0 {
+			oldDI, err := readDebugInfo(oldDebug)
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("can't read old debug information: %w", err), 1)
+			}
+			newDI, err := readDebugInfo(newDebug)
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("can't read new debug information: %w", err), 1)
+			}
+			if err := changes.diffCode(oldN.Script, newN.Script, oldDI, newDI); err != nil {
+				return cli.NewExitError(err, 1)
+			}
+		}
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "No changes")
+		return nil
+	}
+	var breaking int
+	for _, c := range changes {
+		fmt.Fprintln(ctx.App.Writer, c)
+		if c.breaking {
+			breaking++
+		}
+	}
+	if breaking != 0 {
+		return cli.NewExitError(fmt.Errorf("%d breaking change(s) found", breaking), 1)
+	}
+	return nil
+}
+
+func readDebugInfo(filename string) (*compiler.DebugInfo, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	di := new(compiler.DebugInfo)
+	if err := json.Unmarshal(data, di); err != nil {
+		return nil, err
+	}
+	return di, nil
+}
+
+func (cs *contractChanges) diffManifests(oldM, newM *manifest.Manifest) {
+	if oldM.Name != newM.Name {
+		cs.add(true, "contract name changed from %q to %q, update is not possible", oldM.Name, newM.Name)
+	}
+	cs.diffMethods(oldM.ABI.Methods, newM.ABI.Methods)
+	cs.diffEvents(oldM.ABI.Events, newM.ABI.Events)
+
+	removed, added := diffStrings(oldM.SupportedStandards, newM.SupportedStandards)
+	for _, s := range removed {
+		cs.add(true, "supported standard %s removed", s)
+	}
+	for _, s := range added {
+		cs.add(false, "supported standard %s added", s)
+	}
+
+	groups := func(gs []manifest.Group) []string {
+		res := make([]string, len(gs))
+		for i := range gs {
+			res[i] = hex.EncodeToString(gs[i].PublicKey.Bytes())
+		}
+		return res
+	}
+	removed, added = diffStrings(groups(oldM.Groups), groups(newM.Groups))
+	for _, g := range removed {
+		cs.add(true, "group %s removed", g)
+	}
+	for _, g := range added {
+		cs.add(false, "group %s added", g)
+	}
+
+	perms := func(ps []manifest.Permission) []string {
+		res := make([]string, len(ps))
+		for i := range ps {
+			res[i] = permissionString(ps[i])
+		}
+		return res
+	}
+	removed, added = diffStrings(perms(oldM.Permissions), perms(newM.Permissions))
+	for _, p := range removed {
+		cs.add(false, "permission %s removed", p)
+	}
+	for _, p := range added {
+		cs.add(false, "permission %s added", p)
+	}
+
+	trusts := func(ts manifest.WildPermissionDescs) []string {
+		if ts.IsWildcard() {
+			return []string{"*"}
+		}
+		res := make([]string, len(ts.Value))
+		for i := range ts.Value {
+			res[i] = permissionDescString(ts.Value[i])
+		}
+		return res
+	}
+	removed, added = diffStrings(trusts(oldM.Trusts), trusts(newM.Trusts))
+	for _, t := range removed {
+		cs.add(false, "trust %s removed", t)
+	}
+	for _, t := range added {
+		cs.add(false, "trust %s added", t)
+	}
+}
+
+// methodKey identifies methods that can be overloaded by the number of parameters.
+func methodKey(m manifest.Method) string {
+	return m.Name + "/" + strconv.Itoa(len(m.Parameters))
+}
+
+func (cs *contractChanges) diffMethods(oldMs, newMs []manifest.Method) {
+	newByKey := make(map[string]manifest.Method, len(newMs))
+	for _, m := range newMs {
+		newByKey[methodKey(m)] = m
+	}
+	oldKeys := make(map[string]bool, len(oldMs))
+	for _, om := range oldMs {
+		key := methodKey(om)
+		oldKeys[key] = true
+		nm, ok := newByKey[key]
+		if !ok {
+			cs.add(true, "method %s removed", key)
+			continue
+		}
+		if om.ReturnType != nm.ReturnType {
+			cs.add(true, "method %s return type changed from %s to %s", key, om.ReturnType, nm.ReturnType)
+		}
+		cs.diffParameters("method "+key, om.Parameters, nm.Parameters)
+		switch {
+		case om.Safe && !nm.Safe:
+			cs.add(true, "method %s is no longer safe", key)
+		case !om.Safe && nm.Safe:
+			cs.add(false, "method %s is safe now", key)
+		}
+	}
+	for _, nm := range newMs {
+		if key := methodKey(nm); !oldKeys[key] {
+			cs.add(false, "method %s added", key)
+		}
+	}
+}
+
+func (cs *contractChanges) diffEvents(oldEs, newEs []manifest.Event) {
+	newByName := make(map[string]manifest.Event, len(newEs))
+	for _, e := range newEs {
+		newByName[e.Name] = e
+	}
+	oldNames := make(map[string]bool, len(oldEs))
+	for _, oe := range oldEs {
+		oldNames[oe.Name] = true
+		ne, ok := newByName[oe.Name]
+		if !ok {
+			cs.add(true, "event %s removed", oe.Name)
+			continue
+		}
+		if len(oe.Parameters) != len(ne.Parameters) {
+			cs.add(true, "event %s parameter count changed from %d to %d", oe.Name, len(oe.Parameters), len(ne.Parameters))
+			continue
+		}
+		cs.diffParameters("event "+oe.Name, oe.Parameters, ne.Parameters)
+	}
+	for _, ne := range newEs {
+		if !oldNames[ne.Name] {
+			cs.add(false, "event %s added", ne.Name)
+		}
+	}
+}
+
+// diffParameters compares parameters of the same count.
+func (cs *contractChanges) diffParameters(what string, oldPs, newPs []manifest.Parameter) {
+	for i := range oldPs {
+		if oldPs[i].Type != newPs[i].Type {
+			cs.add(true, "%s parameter #%d (%s) type changed from %s to %s", what, i, oldPs[i].Name, oldPs[i].Type, newPs[i].Type)
+		}
+		if oldPs[i].Name != newPs[i].Name {
+			cs.add(false, "%s parameter #%d renamed from %s to %s", what, i, oldPs[i].Name, newPs[i].Name)
+		}
+	}
+}
+
+func (cs *contractChanges) diffNEFs(oldN, newN *nef.File) {
+	if oldN.Compiler != newN.Compiler {
+		cs.add(false, "compiler changed from %s to %s", oldN.Compiler, newN.Compiler)
+	}
+	if oldN.Source != newN.Source {
+		cs.add(false, "source changed from %q to %q", oldN.Source, newN.Source)
+	}
+	tokens := func(ts []nef.MethodToken) []string {
+		res := make([]string, len(ts))
+		for i, t := range ts {
+			res[i] = fmt.Sprintf("%s.%s/%d (%s)", t.Hash.StringLE(), t.Method, t.ParamCount, t.CallFlag)
+		}
+		return res
+	}
+	removed, added := diffStrings(tokens(oldN.Tokens), tokens(newN.Tokens))
+	for _, t := range removed {
+		cs.add(false, "method token %s removed", t)
+	}
+	for _, t := range added {
+		cs.add(false, "method token %s added", t)
+	}
+	if !bytes.Equal(oldN.Script, newN.Script) {
+		cs.add(false, "script changed (%d -> %d bytes)", len(oldN.Script), len(newN.Script))
+	}
+}
+
+// diffCode reports methods with changed code. Jump and call offsets are not
+// compared as they change when other code moves.
+func (cs *contractChanges) diffCode(oldS, newS []byte, oldDI, newDI *compiler.DebugInfo) error {
+	newByID := make(map[string]compiler.MethodDebugInfo, len(newDI.Methods))
+	for _, m := range newDI.Methods {
+		newByID[m.ID] = m
+	}
+	for _, om := range oldDI.Methods {
+		nm, ok := newByID[om.ID]
+		if !ok {
+			continue
+		}
+		oldCode, err := methodCode(oldS, om.Range)
+		if err != nil {
+			return fmt.Errorf("old method %s: %w", om.ID, err)
+		}
+		newCode, err := methodCode(newS, nm.Range)
+		if err != nil {
+			return fmt.Errorf("new method %s: %w", nm.ID, err)
+		}
+		if !bytes.Equal(oldCode, newCode) {
+			cs.add(false, "code of %s changed", om.ID)
+		}
+		oldKeys, err := storagePrefixes(oldS, int(om.Range.Start), int(om.Range.End))
+		if err != nil {
+			return fmt.Errorf("old method %s: %w", om.ID, err)
+		}
+		newKeys, err := storagePrefixes(newS, int(nm.Range.Start), int(nm.Range.End))
+		if err != nil {
+			return fmt.Errorf("new method %s: %w", nm.ID, err)
+		}
+		removed, _ := diffStrings(oldKeys, newKeys)
+		for _, k := range removed {
+			cs.add(true, "storage key prefix %s is no longer used by %s", k, om.ID)
+		}
+	}
+	return nil
+}
+
+// diffStorage reports constant storage key prefixes that are no longer used
+// or new ones. Data stored by the old version with removed (or renumbered)
+// prefixes becomes inaccessible after update.
+func (cs *contractChanges) diffStorage(oldS, newS []byte) error {
+	oldKeys, err := storagePrefixes(oldS, 0, len(oldS)-1)
+	if err != nil {
+		return fmt.Errorf("old script: %w", err)
+	}
+	newKeys, err := storagePrefixes(newS, 0, len(newS)-1)
+	if err != nil {
+		return fmt.Errorf("new script: %w", err)
+	}
+	removed, added := diffStrings(oldKeys, newKeys)
+	for _, k := range removed {
+		cs.add(true, "storage key prefix %s is no longer used", k)
+	}
+	for _, k := range added {
+		cs.add(false, "storage key prefix %s added", k)
+	}
+	return nil
+}
+
+// instruction is a single decoded VM instruction.
+type instruction struct {
+	op    opcode.Opcode
+	param []byte
+}
+
+// storageSyscalls are storage syscalls taking a key (or prefix) as the
+// second parameter mapped to the number of their parameters.
+var storageSyscalls = map[uint32]int{
+	interopnames.ToID([]byte(interopnames.SystemStorageGet)):    2,
+	interopnames.ToID([]byte(interopnames.SystemStorageDelete)): 2,
+	interopnames.ToID([]byte(interopnames.SystemStoragePut)):    3,
+	interopnames.ToID([]byte(interopnames.SystemStorageFind)):   3,
+}
+
+// storagePrefixes returns sorted constant storage key prefixes used by the
+// code between start and end offsets (inclusive). Only keys that are constants or concatenations
+// starting with a constant are detected, keys built in other ways are
+// ignored.
+func storagePrefixes(script []byte, start, end int) ([]string, error) {
+	if len(script) == 0 {
+		return nil, nil
+	}
+	if end >= len(script) || start > end {
+		return nil, fmt.Errorf("invalid range %d-%d", start, end)
+	}
+	var (
+		ins  []instruction
+		keys = make(map[string]bool)
+		ctx  = vm.NewContext(script)
+	)
+	ctx.Jump(start)
+	for ctx.NextIP() <= end {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, err
+		}
+		ins = append(ins, instruction{op: op, param: param})
+		if op != opcode.SYSCALL {
+			continue
+		}
+		n, ok := storageSyscalls[binary.LittleEndian.Uint32(param)]
+		if !ok {
+			continue
+		}
+		// Parameters are pushed in order and reversed before the call.
+		i := len(ins) - 2
+		if i < 0 || n == 2 && ins[i].op != opcode.SWAP || n == 3 && ins[i].op != opcode.REVERSE3 {
+			continue
+		}
+		end := i - 1
+		if n == 3 {
+			end = exprStart(ins, end) - 1
+		}
+		if end < 0 {
+			continue
+		}
+		if p, ok := keyPrefix(ins, end); ok && len(p) != 0 {
+			keys[storagePrefixString(p)] = true
+		}
+	}
+	res := make([]string, 0, len(keys))
+	for k := range keys {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// keyPrefix returns the constant prefix of the key computed by the
+// expression ending at ins[end].
+func keyPrefix(ins []instruction, end int) ([]byte, bool) {
+	switch op := ins[end].op; {
+	case opcode.PUSHDATA1 <= op && op <= opcode.PUSHDATA4:
+		return ins[end].param, true
+	case op == opcode.CONVERT && isCheckedConvert(ins, end) && end > 3:
+		return keyPrefix(ins, end-4)
+	case op == opcode.CONVERT && end > 0:
+		return keyPrefix(ins, end-1)
+	case op == opcode.CAT && end > 0:
+		right := exprStart(ins, end-1)
+		if right <= 0 {
+			return nil, false
+		}
+		return keyPrefix(ins, right-1)
+	}
+	return nil, false
+}
+
+// exprStart returns the index of the first instruction of a simple expression
+// ending at ins[end] or -1 if it can't be determined.
+func exprStart(ins []instruction, end int) int {
+	var need = 1
+	for i := end; i >= 0; i-- {
+		if isCheckedConvert(ins, i) {
+			i -= 3 // Doesn't change the stack depth.
+			continue
+		}
+		pop, push, ok := stackEffect(ins[i])
+		if !ok || push > need {
+			return -1
+		}
+		need += pop - push
+		if need == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// isCheckedConvert checks whether ins[i] ends DUP, ISTYPE, JMPIF, CONVERT
+// sequence the compiler emits for type conversions.
+func isCheckedConvert(ins []instruction, i int) bool {
+	return i >= 3 && ins[i].op == opcode.CONVERT && ins[i-1].op == opcode.JMPIF &&
+		ins[i-2].op == opcode.ISTYPE && ins[i-3].op == opcode.DUP
+}
+
+// stackEffect returns the number of items popped and pushed by simple
+// instructions that can be a part of storage key computation.
+func stackEffect(in instruction) (int, int, bool) {
+	switch op := in.op; {
+	case op <= opcode.PUSHINT256, opcode.PUSHT <= op && op <= opcode.PUSH16 && op != opcode.PUSHA,
+		opcode.LDSFLD0 <= op && op <= opcode.LDSFLD, opcode.LDLOC0 <= op && op <= opcode.LDLOC,
+		opcode.LDARG0 <= op && op <= opcode.LDARG:
+		return 0, 1, true
+	case op == opcode.CONVERT, op == opcode.SIZE, op == opcode.INC, op == opcode.DEC, op == opcode.NEGATE:
+		return 1, 1, true
+	case op == opcode.CAT, op == opcode.ADD, op == opcode.SUB, op == opcode.MUL, op == opcode.PICKITEM:
+		return 2, 1, true
+	case op == opcode.SYSCALL:
+		switch binary.LittleEndian.Uint32(in.param) {
+		case interopnames.ToID([]byte(interopnames.SystemStorageGetContext)),
+			interopnames.ToID([]byte(interopnames.SystemStorageGetReadOnlyContext)):
+			return 0, 1, true
+		case interopnames.ToID([]byte(interopnames.SystemStorageAsReadOnly)):
+			return 1, 1, true
+		}
+	}
+	return 0, 0, false
+}
+
+// storagePrefixString returns printable prefixes as quoted strings and hex
+// for the others.
+func storagePrefixString(p []byte) string {
+	if utf8.Valid(p) && strings.IndexFunc(string(p), func(r rune) bool { return !unicode.IsPrint(r) }) == -1 {
+		return strconv.Quote(string(p))
+	}
+	return "0x" + hex.EncodeToString(p)
+}
+
+// methodCode returns instructions of the method without jump offsets.
+func methodCode(script []byte, rng compiler.DebugRange) ([]byte, error) {
+	if int(rng.End) >= len(script) || rng.Start > rng.End {
+		return nil, fmt.Errorf("invalid method range %d-%d", rng.Start, rng.End)
+	}
+	var (
+		res []byte
+		ctx = vm.NewContext(script)
+	)
+	ctx.Jump(int(rng.Start))
+	for ctx.NextIP() <= int(rng.End) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, byte(op))
+		if !hasOffsetParam(op) {
+			res = append(res, param...)
+		}
+	}
+	return res, nil
+}
+
+func hasOffsetParam(op opcode.Opcode) bool {
+	return opcode.JMP <= op && op <= opcode.CALLL || op == opcode.PUSHA ||
+		op == opcode.TRY || op == opcode.TRYL || op == opcode.ENDTRY || op == opcode.ENDTRYL
+}
+
+func permissionDescString(d manifest.PermissionDesc) string {
+	switch d.Type {
+	case manifest.PermissionHash:
+		return d.Hash().StringLE()
+	case manifest.PermissionGroup:
+		return hex.EncodeToString(d.Group().Bytes())
+	default:
+		return "*"
+	}
+}
+
+func permissionString(p manifest.Permission) string {
+	methods := "*"
+	if !p.Methods.IsWildcard() {
+		ms := append([]string{}, p.Methods.Value...)
+		sort.Strings(ms)
+		methods = "[" + strings.Join(ms, ", ") + "]"
+	}
+	return permissionDescString(p.Contract) + ":" + methods
+}
+
+// diffStrings returns sorted elements removed from and added to old.
+func diffStrings(old, new []string) ([]string, []string) {
+	var (
+		inOld = make(map[string]bool, len(old))
+		inNew = make(map[string]bool, len(new))
+
+		removed, added []string
+	)
+	for _, s := range old {
+		inOld[s] = true
+	}
+	for _, s := range new {
+		inNew[s] = true
+		if !inOld[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range old {
+		if !inNew[s] {
+			removed = append(removed, s)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+	return removed, added
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/pybinding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/rpcbinding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/tsbinding"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v3"
@@ -34,6 +36,14 @@ var generatorFlags = []cli.Flag{
 	},
 }
 
+var rpcWrapperFlags = append([]cli.Flag{
+	cli.StringFlag{
+		Name:  "lang",
+		Value: "go",
+		Usage: "Language of the resulting wrapper: go, ts (TypeScript, neon-js) or py (Python, neo-mamba)",
+	},
+}, generatorFlags...)
+
 var generateWrapperCmd = cli.Command{
 	Name:        "generate-wrapper",
 	Usage:       "generate wrapper to use in other contracts",
@@ -46,9 +56,9 @@ var generateWrapperCmd = cli.Command{
 var generateRPCWrapperCmd = cli.Command{
 	Name:      "generate-rpcwrapper",
 	Usage:     "generate RPC wrapper to use for data reads",
-	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]",
+	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>] [--lang go|ts|py]",
 	Action:    contractGenerateRPCWrapper,
-	Flags:     generatorFlags,
+	Flags:     rpcWrapperFlags,
 }
 
 func contractGenerateWrapper(ctx *cli.Context) error {
@@ -56,7 +66,18 @@ func contractGenerateWrapper(ctx *cli.Context) error {
 }
 
 func contractGenerateRPCWrapper(ctx *cli.Context) error {
-	return contractGenerateSomething(ctx, rpcbinding.Generate, true)
+	var gen func(binding.Config) error
+	switch lang := ctx.String("lang"); lang {
+	case "go":
+		gen = rpcbinding.Generate
+	case "ts":
+		gen = tsbinding.Generate
+	case "py":
+		gen = pybinding.Generate
+	default:
+		return cli.NewExitError(fmt.Errorf("unsupported wrapper language: %s", lang), 1)
+	}
+	return contractGenerateSomething(ctx, gen, true)
 }
 
 // contractGenerateSomething reads generator parameters and calls the given callback.
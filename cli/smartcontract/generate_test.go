@@ -454,6 +454,68 @@ func TestAssistedRPCBindings(t *testing.T) {
 	require.False(t, rewriteExpectedOutputs)
 }
 
+func TestGenerateRPCBindingsLang(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := cli.NewApp()
+	app.Commands = NewCommands()
+
+	var checkBinding = func(args []string, good string) {
+		t.Run(good, func(t *testing.T) {
+			outFile := filepath.Join(tmpDir, "out")
+			require.NoError(t, app.Run(append([]string{"", "contract", "generate-rpcwrapper",
+				"--lang", strings.TrimPrefix(filepath.Ext(good), "."),
+				"--out", outFile,
+			}, args...)))
+
+			data, err := os.ReadFile(outFile)
+			require.NoError(t, err)
+			data = bytes.ReplaceAll(data, []byte("\r"), []byte{}) // Windows.
+			if rewriteExpectedOutputs {
+				require.NoError(t, os.WriteFile(good, data, os.ModePerm))
+			} else {
+				expected, err := os.ReadFile(good)
+				require.NoError(t, err)
+				expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+				require.Equal(t, string(expected), string(data))
+			}
+		})
+	}
+
+	source := filepath.Join("testdata", "rpcbindings", "structs")
+	manifestF := filepath.Join(tmpDir, "manifest.json")
+	bindingF := filepath.Join(tmpDir, "binding.yml")
+	require.NoError(t, app.Run([]string{"", "contract", "compile",
+		"--in", source,
+		"--config", filepath.Join(source, "config.yml"),
+		"--manifest", manifestF,
+		"--bindings", bindingF,
+		"--out", filepath.Join(tmpDir, "out.nef"),
+	}))
+
+	for _, lang := range []string{"ts", "py"} {
+		checkBinding([]string{
+			"--manifest", filepath.Join("testdata", "nex", "nex.manifest.json"),
+			"--hash", "0xa2a67f09e8cf22c6bfd5cea24adc0f4bf0a11aa8",
+		}, filepath.Join("testdata", "nex", "nex."+lang))
+		checkBinding([]string{
+			"--manifest", manifestF,
+			"--config", bindingF,
+		}, filepath.Join(source, "rpcbindings."+lang))
+	}
+
+	t.Run("unsupported language", func(t *testing.T) {
+		app.ExitErrHandler = func(*cli.Context, error) {}
+		err := app.Run([]string{"", "contract", "generate-rpcwrapper",
+			"--lang", "rust",
+			"--manifest", manifestF,
+			"--out", filepath.Join(tmpDir, "out"),
+		})
+		require.ErrorContains(t, err, "unsupported wrapper language: rust")
+	})
+
+	require.False(t, rewriteExpectedOutputs)
+}
+
 func TestGenerate_Errors(t *testing.T) {
 	app := cli.NewApp()
 	app.Commands = []cli.Command{generateWrapperCmd}
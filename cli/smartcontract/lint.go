@@ -0,0 +1,70 @@
+package smartcontract
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/urfave/cli"
+)
+
+var lintCmd = cli.Command{
+	Name:      "lint",
+	Usage:     "check a smart contract for common security problems",
+	UsageText: "neo-go contract lint -i path [-c yaml]",
+	Description: `Compiles given smart contract and analyzes its code looking for storage
+   modifications in public methods without prior witness checks, storage
+   modifications after calls to other contracts (reentrancy), ignored results
+   of contract calls and storage keys clashing with other prefixes. If
+   configuration file is given, manifest permissions are also checked to
+   allow only the calls actually made by the contract. Analysis is heuristic,
+   it doesn't take branches into account, so every reported issue needs to
+   be reviewed. The command fails if any issues are found.`,
+	Action: contractLint,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "in, i",
+			Usage: "Input file for the smart contract to be checked (*.go file or directory)",
+		},
+		cli.StringFlag{
+			Name:  "config, c",
+			Usage: "Configuration input file (*.yml)",
+		},
+	},
+}
+
+func contractLint(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	src := ctx.String("in")
+	if len(src) == 0 {
+		return cli.NewExitError(errNoInput, 1)
+	}
+	var o *compiler.Options
+	if confFile := ctx.String("config"); len(confFile) != 0 {
+		conf, err := ParseContractConfig(confFile)
+		if err != nil {
+			return err
+		}
+		o = &compiler.Options{
+			Name:        conf.Name,
+			Permissions: make([]manifest.Permission, len(conf.Permissions)),
+		}
+		for i := range conf.Permissions {
+			o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+		}
+	}
+	issues, err := compiler.Lint(src, nil, o)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	for _, i := range issues {
+		fmt.Fprintln(ctx.App.Writer, i)
+	}
+	if len(issues) != 0 {
+		return cli.NewExitError(fmt.Errorf("%d issue(s) found", len(issues)), 1)
+	}
+	return nil
+}
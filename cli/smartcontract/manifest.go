@@ -4,13 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/urfave/cli"
@@ -46,7 +50,10 @@ func manifestAddGroup(ctx *cli.Context) error {
 
 	var found bool
 
-	sig := gAcc.PrivateKey().Sign(h.BytesBE())
+	sig := gAcc.SignHash(hash.Sha256(h.BytesBE()))
+	if sig == nil {
+		return cli.NewExitError("can't sign group with the given account", 1)
+	}
 	pub := gAcc.PublicKey()
 	for i := range m.Groups {
 		if m.Groups[i].PublicKey.Equal(pub) {
@@ -115,3 +122,16 @@ func readManifest(filename string, hash util.Uint160) (*manifest.Manifest, []byt
 	}
 	return m, manifestBytes, nil
 }
+
+// printStandards prints standards declared by the manifest and the ones it
+// actually complies with.
+func printStandards(w io.Writer, m *manifest.Manifest) {
+	var list = func(ss []string) string {
+		if len(ss) == 0 {
+			return "none"
+		}
+		return strings.Join(ss, ", ")
+	}
+	fmt.Fprintln(w, "Declared standards:", list(m.SupportedStandards))
+	fmt.Fprintln(w, "Compliant standards:", list(standard.Compliant(m)))
+}
@@ -1,9 +1,11 @@
 package smartcontract
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -130,3 +132,46 @@ func (p *permission) fillMethods(m map[string]any) error {
 	}
 	return errors.New("'methods' field is invalid")
 }
+
+// writeConfigPermissions replaces permissions in the contract configuration
+// file with perms keeping the rest of the file intact.
+func writeConfigPermissions(confFile string, perms []manifest.Permission) error {
+	data, err := os.ReadFile(confFile)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("bad config: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return errors.New("bad config: mapping expected")
+	}
+	ps := make([]permission, len(perms))
+	for i := range perms {
+		ps[i] = permission(perms[i])
+	}
+	val := new(yaml.Node)
+	if err := val.Encode(ps); err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	i := 0
+	for ; i < len(root.Content); i += 2 {
+		if root.Content[i].Value == "permissions" {
+			root.Content[i+1] = val
+			break
+		}
+	}
+	if i >= len(root.Content) {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "permissions"}, val)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return os.WriteFile(confFile, buf.Bytes(), os.ModePerm)
+}
@@ -1,6 +1,7 @@
 package smartcontract
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -130,7 +131,7 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "compile",
 				Usage:     "compile a smart contract to a .nef file",
-				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes]",
+				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes] [--guess-permissions] [--optimize]",
 				Description: `Compiles given smart contract to a .nef file and emits other associated
    information (manifest, bindings configuration, debug information files) if
    asked to. If none of --out, --manifest, --config, --bindings flags are specified,
@@ -184,8 +185,18 @@ func NewCommands() []cli.Command {
 						Name:  "bindings",
 						Usage: "output file for smart-contract bindings configuration",
 					},
+					cli.BoolFlag{
+						Name:  "guess-permissions",
+						Usage: "replace permissions in the manifest and configuration file with the minimal set allowing the contract calls made by the code",
+					},
+					cli.BoolFlag{
+						Name:  "optimize",
+						Usage: "perform additional optimization of the resulting script",
+					},
 				},
 			},
+			lintCmd,
+			diffCmd,
 			{
 				Name:      "deploy",
 				Usage:     "deploy a smart contract (.nef with description)",
@@ -266,7 +277,7 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "inspect",
 				Usage:     "creates a user readable dump of the program instructions",
-				UsageText: "neo-go contract inspect -i file [-c]",
+				UsageText: "neo-go contract inspect -i file [-c] [-m manifest]",
 				Action:    inspect,
 				Flags: []cli.Flag{
 					cli.BoolFlag{
@@ -277,6 +288,10 @@ func NewCommands() []cli.Command {
 						Name:  "in, i",
 						Usage: "input file of the program (either .go or .nef)",
 					},
+					cli.StringFlag{
+						Name:  "manifest, m",
+						Usage: "contract manifest (*.manifest.json) file to report declared and compliant standards for",
+					},
 				},
 			},
 			{
@@ -446,7 +461,12 @@ func contractCompile(ctx *cli.Context) error {
 		NoEventsCheck:      ctx.Bool("no-events"),
 		NoPermissionsCheck: ctx.Bool("no-permissions"),
 
-		GuessEventTypes: ctx.Bool("guess-eventtypes"),
+		GuessEventTypes:  ctx.Bool("guess-eventtypes"),
+		GuessPermissions: ctx.Bool("guess-permissions"),
+		Optimize:         ctx.Bool("optimize"),
+		Warnf: func(format string, args ...any) {
+			fmt.Fprintf(ctx.App.ErrWriter, "Warning: "+format+"\n", args...)
+		},
 	}
 
 	if len(confFile) != 0 {
@@ -467,11 +487,33 @@ func contractCompile(ctx *cli.Context) error {
 		o.Overloads = conf.Overloads
 	}
 
+	configured, err := json.Marshal(o.Permissions)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
 	result, err := compiler.CompileAndSave(src, o)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
+	if o.GuessPermissions && len(confFile) != 0 {
+		guessed, err := json.Marshal(o.Permissions)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if !bytes.Equal(configured, guessed) {
+			if err := writeConfigPermissions(confFile, o.Permissions); err != nil {
+				return cli.NewExitError(fmt.Errorf("can't update permissions in configuration file: %w", err), 1)
+			}
+		}
+	}
 	if ctx.Bool("verbose") {
+		if len(manifestFile) != 0 {
+			m, _, err := readManifest(manifestFile, util.Uint160{})
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("can't read contract manifest: %w", err), 1)
+			}
+			printStandards(ctx.App.Writer, m)
+		}
 		fmt.Fprintln(ctx.App.Writer, hex.EncodeToString(result))
 	}
 
@@ -713,9 +755,20 @@ func inspect(ctx *cli.Context) error {
 	}
 	in := ctx.String("in")
 	compile := ctx.Bool("compile")
-	if len(in) == 0 {
+	manifestFile := ctx.String("manifest")
+	if len(in) == 0 && len(manifestFile) == 0 {
 		return cli.NewExitError(errNoInput, 1)
 	}
+	if len(manifestFile) != 0 {
+		m, _, err := readManifest(manifestFile, util.Uint160{})
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't read contract manifest: %w", err), 1)
+		}
+		printStandards(ctx.App.Writer, m)
+		if len(in) == 0 {
+			return nil
+		}
+	}
 	var (
 		b   []byte
 		err error
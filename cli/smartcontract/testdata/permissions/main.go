@@ -0,0 +1,16 @@
+package permissions
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+)
+
+func Transfer(to interop.Hash160, amount int) bool {
+	return neo.Transfer(runtime.GetExecutingScriptHash(), to, amount, nil)
+}
+
+func Invoke(h interop.Hash160, method string) any {
+	return contract.Call(h, method, contract.All)
+}
@@ -0,0 +1,32 @@
+package storagediff
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+const (
+	balancePrefix = "b"
+	ownerKey      = "o"
+	totalKey      = "t"
+)
+
+// BalanceOf returns the balance of the account.
+func BalanceOf(acc interop.Hash160) int {
+	return storage.Get(storage.GetReadOnlyContext(), balancePrefix+string(acc)).(int)
+}
+
+// Owner returns the contract owner.
+func Owner() interop.Hash160 {
+	return storage.Get(storage.GetReadOnlyContext(), ownerKey).(interop.Hash160)
+}
+
+// SetOwner sets the contract owner.
+func SetOwner(owner interop.Hash160) {
+	storage.Put(storage.GetContext(), ownerKey, owner)
+}
+
+// Total returns the total supply.
+func Total() int {
+	return storage.Get(storage.GetReadOnlyContext(), totalKey).(int)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	vmcli "github.com/nspcc-dev/neo-go/cli/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -84,6 +85,44 @@ func NewCommands() []cli.Command {
    binary (or hex- or base64-encoded) transactions. If --rpc-endpoint flag is specified the result 
    of the given script after running it true the VM will be printed. Otherwise only transaction will
    be printed.`,
+				},
+				{
+					Name:      "qrexport",
+					Usage:     "Export parameter context as QR codes",
+					UsageText: "qrexport [--png <prefix>] [--chunk-size <bytes>] <file.in>",
+					Action:    qrExport,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "png",
+							Usage: "save QR codes into <prefix>-<index>.png files instead of printing them",
+						},
+						cli.IntFlag{
+							Name:  "chunk-size",
+							Usage: "maximum number of context data bytes per QR code (at least 16)",
+							Value: paramcontext.DefaultQRChunkSize,
+						},
+					},
+					Description: `Converts the given parameter context (JSON) file into a compact binary
+   form and splits it into a series of QR codes that are printed to the terminal
+   (expecting dark background) or saved into PNG files. They can be imported with
+   'qrimport' command or used directly with 'wallet sign --qr' on another machine
+   without any other data transfer channel (like USB drives).`,
+				},
+				{
+					Name:      "qrimport",
+					Usage:     "Import parameter context from QR codes",
+					UsageText: "qrimport --out <file.out> [<qr-data> ...]",
+					Action:    qrImport,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "out",
+							Usage: "file to save parameter context (JSON) to",
+						},
+					},
+					Description: `Restores parameter context from the contents of QR codes created by
+   'qrexport' (or 'wallet sign --qr') and saves it into a JSON file. QR code
+   contents (as returned by a scanner) can be passed as arguments (in any order),
+   missing ones are requested interactively one per line.`,
 				},
 				{
 					Name:      "ops",
@@ -0,0 +1,50 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/urfave/cli"
+)
+
+func qrExport(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) == 0 {
+		return cli.NewExitError("missing input file", 1)
+	} else if len(args) > 1 {
+		return cli.NewExitError("only one input file is accepted", 1)
+	}
+	c, err := paramcontext.Read(args[0])
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if prefix := ctx.String("png"); prefix != "" {
+		names, err := paramcontext.SaveQR(c, prefix, ctx.Int("chunk-size"))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		for _, name := range names {
+			fmt.Fprintln(ctx.App.Writer, name)
+		}
+		return nil
+	}
+	if err := paramcontext.WriteQR(ctx.App.Writer, c, ctx.Int("chunk-size")); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
+func qrImport(ctx *cli.Context) error {
+	out := ctx.String("out")
+	if out == "" {
+		return cli.NewExitError("no output file given", 1)
+	}
+	c, err := paramcontext.ReadQR(ctx.Args())
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err := paramcontext.Save(c, out); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
@@ -1,12 +1,14 @@
 package util_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -189,3 +191,83 @@ func TestAwaitUtilCancelTx(t *testing.T) {
 		t.Fatalf("unexpected response: %s", response)
 	}
 }
+
+func TestUtilQR(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmp := t.TempDir()
+	txPath := filepath.Join(tmp, "tx.json")
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet, "--from", testcli.ValidatorAddr,
+		"--to", testcli.ValidatorPriv.Address(), "--token", "NEO", "--amount", "1",
+		"--out", txPath)
+	pc, err := paramcontext.Read(txPath)
+	require.NoError(t, err)
+
+	t.Run("export, bad", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "qrexport")
+		e.RunWithError(t, "neo-go", "util", "qrexport", txPath, txPath)
+		e.RunWithError(t, "neo-go", "util", "qrexport", filepath.Join(tmp, "unknown.json"))
+		e.RunWithError(t, "neo-go", "util", "qrexport", "--chunk-size", "0", txPath)
+		e.RunWithError(t, "neo-go", "util", "qrexport", "--chunk-size", "8", txPath)
+	})
+	t.Run("export to terminal", func(t *testing.T) {
+		e.Run(t, "neo-go", "util", "qrexport", "--chunk-size", "100", txPath)
+		chunks, err := paramcontext.EncodeQRChunks(pc, 100)
+		require.NoError(t, err)
+		require.True(t, len(chunks) > 1)
+		out := e.Out.String()
+		for i := range chunks {
+			require.Contains(t, out, fmt.Sprintf("QR code %d/%d:\n", i+1, len(chunks)))
+		}
+		require.Contains(t, out, "█")
+		e.Out.Reset()
+	})
+	t.Run("export to PNG", func(t *testing.T) {
+		prefix := filepath.Join(tmp, "ctx")
+		e.Run(t, "neo-go", "util", "qrexport", "--png", prefix, txPath)
+		chunks, err := paramcontext.EncodeQRChunks(pc, paramcontext.DefaultQRChunkSize)
+		require.NoError(t, err)
+		for i := range chunks {
+			e.CheckNextLine(t, fmt.Sprintf("%s-%d.png", prefix, i+1))
+		}
+		e.CheckEOF(t)
+		data, err := os.ReadFile(prefix + "-1.png")
+		require.NoError(t, err)
+		require.Equal(t, "\x89PNG", string(data[:4]))
+	})
+
+	chunks, err := paramcontext.EncodeQRChunks(pc, 64)
+	require.NoError(t, err)
+	outPath := filepath.Join(tmp, "out.json")
+	t.Run("import, bad", func(t *testing.T) {
+		e.RunWithError(t, append([]string{"neo-go", "util", "qrimport"}, chunks...)...)
+		e.RunWithError(t, "neo-go", "util", "qrimport", "--out", outPath, "garbage")
+
+		other, err := paramcontext.EncodeQRChunks(pc, 100)
+		require.NoError(t, err)
+		e.RunWithError(t, "neo-go", "util", "qrimport", "--out", outPath, chunks[0], other[1])
+
+		// Huge number of chunks or too small chunks.
+		data := chunks[0][strings.LastIndex(chunks[0], ":")+1:]
+		e.RunWithError(t, "neo-go", "util", "qrimport", "--out", outPath, "NEOCTX:1/2000000000:ABCD1234:"+data)
+		e.RunWithError(t, "neo-go", "util", "qrimport", "--out", outPath, "NEOCTX:1/3:ABCD1234:AE")
+	})
+	t.Run("import", func(t *testing.T) {
+		// Reversed order, duplicates and interactive input.
+		args := []string{"neo-go", "util", "qrimport", "--out", outPath}
+		for i := len(chunks) - 1; i > 0; i-- {
+			args = append(args, chunks[i], chunks[i])
+		}
+		e.In.WriteString(chunks[0] + "\r")
+		e.Run(t, args...)
+
+		actual, err := paramcontext.Read(outPath)
+		require.NoError(t, err)
+		require.Equal(t, pc.Verifiable.Hash(), actual.Verifiable.Hash())
+		require.Equal(t, pc.Items, actual.Items)
+		require.Equal(t, pc.Network, actual.Network)
+	})
+}
@@ -0,0 +1,335 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/input"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/shamir"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/slice"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+// EnterSharePrompt is a prompt used to ask the user for a backup share.
+const EnterSharePrompt = "Enter share > "
+
+// backupVersion is the current version of wallet backup format. Version 0
+// backups don't have account metadata (Extra).
+const backupVersion = 1
+
+// walletBackup is a set of wallet accounts with keys that is split into
+// shares by 'wallet backup' command.
+type walletBackup struct {
+	// RawKeys is true if accounts contain unencrypted private keys instead
+	// of NEP-2 ones.
+	RawKeys  bool
+	Scrypt   keys.ScryptParams
+	Accounts []backupAccount
+}
+
+// backupAccount contains account data needed to restore it.
+type backupAccount struct {
+	Hash     util.Uint160
+	Label    string
+	Default  bool
+	Key      []byte
+	Contract *wallet.Contract
+	Extra    *wallet.AccountExtra
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (b *walletBackup) EncodeBinary(w *io.BinWriter) {
+	w.WriteB(backupVersion)
+	w.WriteBool(b.RawKeys)
+	w.WriteVarUint(uint64(b.Scrypt.N))
+	w.WriteVarUint(uint64(b.Scrypt.R))
+	w.WriteVarUint(uint64(b.Scrypt.P))
+	w.WriteVarUint(uint64(len(b.Accounts)))
+	for _, a := range b.Accounts {
+		w.WriteBytes(a.Hash[:])
+		w.WriteString(a.Label)
+		w.WriteBool(a.Default)
+		w.WriteVarBytes(a.Key)
+		w.WriteBool(a.Contract != nil)
+		if a.Contract != nil {
+			w.WriteVarBytes(a.Contract.Script)
+			w.WriteVarUint(uint64(len(a.Contract.Parameters)))
+			for _, p := range a.Contract.Parameters {
+				w.WriteString(p.Name)
+				w.WriteB(byte(p.Type))
+			}
+			w.WriteBool(a.Contract.Deployed)
+		}
+		var extra []byte
+		if a.Extra != nil {
+			var err error
+			extra, err = json.Marshal(a.Extra)
+			if err != nil {
+				w.Err = fmt.Errorf("can't marshal account extra: %w", err)
+				return
+			}
+		}
+		w.WriteVarBytes(extra)
+	}
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (b *walletBackup) DecodeBinary(r *io.BinReader) {
+	v := r.ReadB()
+	if r.Err == nil && v > backupVersion {
+		r.Err = fmt.Errorf("unsupported backup version %d", v)
+		return
+	}
+	b.RawKeys = r.ReadBool()
+	b.Scrypt.N = int(r.ReadVarUint())
+	b.Scrypt.R = int(r.ReadVarUint())
+	b.Scrypt.P = int(r.ReadVarUint())
+	n := r.ReadVarUint()
+	if n > io.MaxArraySize {
+		r.Err = errors.New("too many accounts")
+		return
+	}
+	b.Accounts = make([]backupAccount, n)
+	for i := range b.Accounts {
+		a := &b.Accounts[i]
+		r.ReadBytes(a.Hash[:])
+		a.Label = r.ReadString()
+		a.Default = r.ReadBool()
+		a.Key = r.ReadVarBytes()
+		if r.ReadBool() {
+			a.Contract = &wallet.Contract{Script: r.ReadVarBytes()}
+			np := r.ReadVarUint()
+			if np > io.MaxArraySize {
+				r.Err = errors.New("too many contract parameters")
+				return
+			}
+			a.Contract.Parameters = make([]wallet.ContractParam, np)
+			for j := range a.Contract.Parameters {
+				a.Contract.Parameters[j].Name = r.ReadString()
+				a.Contract.Parameters[j].Type = smartcontract.ParamType(r.ReadB())
+			}
+			a.Contract.Deployed = r.ReadBool()
+		}
+		if v == 0 {
+			continue
+		}
+		if extra := r.ReadVarBytes(); r.Err == nil && len(extra) != 0 {
+			a.Extra = new(wallet.AccountExtra)
+			if err := json.Unmarshal(extra, a.Extra); err != nil {
+				r.Err = fmt.Errorf("invalid account extra: %w", err)
+				return
+			}
+		}
+	}
+}
+
+func backupWallet(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	var (
+		n      = ctx.Int("shares")
+		m      = ctx.Int("threshold")
+		outDir = ctx.String("out")
+	)
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	b := &walletBackup{
+		RawKeys: ctx.Bool("decrypt"),
+		Scrypt:  wall.Scrypt,
+	}
+	if b.RawKeys && pass == nil {
+		password, err := input.ReadPassword(EnterPasswordPrompt)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("Error reading password: %w", err), 1)
+		}
+		pass = &password
+	}
+	for _, acc := range wall.Accounts {
+		if acc.EncryptedWIF == "" {
+			continue // Nothing to back up for watch-only accounts.
+		}
+		ba := backupAccount{
+			Hash:     acc.ScriptHash(),
+			Label:    acc.Label,
+			Default:  acc.Default,
+			Contract: acc.Contract,
+			Extra:    acc.Extra,
+		}
+		if b.RawKeys {
+			if err := acc.Decrypt(*pass, wall.Scrypt); err != nil {
+				return cli.NewExitError(fmt.Errorf("unable to decrypt account %s: %w", acc.Address, err), 1)
+			}
+			ba.Key = acc.PrivateKey().Bytes()
+			if acc.Extra != nil && acc.Extra.Argon2id != nil {
+				// Restored keys are encrypted with scrypt.
+				extra := *acc.Extra
+				extra.Argon2id = nil
+				ba.Extra = &extra
+				if extra.DerivationPath == "" && len(extra.Tags) == 0 {
+					ba.Extra = nil
+				}
+			}
+		} else {
+			if acc.Extra != nil && acc.Extra.Argon2id != nil {
+				return cli.NewExitError(fmt.Errorf("account %s uses Argon2id encryption, use --decrypt to back it up", acc.Address), 1)
+			}
+			ba.Key, err = base58.CheckDecode(acc.EncryptedWIF)
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("invalid key of account %s: %w", acc.Address, err), 1)
+			}
+		}
+		b.Accounts = append(b.Accounts, ba)
+	}
+	if len(b.Accounts) == 0 {
+		return cli.NewExitError("no accounts with keys in the wallet", 1)
+	}
+
+	bw := io.NewBufBinWriter()
+	b.EncodeBinary(bw.BinWriter)
+	if bw.Err != nil {
+		return cli.NewExitError(bw.Err, 1)
+	}
+	data := bw.Bytes()
+	shares, err := shamir.Split(data, n, m)
+	slice.Clean(data)
+	for _, a := range b.Accounts {
+		slice.Clean(a.Key)
+	}
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if outDir == "" {
+		for _, s := range shares {
+			fmt.Fprintln(ctx.App.Writer, s.String())
+		}
+		return nil
+	}
+	for _, s := range shares {
+		name := filepath.Join(outDir, "share-"+strconv.Itoa(int(s.Index))+".txt")
+		if err := os.WriteFile(name, []byte(s.String()+"\n"), 0600); err != nil {
+			return cli.NewExitError(fmt.Errorf("can't write share: %w", err), 1)
+		}
+	}
+	return nil
+}
+
+func restoreWallet(ctx *cli.Context) error {
+	path, pass, err := getWalletPathAndPass(ctx, true)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if path == "-" {
+		return cli.NewExitError(errNoStdin, 1)
+	}
+
+	var shares []*shamir.Share
+	for _, arg := range ctx.Args() {
+		s, err := shamir.NewShareFromString(arg)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		shares = append(shares, s)
+	}
+	for len(shares) == 0 || len(shares) < int(shares[0].Threshold) {
+		line, err := input.ReadLine(EnterSharePrompt)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("Error reading share: %w", err), 1)
+		}
+		s, err := shamir.NewShareFromString(strings.TrimSpace(line))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		shares = append(shares, s)
+	}
+	data, err := shamir.Combine(shares)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't restore backup: %w", err), 1)
+	}
+	b := new(walletBackup)
+	br := io.NewBinReaderFromBuf(data)
+	b.DecodeBinary(br)
+	slice.Clean(data)
+	if br.Err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid backup data: %w", br.Err), 1)
+	}
+
+	var wall *wallet.Wallet
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		wall, err = wallet.NewWallet(path)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if !b.RawKeys {
+			wall.Scrypt = b.Scrypt
+		}
+	} else {
+		wall, err = wallet.NewWalletFromFile(path)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if !b.RawKeys && wall.Scrypt != b.Scrypt {
+			return cli.NewExitError("wallet scrypt parameters don't match the backup ones", 1)
+		}
+	}
+	defer wall.Close()
+
+	if b.RawKeys && pass == nil {
+		phrase, err := readNewPassword()
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		pass = &phrase
+	}
+	for _, ba := range b.Accounts {
+		addr := address.Uint160ToString(ba.Hash)
+		if wall.GetAccount(ba.Hash) != nil {
+			fmt.Fprintf(ctx.App.Writer, "Account %s is already in the wallet, skipping\n", addr)
+			continue
+		}
+		acc := &wallet.Account{
+			Address:  addr,
+			Label:    ba.Label,
+			Default:  ba.Default,
+			Contract: ba.Contract,
+			Extra:    ba.Extra,
+		}
+		if b.RawKeys {
+			priv, err := keys.NewPrivateKeyFromBytes(ba.Key)
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("invalid key of account %s: %w", addr, err), 1)
+			}
+			acc.EncryptedWIF, err = keys.NEP2Encrypt(priv, *pass, wall.Scrypt)
+			priv.Destroy()
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+		} else {
+			acc.EncryptedWIF = base58.CheckEncode(ba.Key)
+		}
+		slice.Clean(ba.Key)
+		wall.AddAccount(acc)
+		fmt.Fprintf(ctx.App.Writer, "Restored account %s\n", addr)
+	}
+	if err := wall.Save(); err != nil {
+		return cli.NewExitError(fmt.Errorf("error while saving wallet: %w", err), 1)
+	}
+	return nil
+}
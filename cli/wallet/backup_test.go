@@ -0,0 +1,138 @@
+package wallet_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletBackupRestore(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmp := t.TempDir()
+
+	orig, err := wallet.NewWalletFromFile(testcli.ValidatorWallet)
+	require.NoError(t, err)
+
+	getShares := func(t *testing.T, n int) []string {
+		shares := strings.Split(strings.TrimSpace(e.Out.String()), "\n")
+		e.Out.Reset()
+		require.Equal(t, n, len(shares))
+		return shares
+	}
+	checkRestored := func(t *testing.T, path string) *wallet.Wallet {
+		w, err := wallet.NewWalletFromFile(path)
+		require.NoError(t, err)
+		require.Equal(t, len(orig.Accounts), len(w.Accounts))
+		for i, acc := range orig.Accounts {
+			require.Equal(t, acc.Address, w.Accounts[i].Address)
+			require.Equal(t, acc.Label, w.Accounts[i].Label)
+			require.Equal(t, acc.Default, w.Accounts[i].Default)
+			require.Equal(t, acc.Contract.Script, w.Accounts[i].Contract.Script)
+			require.Equal(t, acc.Contract.Parameters, w.Accounts[i].Contract.Parameters)
+		}
+		return w
+	}
+
+	t.Run("bad parameters", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "backup", "--wallet", testcli.ValidatorWallet)
+		e.RunWithError(t, "neo-go", "wallet", "backup", "--wallet", testcli.ValidatorWallet,
+			"--shares", "2", "--threshold", "3")
+		e.RunWithError(t, "neo-go", "wallet", "backup", "--wallet", testcli.ValidatorWallet,
+			"--shares", "2", "--threshold", "1")
+		e.RunWithError(t, "neo-go", "wallet", "restore", "--wallet", filepath.Join(tmp, "bad.json"), "not-a-share")
+	})
+
+	e.Run(t, "neo-go", "wallet", "backup", "--wallet", testcli.ValidatorWallet,
+		"--shares", "3", "--threshold", "2")
+	shares := getShares(t, 3)
+
+	t.Run("NEP-2 keys", func(t *testing.T) {
+		path := filepath.Join(tmp, "nep2.json")
+		e.Run(t, "neo-go", "wallet", "restore", "--wallet", path, shares[2], shares[0])
+		for _, acc := range orig.Accounts {
+			e.CheckNextLine(t, "Restored account "+acc.Address)
+		}
+		e.CheckEOF(t)
+		w := checkRestored(t, path)
+		require.Equal(t, orig.Scrypt, w.Scrypt)
+		for i, acc := range orig.Accounts {
+			require.Equal(t, acc.EncryptedWIF, w.Accounts[i].EncryptedWIF)
+		}
+
+		t.Run("existing wallet", func(t *testing.T) {
+			e.Run(t, "neo-go", "wallet", "restore", "--wallet", path, shares[1], shares[2])
+			for _, acc := range orig.Accounts {
+				e.CheckNextLine(t, "Account "+acc.Address+" is already in the wallet, skipping")
+			}
+			e.CheckEOF(t)
+		})
+		t.Run("interactive", func(t *testing.T) {
+			path := filepath.Join(tmp, "interactive.json")
+			e.In.WriteString(shares[0] + "\r")
+			e.Run(t, "neo-go", "wallet", "restore", "--wallet", path, shares[1])
+			checkRestored(t, path)
+		})
+	})
+
+	t.Run("raw keys", func(t *testing.T) {
+		src := filepath.Join(tmp, "src.json")
+		e.In.WriteString("acc\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", src, "--account")
+		e.Out.Reset()
+		srcWallet, err := wallet.NewWalletFromFile(src)
+		require.NoError(t, err)
+		srcWallet.Accounts[0].Extra = &wallet.AccountExtra{
+			DerivationPath: "m/44'/888'/0'/0/0",
+			Tags:           []string{"cold", "treasury"},
+		}
+		require.NoError(t, srcWallet.Save())
+
+		e.In.WriteString("wrong\r")
+		e.RunWithError(t, "neo-go", "wallet", "backup", "--wallet", src,
+			"--shares", "2", "--threshold", "2", "--decrypt")
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "backup", "--wallet", src,
+			"--shares", "2", "--threshold", "2", "--decrypt")
+		rawShares := getShares(t, 2)
+
+		t.Run("mixed shares", func(t *testing.T) {
+			e.RunWithError(t, "neo-go", "wallet", "restore", "--wallet", filepath.Join(tmp, "mixed.json"),
+				shares[0], rawShares[1])
+		})
+
+		path := filepath.Join(tmp, "raw.json")
+		e.In.WriteString("new\rnew\r")
+		e.Run(t, "neo-go", "wallet", "restore", "--wallet", path, rawShares[1], rawShares[0])
+		w, err := wallet.NewWalletFromFile(path)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(w.Accounts))
+		acc := srcWallet.Accounts[0]
+		require.Equal(t, acc.Address, w.Accounts[0].Address)
+		require.Equal(t, acc.Label, w.Accounts[0].Label)
+		require.Equal(t, acc.Extra, w.Accounts[0].Extra)
+		require.NotEqual(t, acc.EncryptedWIF, w.Accounts[0].EncryptedWIF)
+		require.NoError(t, acc.Decrypt("pass", srcWallet.Scrypt))
+		require.NoError(t, w.Accounts[0].Decrypt("new", w.Scrypt))
+		require.Equal(t, acc.PrivateKey().Bytes(), w.Accounts[0].PrivateKey().Bytes())
+	})
+
+	t.Run("output directory", func(t *testing.T) {
+		dir := t.TempDir()
+		e.Run(t, "neo-go", "wallet", "backup", "--wallet", testcli.ValidatorWallet,
+			"--shares", "3", "--threshold", "3", "--out", dir)
+		e.CheckEOF(t)
+		var args = []string{"neo-go", "wallet", "restore", "--wallet", filepath.Join(tmp, "dir.json")}
+		for _, name := range []string{"share-1.txt", "share-2.txt", "share-3.txt"} {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			args = append(args, strings.TrimSpace(string(data)))
+		}
+		e.Run(t, args...)
+		checkRestored(t, filepath.Join(tmp, "dir.json"))
+	})
+}
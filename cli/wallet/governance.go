@@ -0,0 +1,374 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"text/tabwriter"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/input"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+// governanceAction is an action planned for a single account by governance
+// command.
+type governanceAction byte
+
+const (
+	governanceNone governanceAction = iota
+	governanceClaim
+	governanceVote
+)
+
+// governanceState is the NEO governance state of a single wallet account.
+type governanceState struct {
+	acc       *wallet.Account
+	balance   *big.Int
+	unclaimed *big.Int
+	voteTo    *keys.PublicKey
+	status    string
+	action    governanceAction
+}
+
+func newGovernanceCommand() cli.Command {
+	flgs := []cli.Flag{
+		walletPathFlag,
+		walletConfigFlag,
+		tagFlag,
+		txctx.GasFlag,
+		txctx.SysGasFlag,
+		txctx.ForceFlag,
+		txctx.AwaitFlag,
+		cli.BoolFlag{
+			Name:  "claim",
+			Usage: "Claim GAS for accounts that have some",
+		},
+		flags.Fixed8Flag{
+			Name:  "min-gas",
+			Usage: "Only claim GAS for accounts having more than this amount of unclaimed GAS",
+		},
+		cli.StringFlag{
+			Name:  "candidate, c",
+			Usage: "Public key of candidate to vote for with accounts that don't vote for a registered candidate",
+		},
+		flags.AddressFlag{
+			Name:  "address, a",
+			Usage: "Address to pay fees from (the first account of each transaction by default)",
+		},
+	}
+	flgs = append(flgs, options.RPC...)
+	return cli.Command{
+		Name:      "governance",
+		Usage:     "report and maintain GAS claims and votes of wallet accounts",
+		UsageText: "neo-go wallet governance -w wallet [--wallet-config path] -r endpoint [-s timeout] [--tag tag] [--claim [--min-gas amount]] [-c <public key>] [-a address] [-g gas] [-e sysgas] [--force] [--await]",
+		Description: `Prints NEO balance, unclaimed GAS amount, current vote target and its
+   status for every wallet account (or accounts having one of the tags given).
+   Status is "consensus" for candidates that are next block validators,
+   "committee" for other committee members, "candidate" for other registered
+   candidates and "unregistered" for keys that are not registered (or are
+   blocked) anymore.
+
+   If --claim flag is given, GAS is claimed for all accounts with more than
+   --min-gas of unclaimed GAS. If --candidate is given, all accounts holding
+   NEO that don't vote or vote for an unregistered key are voted for this
+   candidate (which also claims their GAS). Only standard signature accounts
+   with keys take part in these actions, all of them are decrypted with the
+   same password. Actions are batched into as few transactions as possible
+   (up to 16 accounts per transaction), fees are paid by the account given
+   with --address or by the first account of each transaction.
+`,
+		Action: handleGovernance,
+		Flags:  flgs,
+	}
+}
+
+func handleGovernance(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	var candidate *keys.PublicKey
+	if pubStr := ctx.String("candidate"); pubStr != "" {
+		pub, err := keys.NewPublicKeyFromString(pubStr)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid public key: '%s'", pubStr), 1)
+		}
+		candidate = pub
+	}
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	accounts, err := getTaggedAccounts(ctx, wall)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err := options.AttachRemoteSigner(ctx, wall); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	candidates, err := c.GetCandidates()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get candidates: %w", err), 1)
+	}
+	comm, err := c.GetCommittee()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get committee: %w", err), 1)
+	}
+	if candidate != nil && candidateStatus(candidate, candidates, comm) == "unregistered" {
+		return cli.NewExitError(fmt.Errorf("%s is not a registered candidate", hex.EncodeToString(candidate.Bytes())), 1)
+	}
+
+	var (
+		claim  = ctx.Bool("claim")
+		minGas = big.NewInt(int64(flags.Fixed8FromContext(ctx, "min-gas")))
+		reader = neo.NewReader(invoker.New(c, nil))
+		states = make([]*governanceState, 0, len(accounts))
+	)
+	for _, acc := range accounts {
+		st := &governanceState{acc: acc, balance: new(big.Int)}
+		nb, err := reader.GetAccountState(acc.ScriptHash())
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to get NEO state of %s: %w", acc.Address, err), 1)
+		}
+		if nb != nil {
+			st.balance = &nb.Balance
+			st.voteTo = nb.VoteTo
+		}
+		uncl, err := c.GetUnclaimedGas(acc.Address)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to get unclaimed GAS of %s: %w", acc.Address, err), 1)
+		}
+		st.unclaimed = &uncl.Unclaimed
+		if st.voteTo != nil {
+			st.status = candidateStatus(st.voteTo, candidates, comm)
+		}
+		if canSignAlone(acc) {
+			switch {
+			case candidate != nil && st.balance.Sign() > 0 && (st.voteTo == nil || st.status == "unregistered"):
+				st.action = governanceVote
+			case claim && st.unclaimed.Cmp(minGas) > 0:
+				st.action = governanceClaim
+			}
+		}
+		states = append(states, st)
+	}
+	if err := printGovernanceReport(ctx, states); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	var planned []*governanceState
+	for _, st := range states {
+		if st.action != governanceNone {
+			planned = append(planned, st)
+		}
+	}
+	if len(planned) == 0 {
+		if claim || candidate != nil {
+			fmt.Fprintln(ctx.App.Writer, "Nothing to do")
+		}
+		return nil
+	}
+
+	var (
+		payer  *wallet.Account
+		toSign = make([]*wallet.Account, 0, len(planned)+1)
+	)
+	if addrFlag := ctx.Generic("address").(*flags.Address); addrFlag.IsSet {
+		payer = wall.GetAccount(addrFlag.Uint160())
+		if payer == nil {
+			return cli.NewExitError(fmt.Errorf("wallet contains no account for '%s'", addrFlag), 1)
+		}
+		if !canSignAlone(payer) {
+			return cli.NewExitError(errors.New("fee payer must be a standard account with a key"), 1)
+		}
+		toSign = append(toSign, payer)
+	}
+	for _, st := range planned {
+		toSign = append(toSign, st.acc)
+	}
+	if err := unlockAccounts(wall, toSign, pass); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	for _, batch := range batchGovernanceActions(planned, payer) {
+		var (
+			b       = smartcontract.NewBuilder()
+			signers = make([]actor.SignerAccount, 0, transaction.MaxAttributes)
+			sender  = payer
+		)
+		if sender == nil {
+			sender = batch[0].acc
+		}
+		signers = append(signers, governanceSigner(sender))
+		for _, st := range batch {
+			h := st.acc.ScriptHash()
+			if !h.Equals(sender.ScriptHash()) {
+				signers = append(signers, governanceSigner(st.acc))
+			}
+			switch st.action {
+			case governanceVote:
+				b.InvokeWithAssert(neo.Hash, "vote", h, candidate.Bytes())
+			case governanceClaim:
+				b.InvokeWithAssert(neo.Hash, "transfer", h, h, 0, nil)
+			}
+		}
+		script, err := b.Script()
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to create script: %w", err), 1)
+		}
+		act, err := actor.New(c, signers)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to create Actor: %w", err), 1)
+		}
+		tx, err := act.MakeUnsignedRun(script, nil)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if err := txctx.SignAndSend(ctx, act, sender, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// candidateStatus returns the status of the given key as described in
+// governance command description.
+func candidateStatus(pub *keys.PublicKey, candidates []result.Candidate, comm keys.PublicKeys) string {
+	for _, c := range candidates {
+		if !c.PublicKey.Equal(pub) {
+			continue
+		}
+		switch {
+		case c.Active:
+			return "consensus"
+		case comm.Contains(pub):
+			return "committee"
+		default:
+			return "candidate"
+		}
+	}
+	return "unregistered"
+}
+
+// canSignAlone checks whether the account is a standard signature account
+// with a key, so that it can be used to sign transactions without anyone else.
+func canSignAlone(acc *wallet.Account) bool {
+	return acc.Contract != nil && vm.IsSignatureContract(acc.Contract.Script) &&
+		(acc.EncryptedWIF != "" || acc.CanSign())
+}
+
+// unlockAccounts decrypts all given accounts with a single password (read
+// from the terminal if not given).
+func unlockAccounts(wall *wallet.Wallet, accounts []*wallet.Account, pass *string) error {
+	for _, acc := range accounts {
+		if acc.CanSign() {
+			continue
+		}
+		if pass == nil {
+			rawPass, err := input.ReadPassword(EnterPasswordPrompt)
+			if err != nil {
+				return fmt.Errorf("Error reading password: %w", err)
+			}
+			pass = &rawPass
+		}
+		if err := acc.Decrypt(*pass, wall.Scrypt); err != nil {
+			return fmt.Errorf("failed to decrypt account %s: %w", acc.Address, err)
+		}
+	}
+	return nil
+}
+
+// batchGovernanceActions splits the planned actions into groups each fitting
+// into a single transaction (taking the fee payer into account if it's given).
+func batchGovernanceActions(states []*governanceState, payer *wallet.Account) [][]*governanceState {
+	var (
+		res     [][]*governanceState
+		cur     []*governanceState
+		signers int
+		limit   = transaction.MaxAttributes
+	)
+	if payer != nil {
+		limit--
+	}
+	for _, st := range states {
+		if payer != nil && st.acc.ScriptHash().Equals(payer.ScriptHash()) {
+			cur = append(cur, st) // Payer is a signer anyway.
+			continue
+		}
+		if signers == limit {
+			res = append(res, cur)
+			cur, signers = nil, 0
+		}
+		cur = append(cur, st)
+		signers++
+	}
+	return append(res, cur)
+}
+
+func governanceSigner(acc *wallet.Account) actor.SignerAccount {
+	return actor.SignerAccount{
+		Signer: transaction.Signer{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}
+}
+
+func printGovernanceReport(ctx *cli.Context, states []*governanceState) error {
+	var (
+		res   []byte
+		total = new(big.Int)
+	)
+	res = fmt.Appendf(res, "Address\tNEO\tUnclaimed GAS\tVote\tStatus\tAction\n")
+	for _, st := range states {
+		var vote, status, action = "-", "-", "-"
+		if st.voteTo != nil {
+			vote = hex.EncodeToString(st.voteTo.Bytes())
+			status = st.status
+		}
+		switch st.action {
+		case governanceClaim:
+			action = "claim"
+		case governanceVote:
+			action = "vote"
+		}
+		total.Add(total, st.unclaimed)
+		res = fmt.Appendf(res, "%s\t%s\t%s\t%s\t%s\t%s\n", st.acc.Address, st.balance,
+			fixedn.ToString(st.unclaimed, 8), vote, status, action)
+	}
+	tw := tabwriter.NewWriter(ctx.App.Writer, 0, 2, 2, ' ', 0)
+	if _, err := tw.Write(res); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.App.Writer, "Total unclaimed GAS: %s\n", fixedn.ToString(total, 8))
+	return nil
+}
@@ -0,0 +1,130 @@
+package wallet_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletGovernance(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	rpc := "http://" + e.RPC.Addresses()[0]
+
+	walletPath := filepath.Join(t.TempDir(), "treasury.json")
+	w, err := wallet.NewWallet(walletPath)
+	require.NoError(t, err)
+	for range []int{0, 1} {
+		acc, err := wallet.NewAccount()
+		require.NoError(t, err)
+		require.NoError(t, acc.Encrypt("pass", w.Scrypt))
+		w.AddAccount(acc)
+	}
+	require.NoError(t, w.Save())
+	w.Close()
+	addr1, addr2 := w.Accounts[0].Address, w.Accounts[1].Address
+
+	validatorAddress := testcli.ValidatorPriv.Address()
+	validatorHex := hex.EncodeToString(testcli.ValidatorPriv.PublicKey().Bytes())
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", rpc,
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+addr1+":100",
+		"NEO:"+addr2+":200",
+		"GAS:"+addr1+":10",
+		"GAS:"+validatorAddress+":10000")
+	e.CheckTxPersisted(t)
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "candidate", "register",
+		"--rpc-endpoint", rpc,
+		"--wallet", testcli.ValidatorWallet,
+		"--address", validatorAddress,
+		"--force")
+	e.CheckTxPersisted(t)
+
+	t.Run("bad candidate", func(t *testing.T) {
+		unregistered, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		e.RunWithError(t, "neo-go", "wallet", "governance",
+			"--rpc-endpoint", rpc, "--wallet", walletPath,
+			"--candidate", "not-a-key")
+		e.RunWithError(t, "neo-go", "wallet", "governance",
+			"--rpc-endpoint", rpc, "--wallet", walletPath,
+			"--candidate", hex.EncodeToString(unregistered.PublicKey().Bytes()))
+		e.Out.Reset()
+	})
+
+	t.Run("report", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "governance",
+			"--rpc-endpoint", rpc, "--wallet", walletPath)
+		e.CheckNextLine(t, `^Address\s+NEO\s+Unclaimed GAS\s+Vote\s+Status\s+Action$`)
+		e.CheckNextLine(t, "^"+addr1+`\s+100\s+0\.\d+\s+-\s+-\s+-$`)
+		e.CheckNextLine(t, "^"+addr2+`\s+200\s+0\.\d+\s+-\s+-\s+-$`)
+		e.CheckNextLine(t, `^Total unclaimed GAS: 0\.\d+$`)
+		e.CheckEOF(t)
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "governance",
+			"--rpc-endpoint", rpc, "--wallet", walletPath,
+			"--claim", "--min-gas", "1000")
+		e.CheckNextLine(t, `^Address`)
+		e.CheckNextLine(t, "^"+addr1+`.+-$`)
+		e.CheckNextLine(t, "^"+addr2+`.+-$`)
+		e.CheckNextLine(t, `^Total unclaimed GAS`)
+		e.CheckNextLine(t, "^Nothing to do$")
+		e.CheckEOF(t)
+	})
+
+	t.Run("vote and claim", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "governance",
+			"--rpc-endpoint", rpc, "--wallet", walletPath,
+			"--claim", "--candidate", validatorHex,
+			"--address", addr1, "--force")
+		e.CheckNextLine(t, `^Address`)
+		e.CheckNextLine(t, "^"+addr1+`.+vote$`)
+		e.CheckNextLine(t, "^"+addr2+`.+vote$`)
+		e.CheckNextLine(t, `^Total unclaimed GAS`)
+		tx, _ := e.CheckTxPersisted(t)
+		require.Equal(t, 2, len(tx.Signers))
+		require.Equal(t, w.Accounts[0].ScriptHash(), tx.Sender())
+
+		vs, err := e.Chain.GetEnrollments()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(vs))
+		require.Equal(t, big.NewInt(300), vs[0].Votes)
+
+		e.Run(t, "neo-go", "wallet", "governance",
+			"--rpc-endpoint", rpc, "--wallet", walletPath, "--candidate", validatorHex)
+		e.CheckNextLine(t, `^Address`)
+		e.CheckNextLine(t, "^"+addr1+`\s+100\s+\S+\s+`+validatorHex+`\s+(consensus|committee)\s+-$`)
+		e.CheckNextLine(t, "^"+addr2+`\s+200\s+\S+\s+`+validatorHex+`\s+(consensus|committee)\s+-$`)
+		e.CheckNextLine(t, `^Total unclaimed GAS`)
+		e.CheckNextLine(t, "^Nothing to do$")
+		e.CheckEOF(t)
+	})
+
+	t.Run("claim", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "governance",
+			"--rpc-endpoint", rpc, "--wallet", walletPath,
+			"--claim", "--force")
+		e.CheckNextLine(t, `^Address`)
+		e.CheckNextLine(t, "^"+addr1+`.+claim$`)
+		e.CheckNextLine(t, "^"+addr2+`.+claim$`)
+		e.CheckNextLine(t, `^Total unclaimed GAS`)
+		tx, _ := e.CheckTxPersisted(t)
+		require.Equal(t, 2, len(tx.Signers))
+	})
+}
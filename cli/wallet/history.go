@@ -0,0 +1,486 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neptoken"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+// History entry types.
+const (
+	historyTransfer = "transfer"
+	historyMint     = "mint"
+	historyBurn     = "burn"
+	historyClaim    = "claim"
+	historyReward   = "reward"
+	historyFee      = "fee"
+	historyVote     = "vote"
+)
+
+// historyPageLimit is the number of transfers requested at once, it's the
+// maximum allowed by NeoGo RPC server.
+const historyPageLimit = 1000
+
+// historyEntry is a single event of the account history.
+type historyEntry struct {
+	Time    time.Time `json:"time"`
+	Block   uint32    `json:"block"`
+	TxHash  string    `json:"txhash,omitempty"`
+	Account string    `json:"account"`
+	Type    string    `json:"type"`
+	// Direction is "in" or "out" for asset movements.
+	Direction string `json:"direction,omitempty"`
+	Asset     string `json:"asset,omitempty"`
+	TokenID   string `json:"tokenid,omitempty"`
+	Amount    string `json:"amount,omitempty"`
+	// Counterparty is the other side of transfer or candidate key for votes.
+	Counterparty string `json:"counterparty,omitempty"`
+
+	notifyIndex uint32
+}
+
+// historyFetcher collects account history caching token and block data.
+type historyFetcher struct {
+	c          *rpcclient.Client
+	start, end uint64
+	tokens     map[util.Uint160]*wallet.Token
+	blocks     map[uint32]util.Uint256
+}
+
+func walletHistory(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	format := ctx.String("format")
+	if format != "table" && format != "json" && format != "csv" {
+		return cli.NewExitError(fmt.Errorf("unknown output format: %s", format), 1)
+	}
+	var (
+		start uint64
+		end   = uint64(time.Now().UnixMilli())
+	)
+	if s := ctx.String("start"); s != "" {
+		t, err := parseHistoryTime(s)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid start time: %w", err), 1)
+		}
+		start = uint64(t.UnixMilli())
+	}
+	if s := ctx.String("end"); s != "" {
+		t, err := parseHistoryTime(s)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid end time: %w", err), 1)
+		}
+		end = uint64(t.UnixMilli())
+	}
+	if start > end {
+		return cli.NewExitError("start time is after the end time", 1)
+	}
+
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("bad wallet: %w", err), 1)
+	}
+	defer wall.Close()
+
+	var accounts []util.Uint160
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if addrFlag.IsSet {
+		addrHash := addrFlag.Uint160()
+		if wall.GetAccount(addrHash) == nil {
+			return cli.NewExitError(fmt.Errorf("can't find account for the address: %s", address.Uint160ToString(addrHash)), 1)
+		}
+		accounts = append(accounts, addrHash)
+	} else {
+		tagged, err := getTaggedAccounts(ctx, wall)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		for _, acc := range tagged {
+			accounts = append(accounts, acc.ScriptHash())
+		}
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	f := &historyFetcher{
+		c:      c,
+		start:  start,
+		end:    end,
+		tokens: make(map[util.Uint160]*wallet.Token),
+		blocks: make(map[uint32]util.Uint256),
+	}
+	var entries []historyEntry
+	for _, acc := range accounts {
+		accEntries, err := f.accountHistory(acc)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to get history for %s: %w", address.Uint160ToString(acc), err), 1)
+		}
+		entries = append(entries, accEntries...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Block != entries[j].Block {
+			return entries[i].Block < entries[j].Block
+		}
+		return entries[i].notifyIndex < entries[j].notifyIndex
+	})
+
+	switch format {
+	case "json":
+		if entries == nil {
+			entries = []historyEntry{}
+		}
+		b, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, string(b))
+	case "csv":
+		err = printHistoryCSV(ctx.App.Writer, entries)
+	default:
+		err = printHistoryTable(ctx.App.Writer, entries)
+	}
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
+// parseHistoryTime parses RFC3339 time, date (YYYY-MM-DD) or Unix timestamp
+// (in seconds).
+func parseHistoryTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	ts, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("neither RFC3339 time, nor date, nor timestamp: %s", s)
+	}
+	return time.Unix(ts, 0), nil
+}
+
+// accountHistory returns all history entries for the given account.
+func (f *historyFetcher) accountHistory(acc util.Uint160) ([]historyEntry, error) {
+	var (
+		entries []historyEntry
+		accAddr = address.Uint160ToString(acc)
+		fees    = make(map[uint32][]int) // Block -> fee entry indexes.
+	)
+	for page := 0; ; page++ {
+		limit := historyPageLimit
+		trs, err := f.c.GetNEP17Transfers(acc, &f.start, &f.end, &limit, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, tr := range trs.Received {
+			e, err := f.nep17Entry(accAddr, tr, true)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		}
+		for _, tr := range trs.Sent {
+			e, err := f.nep17Entry(accAddr, tr, false)
+			if err != nil {
+				return nil, err
+			}
+			if e.Type == historyFee {
+				fees[e.Block] = append(fees[e.Block], len(entries))
+			}
+			entries = append(entries, e)
+		}
+		if len(trs.Received)+len(trs.Sent) < limit {
+			break
+		}
+	}
+	for page := 0; ; page++ {
+		limit := historyPageLimit
+		trs, err := f.c.GetNEP11Transfers(acc, &f.start, &f.end, &limit, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, tr := range trs.Received {
+			entries = append(entries, f.nep11Entry(accAddr, tr, true))
+		}
+		for _, tr := range trs.Sent {
+			entries = append(entries, f.nep11Entry(accAddr, tr, false))
+		}
+		if len(trs.Received)+len(trs.Sent) < limit {
+			break
+		}
+	}
+	// Fees are burnt for every transaction on block persist, so transactions
+	// sent by the account are to be found in blocks.
+	for index, feeEntries := range fees {
+		txes, err := f.senderTransactions(index, acc)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(feeEntries, func(i, j int) bool {
+			return entries[feeEntries[i]].notifyIndex < entries[feeEntries[j]].notifyIndex
+		})
+		for i, tx := range txes {
+			if i < len(feeEntries) {
+				entries[feeEntries[i]].TxHash = tx.Hash().StringLE()
+			}
+			votes, err := f.votes(accAddr, acc, index, entries[feeEntries[0]].Time, tx.Hash())
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, votes...)
+		}
+	}
+	return entries, nil
+}
+
+// nep17Entry converts NEP-17 transfer into a history entry.
+func (f *historyFetcher) nep17Entry(acc string, tr result.NEP17Transfer, received bool) (historyEntry, error) {
+	tok := f.token(tr.Asset)
+	e := historyEntry{
+		Time:         time.UnixMilli(int64(tr.Timestamp)).UTC(),
+		Block:        tr.Index,
+		TxHash:       tr.TxHash.StringLE(),
+		Account:      acc,
+		Type:         historyTransfer,
+		Direction:    "out",
+		Asset:        tok.Symbol,
+		Amount:       decimalAmount(tr.Amount, int(tok.Decimals)),
+		Counterparty: tr.Address,
+		notifyIndex:  tr.NotifyIndex,
+	}
+	if received {
+		e.Direction = "in"
+	}
+	if tr.Address != "" {
+		return e, nil
+	}
+	var blockLevel bool
+	if tr.Asset.Equals(gas.Hash) {
+		h, err := f.blockHash(tr.Index)
+		if err != nil {
+			return e, err
+		}
+		blockLevel = h.Equals(tr.TxHash)
+	}
+	switch {
+	case received && blockLevel:
+		e.Type = historyReward
+		e.TxHash = ""
+	case received && tr.Asset.Equals(gas.Hash):
+		e.Type = historyClaim
+	case received:
+		e.Type = historyMint
+	case blockLevel:
+		e.Type = historyFee
+		e.TxHash = ""
+	default:
+		e.Type = historyBurn
+	}
+	return e, nil
+}
+
+// nep11Entry converts NEP-11 transfer into a history entry.
+func (f *historyFetcher) nep11Entry(acc string, tr result.NEP11Transfer, received bool) historyEntry {
+	tok := f.token(tr.Asset)
+	e := historyEntry{
+		Time:         time.UnixMilli(int64(tr.Timestamp)).UTC(),
+		Block:        tr.Index,
+		TxHash:       tr.TxHash.StringLE(),
+		Account:      acc,
+		Type:         historyTransfer,
+		Direction:    "out",
+		Asset:        tok.Symbol,
+		TokenID:      tr.ID,
+		Amount:       decimalAmount(tr.Amount, int(tok.Decimals)),
+		Counterparty: tr.Address,
+		notifyIndex:  tr.NotifyIndex,
+	}
+	if received {
+		e.Direction = "in"
+	}
+	if tr.Address == "" {
+		if received {
+			e.Type = historyMint
+		} else {
+			e.Type = historyBurn
+		}
+	}
+	return e
+}
+
+// votes returns vote entries for the account found in the transaction
+// application log.
+func (f *historyFetcher) votes(accAddr string, acc util.Uint160, index uint32, t time.Time, h util.Uint256) ([]historyEntry, error) {
+	log, err := f.c.GetApplicationLog(h, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []historyEntry
+	for _, ex := range log.Executions {
+		for i, ev := range ex.Events {
+			if ev.Name != "Vote" || !ev.ScriptHash.Equals(neo.Hash) {
+				continue
+			}
+			arr, ok := ev.Item.Value().([]stackitem.Item)
+			if !ok || len(arr) != 4 {
+				continue
+			}
+			b, err := arr[0].TryBytes()
+			if err != nil {
+				continue
+			}
+			voter, err := util.Uint160DecodeBytesBE(b)
+			if err != nil || !voter.Equals(acc) {
+				continue
+			}
+			var candidate string
+			if arr[2].Type() != stackitem.AnyT {
+				to, err := arr[2].TryBytes()
+				if err != nil {
+					continue
+				}
+				candidate = hex.EncodeToString(to)
+			}
+			var amount string
+			if n, err := arr[3].TryInteger(); err == nil {
+				amount = n.String()
+			}
+			res = append(res, historyEntry{
+				Time:         t,
+				Block:        index,
+				TxHash:       h.StringLE(),
+				Account:      accAddr,
+				Type:         historyVote,
+				Asset:        f.token(neo.Hash).Symbol,
+				Amount:       amount,
+				Counterparty: candidate,
+				notifyIndex:  uint32(i),
+			})
+		}
+	}
+	return res, nil
+}
+
+// senderTransactions returns transactions sent by the account in the block.
+func (f *historyFetcher) senderTransactions(index uint32, acc util.Uint160) ([]*transaction.Transaction, error) {
+	b, err := f.c.GetBlockByIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	var res []*transaction.Transaction
+	for _, tx := range b.Transactions {
+		if tx.Sender().Equals(acc) {
+			res = append(res, tx)
+		}
+	}
+	return res, nil
+}
+
+// blockHash returns the hash of the block with the given index.
+func (f *historyFetcher) blockHash(index uint32) (util.Uint256, error) {
+	if h, ok := f.blocks[index]; ok {
+		return h, nil
+	}
+	h, err := f.c.GetBlockHash(index)
+	if err != nil {
+		return h, err
+	}
+	f.blocks[index] = h
+	return h, nil
+}
+
+// token returns token data, if it can't be retrieved, the token hash is used
+// as a symbol.
+func (f *historyFetcher) token(h util.Uint160) *wallet.Token {
+	if tok, ok := f.tokens[h]; ok {
+		return tok
+	}
+	tok, err := neptoken.Info(f.c, h)
+	if err != nil {
+		tok = &wallet.Token{Hash: h, Symbol: h.StringLE()}
+	}
+	f.tokens[h] = tok
+	return tok
+}
+
+func historyRow(e historyEntry) []string {
+	return []string{
+		e.Time.Format(time.RFC3339),
+		strconv.FormatUint(uint64(e.Block), 10),
+		e.TxHash,
+		e.Account,
+		e.Type,
+		e.Direction,
+		e.Asset,
+		e.TokenID,
+		e.Amount,
+		e.Counterparty,
+	}
+}
+
+var historyHeader = []string{"Time", "Block", "Transaction", "Account", "Type", "Direction", "Asset", "Token ID", "Amount", "Counterparty"}
+
+func printHistoryCSV(w io.Writer, entries []historyEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(historyHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := cw.Write(historyRow(e)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func printHistoryTable(w io.Writer, entries []historyEntry) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	printRow := func(row []string) {
+		for i, s := range row {
+			if s == "" {
+				s = "-"
+			}
+			if i != 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, s)
+		}
+		fmt.Fprintln(tw)
+	}
+	printRow(historyHeader)
+	for _, e := range entries {
+		printRow(historyRow(e))
+	}
+	return tw.Flush()
+}
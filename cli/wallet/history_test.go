@@ -0,0 +1,124 @@
+package wallet_test
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletHistory(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	validatorAddress := testcli.ValidatorPriv.Address()
+	validatorHex := hex.EncodeToString(testcli.ValidatorPriv.PublicKey().Bytes())
+	rpc := "http://" + e.RPC.Addresses()[0]
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", rpc,
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+validatorAddress+":10",
+		"GAS:"+validatorAddress+":10000")
+	e.CheckTxPersisted(t)
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "candidate", "register",
+		"--rpc-endpoint", rpc,
+		"--wallet", testcli.ValidatorWallet,
+		"--address", validatorAddress,
+		"--force")
+	e.CheckTxPersisted(t)
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "candidate", "vote",
+		"--rpc-endpoint", rpc,
+		"--wallet", testcli.ValidatorWallet,
+		"--address", validatorAddress,
+		"--candidate", validatorHex,
+		"--force")
+	voteTx, _ := e.CheckTxPersisted(t)
+
+	args := []string{"neo-go", "wallet", "history",
+		"--rpc-endpoint", rpc,
+		"--wallet", testcli.ValidatorWallet,
+		"--address", validatorAddress,
+	}
+	t.Run("bad parameters", func(t *testing.T) {
+		e.RunWithError(t, append(args, "--format", "xml")...)
+		e.RunWithError(t, append(args, "--start", "yesterday")...)
+		e.RunWithError(t, append(args, "--start", "2030-01-01", "--end", "2020-01-01")...)
+		e.RunWithError(t, append(args, "something")...)
+	})
+
+	type entry struct {
+		TxHash       string `json:"txhash"`
+		Account      string `json:"account"`
+		Type         string `json:"type"`
+		Direction    string `json:"direction"`
+		Asset        string `json:"asset"`
+		Amount       string `json:"amount"`
+		Counterparty string `json:"counterparty"`
+	}
+	e.Run(t, append(args, "--format", "json")...)
+	var entries []entry
+	require.NoError(t, json.Unmarshal(e.Out.Bytes(), &entries))
+	e.Out.Reset()
+
+	var haveNEO, haveGAS, haveFee, haveVote bool
+	for _, en := range entries {
+		require.Equal(t, validatorAddress, en.Account)
+		switch {
+		case en.Type == "transfer" && en.Asset == "NEO":
+			require.Equal(t, "in", en.Direction)
+			require.Equal(t, "10", en.Amount)
+			require.Equal(t, testcli.ValidatorAddr, en.Counterparty)
+			haveNEO = true
+		case en.Type == "transfer" && en.Asset == "GAS":
+			require.Equal(t, "in", en.Direction)
+			require.Equal(t, "10000", en.Amount)
+			haveGAS = true
+		case en.Type == "fee" && en.TxHash == voteTx.Hash().StringLE():
+			require.Equal(t, "out", en.Direction)
+			require.Equal(t, "GAS", en.Asset)
+			haveFee = true
+		case en.Type == "vote":
+			require.Equal(t, voteTx.Hash().StringLE(), en.TxHash)
+			require.Equal(t, validatorHex, en.Counterparty)
+			require.Equal(t, "10", en.Amount)
+			haveVote = true
+		}
+	}
+	require.True(t, haveNEO)
+	require.True(t, haveGAS)
+	require.True(t, haveFee)
+	require.True(t, haveVote)
+
+	t.Run("csv", func(t *testing.T) {
+		e.Run(t, append(args, "--format", "csv")...)
+		records, err := csv.NewReader(strings.NewReader(e.Out.String())).ReadAll()
+		e.Out.Reset()
+		require.NoError(t, err)
+		require.Equal(t, len(entries)+1, len(records))
+		require.Equal(t, "Time", records[0][0])
+	})
+	t.Run("table", func(t *testing.T) {
+		e.Run(t, args...)
+		e.CheckNextLine(t, `^Time\s+Block\s+Transaction`)
+		for range entries {
+			e.CheckNextLine(t, validatorAddress)
+		}
+		e.CheckEOF(t)
+	})
+	t.Run("time range", func(t *testing.T) {
+		e.Run(t, append(args, "--format", "json", "--end", "2010-01-01")...)
+		e.CheckNextLine(t, `^\[\]$`)
+		e.CheckEOF(t)
+	})
+}
@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+func signMessage(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.NewExitError("exactly one message to sign is required", 1)
+	}
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	addr, err := getDefaultAddress(ctx.Generic("address").(*flags.Address), wall)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err := options.AttachRemoteSigner(ctx, wall); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc, err := options.GetUnlockedAccount(wall, addr, pass)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	m, err := wallet.SignMessage(acc, ctx.Args().First(), ctx.Bool("no-salt"))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't sign message: %w", err), 1)
+	}
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, string(b))
+	return nil
+}
+
+func verifyMessage(ctx *cli.Context) error {
+	var (
+		data []byte
+		in   = ctx.String("in")
+	)
+	switch {
+	case len(in) != 0 && ctx.NArg() != 0:
+		return cli.NewExitError("signed message can't be given both as an argument and via --in", 1)
+	case len(in) != 0:
+		var err error
+		data, err = os.ReadFile(in)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't read signed message: %w", err), 1)
+		}
+	case ctx.NArg() == 1:
+		data = []byte(ctx.Args().First())
+	default:
+		return cli.NewExitError("signed message is required", 1)
+	}
+	m := new(wallet.SignedMessage)
+	if err := json.Unmarshal(data, m); err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid signed message: %w", err), 1)
+	}
+	if m.PublicKey == nil {
+		return cli.NewExitError(errors.New("invalid signed message: no public key"), 1)
+	}
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if addrFlag.IsSet && !m.PublicKey.GetScriptHash().Equals(addrFlag.Uint160()) {
+		return cli.NewExitError(fmt.Errorf("public key doesn't match address %s", m.PublicKey.Address()), 1)
+	}
+	if !m.Verify() {
+		return cli.NewExitError("invalid signature", 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Signature is valid, signed by %s\n", m.PublicKey.Address())
+	return nil
+}
@@ -1,7 +1,9 @@
 package wallet
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
@@ -11,7 +13,10 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/urfave/cli"
 )
 
@@ -20,13 +25,19 @@ func signStoredTransaction(ctx *cli.Context) error {
 		out      = ctx.String("out")
 		rpcNode  = ctx.String(options.RPCEndpointFlag)
 		addrFlag = ctx.Generic("address").(*flags.Address)
-		aer      *state.AppExecResult
+		useQR    = ctx.Bool("qr")
+		pc       *context.ParameterContext
+		err      error
 	)
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
 
-	pc, err := paramcontext.Read(ctx.String("in"))
+	if in := ctx.String("in"); in == "" && useQR {
+		pc, err = paramcontext.ReadQR(nil)
+	} else {
+		pc, err = paramcontext.Read(in)
+	}
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -34,7 +45,11 @@ func signStoredTransaction(ctx *cli.Context) error {
 	if !addrFlag.IsSet {
 		return cli.NewExitError("address was not provided", 1)
 	}
-	acc, _, err := options.GetAccFromContext(ctx)
+	acc, wall, err := options.GetAccFromContext(ctx)
+	if errors.Is(err, options.ErrWatchOnlyAccount) && rpcNode != "" {
+		// Nothing to sign with, but complete context can still be sent.
+		acc, err = wall.GetAccount(addrFlag.Uint160()), nil
+	}
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -49,7 +64,10 @@ func signStoredTransaction(ctx *cli.Context) error {
 	}
 
 	if acc.CanSign() {
-		sign := acc.SignHashable(pc.Network, pc.Verifiable)
+		sign, err := acc.SignHashableWithError(pc.Network, pc.Verifiable)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't sign transaction: %w", err), 1)
+		}
 		if err := pc.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), sign); err != nil {
 			return cli.NewExitError(fmt.Errorf("can't add signature: %w", err), 1)
 		}
@@ -58,6 +76,12 @@ func signStoredTransaction(ctx *cli.Context) error {
 	}
 	// Not saving and not sending, print.
 	if out == "" && rpcNode == "" {
+		if useQR {
+			if err := paramcontext.WriteQR(ctx.App.Writer, pc, paramcontext.DefaultQRChunkSize); err != nil {
+				return cli.NewExitError(fmt.Errorf("can't display resulting context: %w", err), 1)
+			}
+			return nil
+		}
 		txt, err := json.MarshalIndent(pc, " ", "     ")
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("can't display resulting context: %w", err), 1)
@@ -71,32 +95,241 @@ func signStoredTransaction(ctx *cli.Context) error {
 		}
 	}
 	if rpcNode != "" {
-		tx, err = pc.GetCompleteTransaction()
+		return sendContext(ctx, pc)
+	}
+
+	txctx.DumpTransactionInfo(ctx.App.Writer, tx.Hash(), nil)
+	return nil
+}
+
+// sendContext completes the transaction from the context, sends it to the RPC
+// node (awaiting it if needed) and prints its information.
+func sendContext(ctx *cli.Context, pc *context.ParameterContext) error {
+	var aer *state.AppExecResult
+
+	tx, err := pc.GetCompleteTransaction()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to complete transaction: %w", err), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create RPC client: %w", exitErr), 1)
+	}
+	res, err := c.SendRawTransaction(tx)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to submit transaction to RPC node: %w", err), 1)
+	}
+	if ctx.Bool("await") {
+		version, err := c.GetVersion()
+		aer, err = waiter.New(c, version).Wait(res, tx.ValidUntilBlock, err)
 		if err != nil {
-			return cli.NewExitError(fmt.Errorf("failed to complete transaction: %w", err), 1)
+			return cli.NewExitError(fmt.Errorf("failed to await transaction %s: %w", res.StringLE(), err), 1)
 		}
+	}
+	txctx.DumpTransactionInfo(ctx.App.Writer, tx.Hash(), aer)
+	return nil
+}
 
-		gctx, cancel := options.GetTimeoutContext(ctx)
-		defer cancel()
+func newMultisigCommands() []cli.Command {
+	broadcastFlags := append([]cli.Flag{
+		inFlag,
+		txctx.AwaitFlag,
+	}, options.RPC...)
+	return []cli.Command{
+		{
+			Name:      "create",
+			Usage:     "create a signing session file for the transaction",
+			UsageText: "neo-go wallet multisig create -w wallet [--wallet-config path] --in <file.in> --out <file.out>",
+			Description: `Creates a signing session file (which is a regular transaction signing
+   context) from the given context adding empty items for all transaction
+   signers known to the wallet (multisignature accounts included). This
+   allows to check the list of keys expected to sign the transaction with
+   'status' command before collecting any signatures. Signatures are added
+   with 'wallet sign' command, partially signed files can be combined with
+   'merge' command.
+`,
+			Action: createMultisigSession,
+			Flags: []cli.Flag{
+				walletPathFlag,
+				walletConfigFlag,
+				inFlag,
+				txctx.OutFlag,
+			},
+		},
+		{
+			Name:      "status",
+			Usage:     "show signing session status",
+			UsageText: "neo-go wallet multisig status --in <file.in>",
+			Description: `Prints transaction signers along with public keys that have already
+   signed the transaction and the ones that are missing for each of them.
+   Signers are reported as ready when there are enough signatures to
+   construct a witness for them.
+`,
+			Action: multisigSessionStatus,
+			Flags:  []cli.Flag{inFlag},
+		},
+		{
+			Name:      "merge",
+			Usage:     "merge partially signed contexts",
+			UsageText: "neo-go wallet multisig merge --out <file.out> <file1> [<file2> ...]",
+			Description: `Combines signatures from several context files created for the same
+   transaction into one (written to file.out). All signatures are checked
+   before being added.
+`,
+			Action: mergeMultisigSessions,
+			Flags:  []cli.Flag{txctx.OutFlag},
+		},
+		{
+			Name:      "broadcast",
+			Usage:     "send completely signed transaction",
+			UsageText: "neo-go wallet multisig broadcast --in <file.in> -r <endpoint> [-s timeout] [--await]",
+			Description: `Constructs a complete transaction from the given signing session file
+   and sends it via RPC. It fails if not all of the signers have enough
+   signatures (see 'status' command). If the --await flag is included, the
+   command waits for the transaction to be included in a block before exiting.
+`,
+			Action: broadcastMultisigSession,
+			Flags:  broadcastFlags,
+		},
+	}
+}
 
-		var err error // `GetRPCClient` returns specialized type.
-		c, err := options.GetRPCClient(gctx, ctx)
-		if err != nil {
-			return cli.NewExitError(fmt.Errorf("failed to create RPC client: %w", err), 1)
+func createMultisigSession(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	out := ctx.String("out")
+	if out == "" {
+		return cli.NewExitError("no output file given", 1)
+	}
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return cli.NewExitError("verifiable item is not a transaction", 1)
+	}
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("bad wallet: %w", err), 1)
+	}
+	defer wall.Close()
+
+	for _, s := range tx.Signers {
+		acc := wall.GetAccount(s.Account)
+		if acc == nil || acc.Contract == nil {
+			continue
 		}
-		res, err := c.SendRawTransaction(tx)
-		if err != nil {
-			return cli.NewExitError(fmt.Errorf("failed to submit transaction to RPC node: %w", err), 1)
+		pc.AddContract(s.Account, acc.Contract)
+	}
+	if err := paramcontext.Save(pc, out); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
+func multisigSessionStatus(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return cli.NewExitError("verifiable item is not a transaction", 1)
+	}
+
+	var (
+		w     = ctx.App.Writer
+		ready = 0
+	)
+	fmt.Fprintf(w, "Transaction: %s\n", tx.Hash().StringLE())
+	for _, s := range tx.Signers {
+		fmt.Fprintf(w, "Signer: %s\n", address.Uint160ToString(s.Account))
+		item, ok := pc.Items[s.Account]
+		if !ok {
+			fmt.Fprintln(w, "\tType: unknown")
+			fmt.Fprintln(w, "\tReady: no")
+			continue
 		}
-		if ctx.Bool("await") {
-			version, err := c.GetVersion()
-			aer, err = waiter.New(c, version).Wait(res, tx.ValidUntilBlock, err)
-			if err != nil {
-				return cli.NewExitError(fmt.Errorf("failed to await transaction %s: %w", res.StringLE(), err), 1)
+		if m, pubs, ok := vm.ParseMultiSigContract(item.Script); ok {
+			fmt.Fprintf(w, "\tType: multisig %d/%d\n", m, len(pubs))
+			var signed, missing []string
+			for _, pub := range pubs {
+				pubHex := hex.EncodeToString(pub)
+				if item.Signatures[pubHex] != nil {
+					signed = append(signed, pubHex)
+				} else {
+					missing = append(missing, pubHex)
+				}
+			}
+			fmt.Fprintf(w, "\tSigned (%d):\n", len(signed))
+			for _, k := range signed {
+				fmt.Fprintf(w, "\t\t%s\n", k)
 			}
+			fmt.Fprintf(w, "\tMissing (%d):\n", len(missing))
+			for _, k := range missing {
+				fmt.Fprintf(w, "\t\t%s\n", k)
+			}
+		} else if pub, ok := vm.ParseSignatureContract(item.Script); ok {
+			fmt.Fprintln(w, "\tType: signature")
+			fmt.Fprintf(w, "\tKey: %s\n", hex.EncodeToString(pub))
+		} else {
+			fmt.Fprintln(w, "\tType: contract")
+		}
+		if _, err := pc.GetWitness(s.Account); err == nil {
+			ready++
+			fmt.Fprintln(w, "\tReady: yes")
+		} else {
+			fmt.Fprintln(w, "\tReady: no")
 		}
 	}
+	fmt.Fprintf(w, "Ready signers: %d/%d\n", ready, len(tx.Signers))
+	return nil
+}
 
-	txctx.DumpTransactionInfo(ctx.App.Writer, tx.Hash(), aer)
+func mergeMultisigSessions(ctx *cli.Context) error {
+	out := ctx.String("out")
+	if out == "" {
+		return cli.NewExitError("no output file given", 1)
+	}
+	args := ctx.Args()
+	if len(args) == 0 {
+		return cli.NewExitError("no input files given", 1)
+	}
+	pc, err := paramcontext.Read(args[0])
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("%s: %w", args[0], err), 1)
+	}
+	for _, name := range args[1:] {
+		other, err := paramcontext.Read(name)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("%s: %w", name, err), 1)
+		}
+		if err := pc.Merge(other); err != nil {
+			return cli.NewExitError(fmt.Errorf("can't merge %s: %w", name, err), 1)
+		}
+	}
+	if err := paramcontext.Save(pc, out); err != nil {
+		return cli.NewExitError(err, 1)
+	}
 	return nil
 }
+
+func broadcastMultisigSession(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return sendContext(ctx, pc)
+}
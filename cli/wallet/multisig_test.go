@@ -7,8 +7,10 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -265,3 +267,151 @@ func TestSignMultisigTx(t *testing.T) {
 func deployVerifyContract(t *testing.T, e *testcli.Executor) util.Uint160 {
 	return testcli.DeployContract(t, e, "../smartcontract/testdata/verify.go", "../smartcontract/testdata/verify.yml", testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 }
+
+func TestWalletMultisigSession(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	privs, pubs := testcli.GenerateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(3, pubs)
+	require.NoError(t, err)
+	multisigHash := hash.Hash160(script)
+	multisigAddr := address.Uint160ToString(multisigHash)
+
+	tmpDir := t.TempDir()
+	wallets := make([]string, len(privs))
+	for i := range privs {
+		wallets[i] = filepath.Join(tmpDir, fmt.Sprintf("wallet%d.json", i))
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", wallets[i])
+		e.In.WriteString("acc\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "import-multisig",
+			"--wallet", wallets[i],
+			"--wif", privs[i].WIF(),
+			"--min", "3",
+			hex.EncodeToString(pubs[0].Bytes()),
+			hex.EncodeToString(pubs[1].Bytes()),
+			hex.EncodeToString(pubs[2].Bytes()))
+	}
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+multisigAddr+":4",
+		"GAS:"+multisigAddr+":1")
+	e.CheckTxPersisted(t)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	txPath := filepath.Join(tmpDir, "tx.json")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", wallets[0], "--from", multisigAddr,
+		"--to", priv.Address(), "--token", "NEO", "--amount", "1",
+		"--out", txPath)
+
+	sessionPath := filepath.Join(tmpDir, "session.json")
+	t.Run("create", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "create",
+			"--wallet", wallets[1], "--in", txPath)
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "create",
+			"--wallet", wallets[1], "--out", sessionPath)
+		e.Run(t, "neo-go", "wallet", "multisig", "create",
+			"--wallet", wallets[1], "--in", txPath, "--out", sessionPath)
+	})
+
+	checkStatus := func(t *testing.T, path string, signed int) {
+		e.Run(t, "neo-go", "wallet", "multisig", "status", "--in", path)
+		e.CheckNextLine(t, "^Transaction: [0-9a-f]{64}$")
+		e.CheckNextLine(t, "^Signer: "+multisigAddr+"$")
+		e.CheckNextLine(t, "^\tType: multisig 3/3$")
+		e.CheckNextLine(t, fmt.Sprintf("^\tSigned \\(%d\\):$", signed))
+		for i := 0; i < signed; i++ {
+			e.CheckNextLine(t, "^\t\t[0-9a-f]{66}$")
+		}
+		e.CheckNextLine(t, fmt.Sprintf("^\tMissing \\(%d\\):$", 3-signed))
+		for i := signed; i < 3; i++ {
+			e.CheckNextLine(t, "^\t\t[0-9a-f]{66}$")
+		}
+		if signed == 3 {
+			e.CheckNextLine(t, "^\tReady: yes$")
+			e.CheckNextLine(t, "^Ready signers: 1/1$")
+		} else {
+			e.CheckNextLine(t, "^\tReady: no$")
+			e.CheckNextLine(t, "^Ready signers: 0/1$")
+		}
+		e.CheckEOF(t)
+	}
+	t.Run("status", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "status")
+		checkStatus(t, sessionPath, 1)
+	})
+	t.Run("broadcast, incomplete", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "broadcast",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--in", sessionPath)
+	})
+
+	parts := make([]string, 2)
+	for i := range parts {
+		parts[i] = filepath.Join(tmpDir, fmt.Sprintf("part%d.json", i))
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[i+1], "--address", multisigAddr,
+			"--in", sessionPath, "--out", parts[i])
+		checkStatus(t, parts[i], 2)
+	}
+
+	t.Run("sign with QR", func(t *testing.T) {
+		pc, err := paramcontext.Read(sessionPath)
+		require.NoError(t, err)
+		chunks, err := paramcontext.EncodeQRChunks(pc, 64)
+		require.NoError(t, err)
+
+		e.In.WriteString(strings.Join(chunks, "\r") + "\rpass\r")
+		e.Run(t, "neo-go", "wallet", "sign", "--qr",
+			"--wallet", wallets[1], "--address", multisigAddr)
+		require.True(t, strings.HasPrefix(e.Out.String(), "QR code 1/"))
+		e.Out.Reset()
+
+		qrPath := filepath.Join(tmpDir, "qr.json")
+		e.In.WriteString(strings.Join(chunks, "\r") + "\rpass\r")
+		e.Run(t, "neo-go", "wallet", "sign", "--qr",
+			"--wallet", wallets[1], "--address", multisigAddr, "--out", qrPath)
+		checkStatus(t, qrPath, 2)
+	})
+
+	mergedPath := filepath.Join(tmpDir, "merged.json")
+	t.Run("merge", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "merge", parts[0], parts[1])
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "merge", "--out", mergedPath)
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "merge", "--out", mergedPath,
+			parts[0], filepath.Join(tmpDir, "unknown.json"))
+
+		otherPath := filepath.Join(tmpDir, "other.json")
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", wallets[0], "--from", multisigAddr,
+			"--to", priv.Address(), "--token", "GAS", "--amount", "1",
+			"--out", otherPath)
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "merge", "--out", mergedPath,
+			parts[0], otherPath)
+
+		e.Run(t, "neo-go", "wallet", "multisig", "merge", "--out", mergedPath,
+			sessionPath, parts[0], parts[1])
+		checkStatus(t, mergedPath, 3)
+	})
+
+	t.Run("broadcast", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "multisig", "broadcast",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--in", mergedPath, "--await")
+		e.CheckAwaitableTxPersisted(t)
+	})
+
+	b, _ := e.Chain.GetGoverningTokenBalance(priv.GetScriptHash())
+	require.Equal(t, big.NewInt(1), b)
+}
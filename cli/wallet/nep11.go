@@ -49,11 +49,12 @@ func newNEP11Commands() []cli.Command {
 		{
 			Name:      "balance",
 			Usage:     "get address balance",
-			UsageText: "balance -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] [--address <address>] [--token <hash-or-name>] [--id <token-id>]",
+			UsageText: "balance -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] [--address <address> | --tag <tag> ...] [--token <hash-or-name>] [--id <token-id>]",
 			Description: `Prints NEP-11 balances for address and assets/IDs specified. By default (no
    address or token parameter) all tokens (NFT contracts) for all accounts in
    the specified wallet are listed with all tokens (actual NFTs) insied. A
-   single account can be chosen with the address option and/or a single NFT
+   single account can be chosen with the address option (or a set of accounts
+   with the tag option) and/or a single NFT
    contract can be selected with the token option. Further, you can specify a
    particular NFT ID (hex-encoded) to display (which is mostly useful for
    divisible NFTs). Tokens can be specified by hash, address, name or symbol.
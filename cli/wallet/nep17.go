@@ -47,6 +47,7 @@ var (
 		walletPathFlag,
 		walletConfigFlag,
 		tokenFlag,
+		tagFlag,
 		flags.AddressFlag{
 			Name:  "address, a",
 			Usage: "Address to use",
@@ -99,10 +100,11 @@ func newNEP17Commands() []cli.Command {
 		{
 			Name:      "balance",
 			Usage:     "get address balance",
-			UsageText: "balance -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] [--address <address>] [--token <hash-or-name>]",
+			UsageText: "balance -w wallet [--wallet-config path] --rpc-endpoint <node> [--timeout <time>] [--address <address> | --tag <tag> ...] [--token <hash-or-name>]",
 			Description: `Prints NEP-17 balances for address and tokens specified. By default (no
    address or token parameter) all tokens for all accounts in the specified wallet
-   are listed. A single account can be chosen with the address option and/or a
+   are listed. A single account can be chosen with the address option (or a set
+   of accounts with the tag option) and/or a
    single token can be selected with the token option. Tokens can be specified
    by hash, address, name or symbol. Hashes and addresses always work (as long
    as they belong to a correct NEP-17 contract), while names or symbols (if
@@ -235,10 +237,10 @@ func getNEPBalance(ctx *cli.Context, standard string, accHandler func(*cli.Conte
 		}
 		accounts = append(accounts, acc)
 	} else {
-		if len(wall.Accounts) == 0 {
-			return cli.NewExitError(errors.New("no accounts in the wallet"), 1)
+		accounts, err = getTaggedAccounts(ctx, wall)
+		if err != nil {
+			return cli.NewExitError(err, 1)
 		}
-		accounts = wall.Accounts
 	}
 
 	gctx, cancel := options.GetTimeoutContext(ctx)
@@ -526,7 +528,10 @@ func multiTransferNEP17(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	acc, err := options.GetUnlockedAccount(wall, from, pass)
+	if err := options.AttachRemoteSigner(ctx, wall); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc, err := options.GetAccountForTx(ctx, wall, from, pass)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -620,7 +625,10 @@ func transferNEP(ctx *cli.Context, standard string) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	acc, err := options.GetUnlockedAccount(wall, from, pass)
+	if err := options.AttachRemoteSigner(ctx, wall); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc, err := options.GetAccountForTx(ctx, wall, from, pass)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -0,0 +1,236 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/input"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+func newRekeyCommand() cli.Command {
+	def := keys.DefaultArgon2idParams()
+	return cli.Command{
+		Name:      "rekey",
+		Usage:     "re-encrypt all wallet keys with new KDF parameters",
+		UsageText: "neo-go wallet rekey -w wallet [--wallet-config path] [--scrypt-n n] [--scrypt-r r] [--scrypt-p p] [--argon2id [--argon2-time t] [--argon2-memory KiB] [--argon2-threads n]] [--change-password]",
+		Description: `Re-encrypts keys of all wallet accounts (watch-only ones are skipped)
+   using new scrypt parameters (unset ones are kept as is) or Argon2id if
+   --argon2id flag is given. Argon2id is a NeoGo extension of NEP-2, its
+   parameters are stored in the "extra" section of every account and other
+   wallets won't be able to decrypt such keys. Accounts created in the wallet
+   later still use its scrypt parameters.
+
+   All accounts are to be decrypted with the same password (the new one is
+   asked for if --change-password is given). Every key is checked to decrypt
+   correctly with the new parameters before saving, if anything fails the
+   wallet is not changed at all. The file is replaced atomically when saving
+   (a new one is written and renamed, so the wallet directory must be
+   writable), symlinks are followed and file permissions are kept.
+`,
+		Action: rekeyWallet,
+		Flags: []cli.Flag{
+			walletPathFlag,
+			walletConfigFlag,
+			cli.IntFlag{
+				Name:  "scrypt-n",
+				Usage: "New scrypt N (CPU/memory cost) parameter, must be a power of two",
+			},
+			cli.IntFlag{
+				Name:  "scrypt-r",
+				Usage: "New scrypt r (block size) parameter",
+			},
+			cli.IntFlag{
+				Name:  "scrypt-p",
+				Usage: "New scrypt p (parallelization) parameter",
+			},
+			cli.BoolFlag{
+				Name:  "argon2id",
+				Usage: "Use Argon2id instead of scrypt (NeoGo extension of NEP-2)",
+			},
+			cli.UintFlag{
+				Name:  "argon2-time",
+				Usage: "Argon2id number of passes",
+				Value: uint(def.Time),
+			},
+			cli.UintFlag{
+				Name:  "argon2-memory",
+				Usage: "Argon2id memory size in KiB",
+				Value: uint(def.Memory),
+			},
+			cli.UintFlag{
+				Name:  "argon2-threads",
+				Usage: "Argon2id number of threads",
+				Value: uint(def.Threads),
+			},
+			cli.BoolFlag{
+				Name:  "change-password",
+				Usage: "Set a new password for all accounts",
+			},
+		},
+	}
+}
+
+func rekeyWallet(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	var (
+		argon     *keys.Argon2idParams
+		scryptSet = ctx.IsSet("scrypt-n") || ctx.IsSet("scrypt-r") || ctx.IsSet("scrypt-p")
+	)
+	if ctx.Bool("argon2id") {
+		if scryptSet {
+			return cli.NewExitError(errors.New("scrypt parameters can't be used with --argon2id"), 1)
+		}
+		var (
+			t   = ctx.Uint("argon2-time")
+			m   = ctx.Uint("argon2-memory")
+			thr = ctx.Uint("argon2-threads")
+		)
+		if t > math.MaxUint32 || m > math.MaxUint32 || thr > math.MaxUint8 {
+			return cli.NewExitError(errors.New("Argon2id parameters are too big"), 1)
+		}
+		argon = &keys.Argon2idParams{Time: uint32(t), Memory: uint32(m), Threads: uint8(thr)}
+	} else if !scryptSet && !ctx.Bool("change-password") {
+		return cli.NewExitError(errors.New("no new parameters given"), 1)
+	}
+
+	wall, pass, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	scrypt := wall.Scrypt
+	if ctx.IsSet("scrypt-n") {
+		scrypt.N = ctx.Int("scrypt-n")
+	}
+	if ctx.IsSet("scrypt-r") {
+		scrypt.R = ctx.Int("scrypt-r")
+	}
+	if ctx.IsSet("scrypt-p") {
+		scrypt.P = ctx.Int("scrypt-p")
+	}
+
+	var accounts []*wallet.Account
+	for _, acc := range wall.Accounts {
+		if acc.EncryptedWIF != "" {
+			accounts = append(accounts, acc)
+		}
+	}
+	if len(accounts) == 0 {
+		return cli.NewExitError(errors.New("wallet has no accounts with keys"), 1)
+	}
+
+	if pass == nil {
+		password, err := input.ReadPassword(EnterPasswordPrompt)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("Error reading password: %w", err), 1)
+		}
+		pass = &password
+	}
+	for _, acc := range accounts {
+		if err := acc.Decrypt(*pass, wall.Scrypt); err != nil {
+			return cli.NewExitError(fmt.Errorf("unable to decrypt account %s: %w", acc.Address, err), 1)
+		}
+	}
+	newPass := *pass
+	if ctx.Bool("change-password") {
+		newPass, err = readNewPassword()
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("Error reading new password: %w", err), 1)
+		}
+	}
+
+	// Re-encrypt copies first, so that nothing is changed if any of the keys
+	// fails.
+	rekeyed := make([]wallet.Account, len(accounts))
+	for i, acc := range accounts {
+		rekeyed[i] = *acc
+		if acc.Extra != nil {
+			extra := *acc.Extra
+			rekeyed[i].Extra = &extra
+		}
+		if argon != nil {
+			err = rekeyed[i].EncryptArgon2id(newPass, *argon)
+		} else {
+			err = rekeyed[i].Encrypt(newPass, scrypt)
+		}
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("unable to encrypt account %s: %w", acc.Address, err), 1)
+		}
+		check := wallet.Account{EncryptedWIF: rekeyed[i].EncryptedWIF, Extra: rekeyed[i].Extra}
+		err = check.Decrypt(newPass, scrypt)
+		if err == nil && !bytes.Equal(check.PrivateKey().Bytes(), acc.PrivateKey().Bytes()) {
+			err = errors.New("key mismatch")
+		}
+		check.Close()
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to verify account %s: %w", acc.Address, err), 1)
+		}
+	}
+	for i, acc := range accounts {
+		acc.EncryptedWIF = rekeyed[i].EncryptedWIF
+		acc.Extra = rekeyed[i].Extra
+	}
+	wall.Scrypt = scrypt
+	if err := saveWalletAtomic(wall); err != nil {
+		return cli.NewExitError(fmt.Errorf("Error saving the wallet: %w", err), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Rekeyed %d account(s)\n", len(accounts))
+	return nil
+}
+
+// saveWalletAtomic saves the wallet like wallet.Wallet.Save does, but writes
+// it into a temporary file first and then renames it, so the wallet file has
+// either the old or the new contents even if saving fails. It requires the
+// wallet directory to be writable, symlinks are followed (the target file is
+// replaced) and file permissions are preserved.
+func saveWalletAtomic(wall *wallet.Wallet) error {
+	data, err := json.Marshal(wall)
+	if err != nil {
+		return err
+	}
+	path := wall.Path()
+	if path == "" {
+		return wallet.ErrPathIsEmpty
+	}
+	mode := os.FileMode(0644)
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+		if fi, err := os.Stat(path); err == nil {
+			mode = fi.Mode().Perm()
+		}
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, mode)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package wallet_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletRekey(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmp := t.TempDir()
+
+	checkUnchanged := func(t *testing.T, path string, orig []byte) {
+		actual, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, orig, actual)
+	}
+
+	t.Run("partial", func(t *testing.T) {
+		path := filepath.Join(tmp, "validator.json")
+		orig, err := os.ReadFile(testcli.ValidatorWallet)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, orig, 0644))
+
+		// The last account uses another password.
+		e.In.WriteString("one\r")
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path, "--scrypt-n", "1024")
+		checkUnchanged(t, path, orig)
+	})
+
+	path := filepath.Join(tmp, "wallet.json")
+	w, err := wallet.NewWallet(path)
+	require.NoError(t, err)
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+	privs := make([][]byte, 2)
+	for i := range privs {
+		acc, err := wallet.NewAccount()
+		require.NoError(t, err)
+		privs[i] = acc.PrivateKey().Bytes()
+		require.NoError(t, acc.Encrypt("pass", w.Scrypt))
+		w.AddAccount(acc)
+	}
+	w.AddAccount(wallet.NewWatchOnlyAccount(util.Uint160{1, 2, 3}))
+	require.NoError(t, w.Save())
+	w.Close()
+
+	checkWallet := func(t *testing.T, pass string, scrypt keys.ScryptParams, argon *keys.Argon2idParams) {
+		w, err := wallet.NewWalletFromFile(path)
+		require.NoError(t, err)
+		defer w.Close()
+		require.Equal(t, scrypt, w.Scrypt)
+		require.Equal(t, 3, len(w.Accounts))
+		for i, priv := range privs {
+			acc := w.Accounts[i]
+			if argon == nil {
+				require.Nil(t, acc.Extra)
+			} else {
+				require.Equal(t, argon, acc.Extra.Argon2id)
+			}
+			require.NoError(t, acc.Decrypt(pass, w.Scrypt))
+			require.Equal(t, priv, acc.PrivateKey().Bytes())
+		}
+		require.Nil(t, w.Accounts[2].Extra)
+		require.Equal(t, "", w.Accounts[2].EncryptedWIF)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		orig, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path)
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path, "--argon2id", "--scrypt-n", "1024")
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path, "--argon2id", "--argon2-threads", "256")
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path, "--scrypt-n", "1024", "extra")
+
+		e.In.WriteString("wrong\r")
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path, "--scrypt-n", "1024")
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path, "--scrypt-n", "1000")
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, "neo-go", "wallet", "rekey", "--wallet", path, "--argon2id", "--argon2-time", "0")
+		checkUnchanged(t, path, orig)
+	})
+
+	t.Run("scrypt", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "rekey", "--wallet", path, "--scrypt-n", "1024", "--scrypt-r", "4")
+		e.CheckNextLine(t, "^Rekeyed 2 account\\(s\\)$")
+		e.CheckEOF(t)
+		checkWallet(t, "pass", keys.ScryptParams{N: 1024, R: 4, P: 1}, nil)
+	})
+
+	argon := &keys.Argon2idParams{Time: 1, Memory: 64, Threads: 1}
+	t.Run("argon2id", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.In.WriteString("newpass\r")
+		e.In.WriteString("newpass\r")
+		e.Run(t, "neo-go", "wallet", "rekey", "--wallet", path, "--argon2id",
+			"--argon2-time", "1", "--argon2-memory", "64", "--argon2-threads", "1",
+			"--change-password")
+		e.CheckNextLine(t, "^Rekeyed 2 account\\(s\\)$")
+		e.CheckEOF(t)
+		checkWallet(t, "newpass", keys.ScryptParams{N: 1024, R: 4, P: 1}, argon)
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		e.In.WriteString("newpass\r")
+		e.In.WriteString("other\r")
+		e.In.WriteString("other\r")
+		e.Run(t, "neo-go", "wallet", "change-password", "--wallet", path)
+		e.Out.Reset()
+		checkWallet(t, "other", keys.ScryptParams{N: 1024, R: 4, P: 1}, argon)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		w, err := wallet.NewWalletFromFile(path)
+		require.NoError(t, err)
+		acc := w.Accounts[0]
+		require.NoError(t, acc.Decrypt("other", w.Scrypt))
+		wif := acc.PrivateKey().WIF()
+		w.Close()
+
+		// NEP-2 string is useless without Argon2id parameters.
+		e.RunWithError(t, "neo-go", "wallet", "export", "--wallet", path, acc.Address)
+		e.RunWithError(t, "neo-go", "wallet", "export", "--wallet", path)
+
+		e.In.WriteString("other\r")
+		e.Run(t, "neo-go", "wallet", "export", "--wallet", path, "--decrypt", acc.Address)
+		e.CheckNextLine(t, wif)
+		e.CheckEOF(t)
+	})
+
+	t.Run("backup", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "backup", "--wallet", path,
+			"--shares", "3", "--threshold", "2")
+	})
+
+	t.Run("back to scrypt", func(t *testing.T) {
+		e.In.WriteString("other\r")
+		e.Run(t, "neo-go", "wallet", "rekey", "--wallet", path, "--scrypt-p", "2")
+		e.CheckNextLine(t, "^Rekeyed 2 account\\(s\\)$")
+		e.CheckEOF(t)
+		checkWallet(t, "other", keys.ScryptParams{N: 1024, R: 4, P: 2}, nil)
+	})
+
+	t.Run("symlink", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("symlinks and file permissions are not portable")
+		}
+		require.NoError(t, os.Chmod(path, 0600))
+		dir := t.TempDir()
+		link := filepath.Join(dir, "link.json")
+		require.NoError(t, os.Symlink(path, link))
+
+		e.In.WriteString("other\r")
+		e.Run(t, "neo-go", "wallet", "rekey", "--wallet", link, "--scrypt-p", "1")
+		e.CheckNextLine(t, "^Rekeyed 2 account\\(s\\)$")
+		e.CheckEOF(t)
+		checkWallet(t, "other", keys.ScryptParams{N: 1024, R: 4, P: 1}, nil)
+
+		fi, err := os.Lstat(link)
+		require.NoError(t, err)
+		require.True(t, fi.Mode()&os.ModeSymlink != 0)
+		fi, err = os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+		// No temporary files left.
+		entries, err := os.ReadDir(tmp)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(entries))
+	})
+}
@@ -112,7 +112,10 @@ func handleNeoAction(ctx *cli.Context, mkTx func(*neo.Contract, util.Uint160, *w
 		return cli.NewExitError("address was not provided", 1)
 	}
 	addr := addrFlag.Uint160()
-	acc, err := options.GetUnlockedAccount(wall, addr, pass)
+	if err := options.AttachRemoteSigner(ctx, wall); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	acc, err := options.GetAccountForTx(ctx, wall, addr, pass)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
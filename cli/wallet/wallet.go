@@ -37,6 +37,8 @@ const (
 	EnterOldPasswordPrompt = "Enter old password > "
 	// ConfirmPasswordPrompt is a prompt used to confirm the password.
 	ConfirmPasswordPrompt = "Confirm password > "
+	// EnterMnemonicPrompt is a prompt used to ask the user for a mnemonic phrase.
+	EnterMnemonicPrompt = "Enter mnemonic phrase > "
 )
 
 var (
@@ -103,12 +105,52 @@ func NewCommands() []cli.Command {
 			Name:  "address, a",
 			Usage: "Address to use",
 		},
+		cli.BoolFlag{
+			Name:  "qr",
+			Usage: "Read input from QR codes (if no input file is given) and print the result as QR codes",
+		},
 	}
 	signFlags = append(signFlags, options.RPC...)
 	return []cli.Command{{
 		Name:  "wallet",
 		Usage: "create, open and manage a Neo wallet",
 		Subcommands: []cli.Command{
+			{
+				Name:      "backup",
+				Usage:     "split wallet keys into Shamir's secret sharing shares",
+				UsageText: "neo-go wallet backup -w wallet [--wallet-config path] --shares N --threshold M [--decrypt] [--out dir]",
+				Description: `Creates a backup of all wallet accounts with keys (watch-only accounts
+   are skipped) split into N shares any M of which are needed to restore them
+   with 'restore' command (fewer shares reveal nothing about the keys, but
+   every share contains a short checksum of the backup data). By
+   default NEP-2 encrypted keys are stored (so the wallet password is still
+   needed to use restored accounts), with --decrypt raw private keys are
+   stored instead (allowing to restore accounts without the password). Shares
+   are printed one per line or saved into share-<index>.txt files in the
+   given directory if --out is used, keep them in different places.
+`,
+				Action: backupWallet,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+					cli.IntFlag{
+						Name:  "shares, n",
+						Usage: "Number of shares to create",
+					},
+					cli.IntFlag{
+						Name:  "threshold, m",
+						Usage: "Number of shares needed to restore the keys",
+					},
+					cli.BoolFlag{
+						Name:  "decrypt, d",
+						Usage: "Back up decrypted keys",
+					},
+					cli.StringFlag{
+						Name:  "out, o",
+						Usage: "Directory to save shares to",
+					},
+				},
+			},
 			{
 				Name:      "claim",
 				Usage:     "claim GAS",
@@ -119,8 +161,17 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "init",
 				Usage:     "create a new wallet",
-				UsageText: "neo-go wallet init -w wallet [--wallet-config path] [-a]",
-				Action:    createWallet,
+				UsageText: "neo-go wallet init -w wallet [--wallet-config path] [-a] [--mnemonic [--words n] | --recover [--count n]]",
+				Description: `Creates a new wallet. If -a is given, a new random account is created
+   in it. If --mnemonic is given, a new BIP-39 mnemonic phrase is generated
+   (with the number of words specified by --words, 24 by default), the first
+   account is derived from it using m/44'/888'/0'/0/0 path and the phrase is
+   printed, write it down and keep it safe, it's the only thing needed to
+   restore the wallet. If --recover is given, the phrase is read from the
+   terminal and the number of accounts specified by --count (1 by default) is
+   derived from it.
+`,
+				Action: createWallet,
 				Flags: []cli.Flag{
 					walletPathFlag,
 					walletConfigFlag,
@@ -128,6 +179,24 @@ func NewCommands() []cli.Command {
 						Name:  "account, a",
 						Usage: "Create a new account",
 					},
+					cli.BoolFlag{
+						Name:  "mnemonic",
+						Usage: "Generate a new mnemonic phrase and derive the first account from it",
+					},
+					cli.IntFlag{
+						Name:  "words",
+						Usage: "Number of words in the generated mnemonic phrase (12, 15, 18, 21 or 24)",
+						Value: 24,
+					},
+					cli.BoolFlag{
+						Name:  "recover",
+						Usage: "Derive accounts from an existing mnemonic phrase",
+					},
+					cli.IntFlag{
+						Name:  "count",
+						Usage: "Number of accounts to derive when recovering",
+						Value: 1,
+					},
 				},
 			},
 			{
@@ -160,11 +229,19 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "create",
 				Usage:     "add an account to the existing wallet",
-				UsageText: "neo-go wallet create -w wallet [--wallet-config path]",
-				Action:    addAccount,
+				UsageText: "neo-go wallet create -w wallet [--wallet-config path] [--derive]",
+				Description: `Adds a new account to the wallet. If --derive is given, the account key
+   is derived from the mnemonic phrase read from the terminal using the next
+   unused m/44'/888'/0'/0/i path, otherwise a random key is generated.
+`,
+				Action: addAccount,
 				Flags: []cli.Flag{
 					walletPathFlag,
 					walletConfigFlag,
+					cli.BoolFlag{
+						Name:  "derive",
+						Usage: "Derive the account from a mnemonic phrase",
+					},
 				},
 			},
 			{
@@ -186,11 +263,12 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "dump-keys",
 				Usage:     "dump public keys for account",
-				UsageText: "neo-go wallet dump-keys -w wallet [--wallet-config path] [-a address]",
+				UsageText: "neo-go wallet dump-keys -w wallet [--wallet-config path] [-a address | --tag tag ...]",
 				Action:    dumpKeys,
 				Flags: []cli.Flag{
 					walletPathFlag,
 					walletConfigFlag,
+					tagFlag,
 					flags.AddressFlag{
 						Name:  "address, a",
 						Usage: "address to print public keys for",
@@ -205,7 +283,8 @@ func NewCommands() []cli.Command {
    encrypted format by default (the way NEP-6 wallets store it) or WIF format if
    -d option is given. In the latter case the key can be displayed in clear text
    on the console, so be extremely careful with this option and don't use unless
-   you really need it and know what you're doing.
+   you really need it and know what you're doing. Keys encrypted with Argon2id
+   (see 'wallet rekey') can only be exported with -d option.
 `,
 				Action: exportKeys,
 				Flags: []cli.Flag{
@@ -214,6 +293,46 @@ func NewCommands() []cli.Command {
 					decryptFlag,
 				},
 			},
+			newGovernanceCommand(),
+			{
+				Name:      "history",
+				Usage:     "show account history",
+				UsageText: "neo-go wallet history -w wallet [--wallet-config path] -r endpoint [-s timeout] [-a address | --tag tag ...] [--start time] [--end time] [--format table|json|csv]",
+				Description: `Prints the history of the given account (or all wallet accounts if no
+   address is given, accounts can also be selected with --tag) for the
+   specified time range (all history up to the
+   current moment by default). Start and end times can be specified in RFC3339
+   format, as a date (YYYY-MM-DD) or as a Unix timestamp (in seconds). History
+   is built from NEP-17 and NEP-11 transfers data (which requires the node to
+   have token transfers tracking enabled) and includes incoming/outgoing
+   transfers, token minting/burning, GAS claims and rewards, fees paid (for
+   transactions sent by the account) and votes (made by transactions sent by
+   the account). Output can be formatted as a table (default), JSON or CSV.
+`,
+				Action: walletHistory,
+				Flags: append([]cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+					flags.AddressFlag{
+						Name:  "address, a",
+						Usage: "Address to show history for",
+					},
+					tagFlag,
+					cli.StringFlag{
+						Name:  "start",
+						Usage: "Start time of the history",
+					},
+					cli.StringFlag{
+						Name:  "end",
+						Usage: "End time of the history",
+					},
+					cli.StringFlag{
+						Name:  "format",
+						Usage: "Output format (table, json or csv)",
+						Value: "table",
+					},
+				}, options.RPC...),
+			},
 			{
 				Name:      "import",
 				Usage:     "import WIF of a standard signature contract",
@@ -279,6 +398,77 @@ func NewCommands() []cli.Command {
 					},
 				}, options.RPC...),
 			},
+			{
+				Name:      "import-watch",
+				Usage:     "import watch-only account",
+				UsageText: "import-watch -w wallet [--wallet-config path] [--name <account_name>] [--tag tag ...] <address|pubkey>",
+				Description: `Adds an account without any keys to the wallet. If an address (or script
+   hash in LE form) is given, the account has no verification script at all
+   (NEP-6 watch-only account), it can be used to check balances and history
+   of this address. If a public key is given, a standard signature account is
+   created for it, it can additionally be used as a transaction signer with
+   signatures added elsewhere (see 'sign' command).
+`,
+				Action: importWatchOnly,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+					cli.StringFlag{
+						Name:  "name, n",
+						Usage: "Optional account name",
+					},
+					cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "Optional account tag (can be repeated)",
+					},
+				},
+			},
+			{
+				Name:      "label",
+				Usage:     "change account name and tags",
+				UsageText: "label -w wallet [--wallet-config path] -a address [--name <account_name>] [--tag tag ...] [--untag tag ...]",
+				Description: `Changes the name (label) and tags of the given account. Tags are
+   arbitrary strings that can be used to group accounts and select them in
+   commands working with all wallet accounts (see --tag option of 'list',
+   'dump-keys', 'history' and 'balance' commands).
+`,
+				Action: setAccountLabel,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+					flags.AddressFlag{
+						Name:  "address, a",
+						Usage: "Account address or hash in LE form",
+					},
+					cli.StringFlag{
+						Name:  "name, n",
+						Usage: "New account name",
+					},
+					cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "Tag to add (can be repeated)",
+					},
+					cli.StringSliceFlag{
+						Name:  "untag",
+						Usage: "Tag to remove (can be repeated)",
+					},
+				},
+			},
+			{
+				Name:      "list",
+				Usage:     "list wallet accounts",
+				UsageText: "list -w wallet [--wallet-config path] [--tag tag ...]",
+				Description: `Prints addresses, names, types and tags of all wallet accounts (or the
+   ones having any of the given tags) as a table.
+`,
+				Action: listAccounts,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+					tagFlag,
+				},
+			},
+			newRekeyCommand(),
 			{
 				Name:      "remove",
 				Usage:     "remove an account from the wallet",
@@ -294,10 +484,28 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "restore",
+				Usage:     "restore accounts from backup shares",
+				UsageText: "neo-go wallet restore -w wallet [--wallet-config path] [share ...]",
+				Description: `Restores accounts from the shares created by 'backup' command and adds
+   them to the given wallet (it's created if it doesn't exist). Shares can be
+   given as arguments, otherwise they're read from the input interactively
+   until the number of them is enough for restoration. Accounts backed up
+   with NEP-2 keys keep their password (the wallet must use the same scrypt
+   parameters as the original one then), raw keys are encrypted with the new
+   password.
+`,
+				Action: restoreWallet,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+				},
+			},
 			{
 				Name:      "sign",
 				Usage:     "cosign transaction with multisig/contract/additional account",
-				UsageText: "sign -w wallet [--wallet-config path] --address <address> --in <file.in> [--out <file.out>] [-r <endpoint>] [--await]",
+				UsageText: "sign -w wallet [--wallet-config path] --address <address> [--in <file.in>] [--qr] [--out <file.out>] [-r <endpoint>] [--await]",
 				Description: `Signs the given (in file.in) context (which must be a transaction
    signing context) for the given address using the given wallet. This command can
    output the resulting JSON (with additional signature added) right to the console
@@ -305,11 +513,40 @@ func NewCommands() []cli.Command {
    same as input one). If an RPC endpoint is given it'll also try to construct a
    complete transaction and send it via RPC (printing its hash if everything is OK). 
    If the --await (with a given RPC endpoint) flag is included, the command waits 
-   for the transaction to be included in a block before exiting.
+   for the transaction to be included in a block before exiting. The --qr flag
+   allows to read the context from QR codes (see 'util qrexport', QR contents
+   are requested interactively if no input file is given) and makes the command
+   print the resulting context as a series of QR codes instead of JSON, which
+   allows to sign transactions on air-gapped machines.
 `,
 				Action: signStoredTransaction,
 				Flags:  signFlags,
 			},
+			{
+				Name:      "sign-message",
+				Usage:     "sign an arbitrary message",
+				UsageText: "neo-go wallet sign-message -w wallet [--wallet-config path] [-a address] [--no-salt] <message>",
+				Description: `Signs the given message with the key of the given account (default
+   wallet account is used if no address is given) proving the ownership of
+   this account. Signed message is printed in JSON format (compatible with
+   NeoLine and O3 wallets signMessageV2 and signMessageWithoutSaltV2 dAPI
+   methods) containing the message, random salt (unless --no-salt is used),
+   public key and signature. It can be checked with 'verify-message' command.
+`,
+				Action: signMessage,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+					flags.AddressFlag{
+						Name:  "address, a",
+						Usage: "address of the account to sign message with",
+					},
+					cli.BoolFlag{
+						Name:  "no-salt",
+						Usage: "don't use random salt",
+					},
+				},
+			},
 			{
 				Name:      "strip-keys",
 				Usage:     "remove private keys for all accounts",
@@ -327,6 +564,32 @@ func NewCommands() []cli.Command {
 					txctx.ForceFlag,
 				},
 			},
+			{
+				Name:      "verify-message",
+				Usage:     "verify signed message",
+				UsageText: "neo-go wallet verify-message [-a address] {<signed message JSON> | --in <file>}",
+				Description: `Verifies the message signed by 'sign-message' command or any other
+   NeoLine-compatible wallet. Signed message JSON is given as an argument or
+   via a file. If an address is given, it also checks that the message is
+   signed by the key corresponding to this address.
+`,
+				Action: verifyMessage,
+				Flags: []cli.Flag{
+					flags.AddressFlag{
+						Name:  "address, a",
+						Usage: "expected signer address",
+					},
+					cli.StringFlag{
+						Name:  "in",
+						Usage: "file with signed message JSON",
+					},
+				},
+			},
+			{
+				Name:        "multisig",
+				Usage:       "coordinate multisignature transaction signing",
+				Subcommands: newMultisigCommands(),
+			},
 			{
 				Name:        "nep17",
 				Usage:       "work with NEP-17 contracts",
@@ -371,6 +634,9 @@ func changePassword(ctx *cli.Context) error {
 		if acc == nil {
 			return cli.NewExitError("account is missing", 1)
 		}
+		if acc.EncryptedWIF == "" {
+			return cli.NewExitError("account has no key", 1)
+		}
 	}
 
 	oldPass, err := input.ReadPassword(EnterOldPasswordPrompt)
@@ -379,7 +645,8 @@ func changePassword(ctx *cli.Context) error {
 	}
 
 	for i := range wall.Accounts {
-		if addrFlag.IsSet && wall.Accounts[i].Address != addrFlag.String() {
+		if addrFlag.IsSet && wall.Accounts[i].Address != addrFlag.String() ||
+			wall.Accounts[i].EncryptedWIF == "" {
 			continue
 		}
 		err := wall.Accounts[i].Decrypt(oldPass, wall.Scrypt)
@@ -393,10 +660,15 @@ func changePassword(ctx *cli.Context) error {
 		return cli.NewExitError(fmt.Errorf("Error reading new password: %w", err), 1)
 	}
 	for i := range wall.Accounts {
-		if addrFlag.IsSet && wall.Accounts[i].Address != addrFlag.String() {
+		if addrFlag.IsSet && wall.Accounts[i].Address != addrFlag.String() ||
+			wall.Accounts[i].EncryptedWIF == "" {
 			continue
 		}
-		err := wall.Accounts[i].Encrypt(pass, wall.Scrypt)
+		if extra := wall.Accounts[i].Extra; extra != nil && extra.Argon2id != nil {
+			err = wall.Accounts[i].EncryptArgon2id(pass, *extra.Argon2id)
+		} else {
+			err = wall.Accounts[i].Encrypt(pass, wall.Scrypt)
+		}
 		if err != nil {
 			return cli.NewExitError(err, 1)
 		}
@@ -458,6 +730,17 @@ func addAccount(ctx *cli.Context) error {
 	}
 	defer wall.Close()
 
+	if ctx.Bool("derive") {
+		phrase, err := input.ReadPassword(EnterMnemonicPrompt)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("Error reading mnemonic: %w", err), 1)
+		}
+		err = createDerivedAccounts(wall, pass, phrase, 1)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		return nil
+	}
 	if err := createAccount(wall, pass); err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -486,7 +769,7 @@ func exportKeys(ctx *cli.Context) error {
 		}
 	}
 
-	var wifs []string
+	var accs []*wallet.Account
 
 loop:
 	for _, a := range wall.Accounts {
@@ -494,16 +777,27 @@ loop:
 			continue
 		}
 
-		for i := range wifs {
-			if a.EncryptedWIF == wifs[i] {
+		for i := range accs {
+			if a.EncryptedWIF == accs[i].EncryptedWIF {
 				continue loop
 			}
 		}
 
-		wifs = append(wifs, a.EncryptedWIF)
+		accs = append(accs, a)
 	}
 
-	for _, wif := range wifs {
+	if !decrypt {
+		for _, a := range accs {
+			// Such keys look like NEP-2, but can't be decrypted without
+			// Argon2id parameters.
+			if a.Extra != nil && a.Extra.Argon2id != nil {
+				return cli.NewExitError(fmt.Errorf("account %s uses Argon2id encryption, use --decrypt to export it", a.Address), 1)
+			}
+		}
+	}
+
+	for _, a := range accs {
+		wif := a.EncryptedWIF
 		if decrypt {
 			if pass == nil {
 				password, err := input.ReadPassword(EnterPasswordPrompt)
@@ -513,12 +807,11 @@ loop:
 				pass = &password
 			}
 
-			pk, err := keys.NEP2Decrypt(wif, *pass, wall.Scrypt)
-			if err != nil {
+			if err := a.Decrypt(*pass, wall.Scrypt); err != nil {
 				return cli.NewExitError(err, 1)
 			}
 
-			wif = pk.WIF()
+			wif = a.PrivateKey().WIF()
 		}
 
 		fmt.Fprintln(ctx.App.Writer, wif)
@@ -769,6 +1062,9 @@ func dumpWallet(ctx *cli.Context) error {
 			pass = &password
 		}
 		for i := range wall.Accounts {
+			if wall.Accounts[i].EncryptedWIF == "" {
+				continue // Watch-only account.
+			}
 			// Just testing the decryption here.
 			err := wall.Accounts[i].Decrypt(*pass, wall.Scrypt)
 			if err != nil {
@@ -789,8 +1085,8 @@ func dumpKeys(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 	defer wall.Close()
-	accounts := wall.Accounts
 
+	var accounts []*wallet.Account
 	addrFlag := ctx.Generic("address").(*flags.Address)
 	if addrFlag.IsSet {
 		acc := wall.GetAccount(addrFlag.Uint160())
@@ -798,10 +1094,21 @@ func dumpKeys(ctx *cli.Context) error {
 			return cli.NewExitError("account is missing", 1)
 		}
 		accounts = []*wallet.Account{acc}
+	} else {
+		accounts, err = getTaggedAccounts(ctx, wall)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
 	}
 
 	hasPrinted := false
 	for _, acc := range accounts {
+		if acc.Contract == nil {
+			if addrFlag.IsSet {
+				return cli.NewExitError(fmt.Errorf("no verification script for address %s", acc.Address), 1)
+			}
+			continue
+		}
 		pub, ok := vm.ParseSignatureContract(acc.Contract.Script)
 		if ok {
 			if hasPrinted {
@@ -868,6 +1175,38 @@ func createWallet(ctx *cli.Context) error {
 	if len(path) == 0 && len(configPath) == 0 {
 		return cli.NewExitError(errNoPath, 1)
 	}
+	var (
+		genMnemonic = ctx.Bool("mnemonic")
+		recoverHD   = ctx.Bool("recover")
+		mnemonic    string
+	)
+	if btoi(ctx.Bool("account"))+btoi(genMnemonic)+btoi(recoverHD) > 1 {
+		return cli.NewExitError(errors.New("only one of '--account', '--mnemonic' and '--recover' can be used"), 1)
+	}
+	if genMnemonic {
+		words := ctx.Int("words")
+		if words < 12 || words > 24 || words%3 != 0 {
+			return cli.NewExitError(fmt.Errorf("invalid number of words: %d", words), 1)
+		}
+		var err error
+		mnemonic, err = wallet.NewMnemonic(words * 32 / 3)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	if recoverHD {
+		if ctx.Int("count") < 1 {
+			return cli.NewExitError(errors.New("number of accounts must be positive"), 1)
+		}
+		var err error
+		mnemonic, err = input.ReadPassword(EnterMnemonicPrompt)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("Error reading mnemonic: %w", err), 1)
+		}
+		if _, err = wallet.MnemonicToEntropy(mnemonic); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
 	var pass *string
 	if len(configPath) != 0 {
 		cfg, err := options.ReadWalletConfig(configPath)
@@ -891,12 +1230,34 @@ func createWallet(ctx *cli.Context) error {
 		}
 		defer wall.Close()
 	}
+	if genMnemonic || recoverHD {
+		count := 1
+		if recoverHD {
+			count = ctx.Int("count")
+		}
+		if err := createDerivedAccounts(wall, pass, mnemonic, count); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer wall.Close()
+	}
 
 	fmtPrintWallet(ctx.App.Writer, wall)
 	fmt.Fprintf(ctx.App.Writer, "wallet successfully created, file location is %s\n", wall.Path())
+	if genMnemonic {
+		fmt.Fprintln(ctx.App.Writer, "")
+		fmt.Fprintln(ctx.App.Writer, "Mnemonic phrase (write it down and keep it safe, it's the only way to restore your accounts):")
+		fmt.Fprintln(ctx.App.Writer, mnemonic)
+	}
 	return nil
 }
 
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func readAccountInfo() (string, string, error) {
 	name, err := readAccountName()
 	if err != nil {
@@ -945,6 +1306,32 @@ func createAccount(wall *wallet.Wallet, pass *string) error {
 	return wall.CreateAccount(name, phrase)
 }
 
+// createDerivedAccounts derives count accounts from the given mnemonic phrase
+// (using an empty BIP-39 passphrase) and adds them to the wallet. Only the
+// first account is named.
+func createDerivedAccounts(wall *wallet.Wallet, pass *string, mnemonic string, count int) error {
+	seed, err := wallet.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return err
+	}
+	var name, phrase string
+	if pass == nil {
+		name, phrase, err = readAccountInfo()
+		if err != nil {
+			return err
+		}
+	} else {
+		phrase = *pass
+	}
+	for i := 0; i < count; i++ {
+		if _, err := wall.CreateDerivedAccount(seed, name, phrase); err != nil {
+			return err
+		}
+		name = ""
+	}
+	return nil
+}
+
 func openWallet(ctx *cli.Context, canUseWalletConfig bool) (*wallet.Wallet, *string, error) {
 	path, pass, err := getWalletPathAndPass(ctx, canUseWalletConfig)
 	if err != nil {
@@ -1,11 +1,13 @@
 package wallet_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"math/big"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -543,6 +545,160 @@ func TestWalletInit(t *testing.T) {
 	})
 }
 
+func TestWalletInitHD(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	const phrase = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	seed, err := wallet.MnemonicToSeed(phrase, "")
+	require.NoError(t, err)
+	derived := func(t *testing.T, i int) string {
+		acc, err := wallet.NewAccountFromSeed(seed, wallet.DefaultDerivationPathPrefix+strconv.Itoa(i))
+		require.NoError(t, err)
+		return acc.Address
+	}
+
+	t.Run("conflicting flags", func(t *testing.T) {
+		walletPath := filepath.Join(t.TempDir(), "wallet.json")
+		e.RunWithError(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--mnemonic", "--recover")
+		e.RunWithError(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--mnemonic", "--account")
+	})
+	t.Run("mnemonic", func(t *testing.T) {
+		walletPath := filepath.Join(t.TempDir(), "wallet.json")
+		e.RunWithError(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--mnemonic", "--words", "13")
+
+		e.In.WriteString("acc\r")
+		e.In.WriteString("pass\r")
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--mnemonic", "--words", "12")
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(w.Accounts))
+		require.Equal(t, "acc", w.Accounts[0].Label)
+		require.Equal(t, wallet.DefaultDerivationPathPrefix+"0", w.Accounts[0].Extra.DerivationPath)
+
+		var generated string
+		for {
+			line, err := e.Out.ReadString('\n')
+			require.NoError(t, err)
+			if strings.HasPrefix(line, "Mnemonic phrase") {
+				generated, err = e.Out.ReadString('\n')
+				require.NoError(t, err)
+				break
+			}
+		}
+		words := strings.Fields(generated)
+		require.Equal(t, 12, len(words))
+		s, err := wallet.MnemonicToSeed(generated, "")
+		require.NoError(t, err)
+		acc, err := wallet.NewAccountFromSeed(s, wallet.DefaultDerivationPathPrefix+"0")
+		require.NoError(t, err)
+		require.Equal(t, acc.Address, w.Accounts[0].Address)
+	})
+	t.Run("recover", func(t *testing.T) {
+		walletPath := filepath.Join(t.TempDir(), "wallet.json")
+		t.Run("invalid phrase", func(t *testing.T) {
+			e.In.WriteString("abandon abandon abandon\r")
+			e.RunWithError(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--recover")
+		})
+		e.In.WriteString(phrase + "\r")
+		e.In.WriteString("acc\r")
+		e.In.WriteString("pass\r")
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--recover", "--count", "2")
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(w.Accounts))
+		require.Equal(t, "acc", w.Accounts[0].Label)
+		require.Equal(t, "", w.Accounts[1].Label)
+		for i := range w.Accounts {
+			require.Equal(t, derived(t, i), w.Accounts[i].Address)
+			require.NoError(t, w.Accounts[i].Decrypt("pass", w.Scrypt))
+		}
+
+		t.Run("create --derive", func(t *testing.T) {
+			t.Run("wrong phrase", func(t *testing.T) {
+				e.In.WriteString("zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong\r")
+				e.In.WriteString("acc\r")
+				e.In.WriteString("pass\r")
+				e.In.WriteString("pass\r")
+				e.RunWithError(t, "neo-go", "wallet", "create", "--wallet", walletPath, "--derive")
+			})
+			e.In.WriteString(phrase + "\r")
+			e.In.WriteString("acc3\r")
+			e.In.WriteString("pass\r")
+			e.In.WriteString("pass\r")
+			e.Run(t, "neo-go", "wallet", "create", "--wallet", walletPath, "--derive")
+			w, err := wallet.NewWalletFromFile(walletPath)
+			require.NoError(t, err)
+			require.Equal(t, 3, len(w.Accounts))
+			require.Equal(t, "acc3", w.Accounts[2].Label)
+			require.Equal(t, derived(t, 2), w.Accounts[2].Address)
+			require.Equal(t, wallet.DefaultDerivationPathPrefix+"2", w.Accounts[2].Extra.DerivationPath)
+		})
+	})
+}
+
+func TestWalletSignVerifyMessage(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	addr := testcli.ValidatorPriv.Address()
+
+	t.Run("missing message", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign-message", "--wallet", testcli.ValidatorWallet)
+	})
+	t.Run("invalid password", func(t *testing.T) {
+		e.In.WriteString("invalid_pass\r")
+		e.RunWithError(t, "neo-go", "wallet", "sign-message", "--wallet", testcli.ValidatorWallet,
+			"--address", addr, "Hello")
+	})
+
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, "neo-go", "wallet", "sign-message", "--wallet", testcli.ValidatorWallet,
+		"--address", addr, "Hello")
+	signed := bytes.Clone(e.Out.Bytes())
+	e.Out.Reset()
+	m := new(wallet.SignedMessage)
+	require.NoError(t, json.Unmarshal(signed, m))
+	require.Equal(t, "Hello", m.Message)
+	require.Equal(t, testcli.ValidatorPriv.PublicKey(), m.PublicKey)
+	require.NotEmpty(t, m.Salt)
+	require.True(t, m.Verify())
+
+	t.Run("no salt", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.Run(t, "neo-go", "wallet", "sign-message", "--wallet", testcli.ValidatorWallet,
+			"--address", addr, "--no-salt", "Hello")
+		m := new(wallet.SignedMessage)
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), m))
+		e.Out.Reset()
+		require.Empty(t, m.Salt)
+		require.True(t, m.Verify())
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "verify-message")
+		e.RunWithError(t, "neo-go", "wallet", "verify-message", "not a JSON")
+
+		e.Run(t, "neo-go", "wallet", "verify-message", string(signed))
+		e.CheckNextLine(t, "Signature is valid, signed by "+addr)
+		e.CheckEOF(t)
+
+		e.Run(t, "neo-go", "wallet", "verify-message", "--address", addr, string(signed))
+		e.CheckNextLine(t, "Signature is valid")
+		e.RunWithError(t, "neo-go", "wallet", "verify-message", "--address", testcli.TestWalletAccount, string(signed))
+
+		in := filepath.Join(t.TempDir(), "signed.json")
+		require.NoError(t, os.WriteFile(in, signed, 0644))
+		e.Run(t, "neo-go", "wallet", "verify-message", "--in", in)
+		e.CheckNextLine(t, "Signature is valid")
+		e.RunWithError(t, "neo-go", "wallet", "verify-message", "--in", in, string(signed))
+
+		bad := *m
+		bad.Message = "Hi"
+		data, err := json.Marshal(bad)
+		require.NoError(t, err)
+		e.RunWithError(t, "neo-go", "wallet", "verify-message", string(data))
+	})
+}
+
 func TestWalletExport(t *testing.T) {
 	e := testcli.NewExecutor(t, false)
 
@@ -1153,3 +1309,112 @@ func TestWalletConvert(t *testing.T) {
 func deployNNSContract(t *testing.T, e *testcli.Executor) util.Uint160 {
 	return testcli.DeployContract(t, e, "../../examples/nft-nd-nns/", "../../examples/nft-nd-nns/nns.yml", testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 }
+
+func TestWalletWatchOnly(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	walletPath := filepath.Join(t.TempDir(), "wallet.json")
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath)
+
+	pub := testcli.ValidatorPriv.PublicKey()
+	pubHex := hex.EncodeToString(pub.Bytes())
+	t.Run("import", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath)
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath, "not-an-address")
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath,
+			testcli.ValidatorAddr, pubHex)
+
+		e.Run(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath,
+			"--name", "validators", "--tag", "cold", "--tag", "team", testcli.ValidatorAddr)
+		e.Run(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath,
+			"--tag", "customer", pubHex)
+		e.RunWithError(t, "neo-go", "wallet", "import-watch", "--wallet", walletPath,
+			testcli.ValidatorAddr)
+
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(w.Accounts))
+		require.Equal(t, testcli.ValidatorAddr, w.Accounts[0].Address)
+		require.Nil(t, w.Accounts[0].Contract)
+		require.Equal(t, "validators", w.Accounts[0].Label)
+		require.Equal(t, []string{"cold", "team"}, w.Accounts[0].Extra.Tags)
+		require.Equal(t, pub.Address(), w.Accounts[1].Address)
+		require.Equal(t, pub.GetVerificationScript(), w.Accounts[1].Contract.Script)
+		require.True(t, w.Accounts[1].IsWatchOnly())
+	})
+
+	t.Run("list", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "list", "--wallet", walletPath)
+		e.CheckNextLine(t, `^Address\s+Label\s+Type\s+Watch-only\s+Tags$`)
+		e.CheckNextLine(t, `^`+testcli.ValidatorAddr+`\s+validators\s+none\s+true\s+cold,team$`)
+		e.CheckNextLine(t, `^`+pub.Address()+`\s+-\s+standard\s+true\s+customer$`)
+		e.CheckEOF(t)
+
+		e.Run(t, "neo-go", "wallet", "list", "--wallet", walletPath, "--tag", "customer")
+		e.CheckNextLine(t, `^Address`)
+		e.CheckNextLine(t, `^`+pub.Address())
+		e.CheckEOF(t)
+
+		e.RunWithError(t, "neo-go", "wallet", "list", "--wallet", walletPath, "--tag", "unknown")
+	})
+
+	t.Run("label", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "label", "--wallet", walletPath, "--name", "x")
+		e.RunWithError(t, "neo-go", "wallet", "label", "--wallet", walletPath, "-a", pub.Address())
+		e.RunWithError(t, "neo-go", "wallet", "label", "--wallet", walletPath,
+			"-a", util.Uint160{}.StringLE(), "--name", "x")
+
+		e.Run(t, "neo-go", "wallet", "label", "--wallet", walletPath, "-a", pub.Address(),
+			"--name", "alice", "--tag", "vip", "--untag", "customer")
+		e.Run(t, "neo-go", "wallet", "label", "--wallet", walletPath, "-a", testcli.ValidatorAddr,
+			"--untag", "cold", "--untag", "team")
+		e.Run(t, "neo-go", "wallet", "list", "--wallet", walletPath)
+		e.CheckNextLine(t, `^Address`)
+		e.CheckNextLine(t, `^`+testcli.ValidatorAddr+`\s+validators\s+none\s+true\s+-$`)
+		e.CheckNextLine(t, `^`+pub.Address()+`\s+alice\s+standard\s+true\s+vip$`)
+		e.CheckEOF(t)
+	})
+
+	t.Run("dump-keys", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "dump-keys", "--wallet", walletPath)
+		e.CheckNextLine(t, pub.Address()+" \\(simple signature contract\\):")
+		e.CheckNextLine(t, pubHex)
+		e.CheckEOF(t)
+		e.RunWithError(t, "neo-go", "wallet", "dump-keys", "--wallet", walletPath, "-a", testcli.ValidatorAddr)
+	})
+
+	t.Run("balance by tag", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "nep17", "balance",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", walletPath, "--tag", "vip", "--token", "NEO")
+		e.CheckNextLine(t, "^Account "+pub.Address()+"$")
+	})
+
+	t.Run("sign fails", func(t *testing.T) {
+		for _, from := range []string{testcli.ValidatorAddr, pub.Address()} {
+			e.RunWithError(t, "neo-go", "wallet", "nep17", "transfer",
+				"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+				"--wallet", walletPath, "--from", from,
+				"--to", testcli.ValidatorAddr, "--token", "GAS", "--amount", "1", "--force")
+
+			e.RunWithError(t, "neo-go", "contract", "deploy",
+				"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+				"--wallet", walletPath, "--address", from,
+				"--in", "../smartcontract/testdata/verify.nef",
+				"--manifest", "../smartcontract/testdata/verify.manifest.json", "--force")
+		}
+	})
+
+	t.Run("unsigned transaction", func(t *testing.T) {
+		txPath := filepath.Join(t.TempDir(), "tx.json")
+		args := []string{"neo-go", "wallet", "nep17", "transfer",
+			"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+			"--wallet", walletPath, "--to", testcli.ValidatorAddr,
+			"--token", "GAS", "--amount", "1", "--force", "--out", txPath}
+		// No verification script to create a transaction with.
+		e.RunWithError(t, append(args, "--from", testcli.ValidatorAddr)...)
+		// But it can be created for an account with a public key.
+		e.Run(t, append(args, "--from", pub.Address())...)
+		_, err := os.Stat(txPath)
+		require.NoError(t, err)
+	})
+}
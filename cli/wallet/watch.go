@@ -0,0 +1,179 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+var tagFlag = cli.StringSliceFlag{
+	Name:  "tag",
+	Usage: "Only use accounts with the given tag (can be repeated to match any of several tags)",
+}
+
+// filterAccountsByTags returns accounts having any of the given tags (all
+// accounts if no tags are given).
+func filterAccountsByTags(accounts []*wallet.Account, tags []string) []*wallet.Account {
+	if len(tags) == 0 {
+		return accounts
+	}
+	var res []*wallet.Account
+	for _, acc := range accounts {
+		for _, tag := range tags {
+			if acc.HasTag(tag) {
+				res = append(res, acc)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// getTaggedAccounts returns wallet accounts filtered by the tags given in the
+// "tag" flag or an error if there are no such accounts.
+func getTaggedAccounts(ctx *cli.Context, wall *wallet.Wallet) ([]*wallet.Account, error) {
+	if len(wall.Accounts) == 0 {
+		return nil, errors.New("no accounts in the wallet")
+	}
+	tags := ctx.StringSlice("tag")
+	accounts := filterAccountsByTags(wall.Accounts, tags)
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no accounts with %s tag(s) in the wallet", strings.Join(tags, ", "))
+	}
+	return accounts, nil
+}
+
+func importWatchOnly(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.NewExitError(errors.New("exactly one address or public key is expected"), 1)
+	}
+	wall, _, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	var (
+		acc *wallet.Account
+		arg = ctx.Args().First()
+	)
+	if pub, err := keys.NewPublicKeyFromString(arg); err == nil {
+		acc = wallet.NewWatchOnlyAccountFromPublicKey(pub)
+	} else {
+		h, err := flags.ParseAddress(arg)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("%s is neither a public key nor an address", arg), 1)
+		}
+		acc = wallet.NewWatchOnlyAccount(h)
+	}
+	acc.Label = ctx.String("name")
+	for _, tag := range ctx.StringSlice("tag") {
+		acc.AddTag(tag)
+	}
+	if err := addAccountAndSave(wall, acc); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
+func setAccountLabel(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if !addrFlag.IsSet {
+		return cli.NewExitError("address was not provided", 1)
+	}
+	if !ctx.IsSet("name") && len(ctx.StringSlice("tag")) == 0 && len(ctx.StringSlice("untag")) == 0 {
+		return cli.NewExitError("nothing to change", 1)
+	}
+	wall, _, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	acc := wall.GetAccount(addrFlag.Uint160())
+	if acc == nil {
+		return cli.NewExitError("account is missing", 1)
+	}
+	if ctx.IsSet("name") {
+		acc.Label = ctx.String("name")
+	}
+	for _, tag := range ctx.StringSlice("untag") {
+		acc.RemoveTag(tag)
+	}
+	for _, tag := range ctx.StringSlice("tag") {
+		acc.AddTag(tag)
+	}
+	if err := wall.Save(); err != nil {
+		return cli.NewExitError(fmt.Errorf("error while saving wallet: %w", err), 1)
+	}
+	return nil
+}
+
+func listAccounts(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	accounts, err := getTaggedAccounts(ctx, wall)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	tw := tabwriter.NewWriter(ctx.App.Writer, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "Address\tLabel\tType\tWatch-only\tTags")
+	for _, acc := range accounts {
+		var tags []string
+		if acc.Extra != nil {
+			tags = acc.Extra.Tags
+		}
+		row := []string{
+			acc.Address,
+			acc.Label,
+			accountType(acc),
+			strconv.FormatBool(acc.IsWatchOnly()),
+			strings.Join(tags, ","),
+		}
+		for i := range row {
+			if row[i] == "" {
+				row[i] = "-"
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
+// accountType returns a short description of the account verification script.
+func accountType(acc *wallet.Account) string {
+	switch {
+	case acc.Contract == nil:
+		return "none"
+	case acc.Contract.Deployed:
+		return "contract"
+	case vm.IsSignatureContract(acc.Contract.Script):
+		return "standard"
+	}
+	if m, pubs, ok := vm.ParseMultiSigContract(acc.Contract.Script); ok {
+		return fmt.Sprintf("multisig %d/%d", m, len(pubs))
+	}
+	return "unknown"
+}
@@ -33,6 +33,7 @@ require (
 	golang.org/x/text v0.14.0
 	golang.org/x/tools v0.19.0
 	gopkg.in/yaml.v3 v3.0.1
+	rsc.io/qr v0.2.0
 )
 
 require (
@@ -19,8 +19,8 @@ var (
 	ErrMissingExportedParamName = errors.New("exported method is not allowed to have unnamed parameter")
 	// ErrInvalidExportedRetCount is returned when exported contract method has invalid return values count.
 	ErrInvalidExportedRetCount = errors.New("exported method is not allowed to have more than one return value")
-	// ErrGenericsUnsuppored is returned when generics-related tokens are encountered.
-	ErrGenericsUnsuppored = errors.New("generics are currently unsupported, please, see the https://github.com/nspcc-dev/neo-go/issues/2376")
+	// ErrGenericsUnsuppored is returned when generic code can't be instantiated.
+	ErrGenericsUnsuppored = errors.New("unsupported generics usage")
 )
 
 var (
@@ -358,7 +358,7 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 				// functions invoked in variable declarations in imported packages
 				// are marked as used.
 				var name string
-				switch t := n.Fun.(type) {
+				switch t := c.unwrapInstance(n.Fun).(type) {
 				case *ast.Ident:
 					name = c.getIdentName(pkgPath, t.Name)
 				case *ast.SelectorExpr:
@@ -369,20 +369,15 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 				diff[name] = true
 			case *ast.FuncDecl:
 				name := c.getFuncNameFromDecl(pkgPath, n)
-
-				// filter out generic functions
-				err := c.checkGenericsFuncDecl(n, name)
-				if err != nil {
-					c.prog.Err = err
-					return false // Program is invalid.
-				}
+				// generic functions are never exported, only their instances are used
+				isExported := isMain && n.Name.IsExported() && !isGenericFunc(n)
 
 				// exported functions and methods are always assumed to be used
-				if isMain && n.Name.IsExported() || isInitFunc(n) || isDeployFunc(n) {
+				if isExported || isInitFunc(n) || isDeployFunc(n) {
 					diff[name] = true
 				}
 				// exported functions are not allowed to have unnamed parameters  or multiple return values
-				if isMain && n.Name.IsExported() && n.Recv == nil {
+				if isExported && n.Recv == nil {
 					if n.Type.Params.List != nil {
 						for i, param := range n.Type.Params.List {
 							if param.Names == nil {
@@ -404,13 +399,6 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 				nodeCache[name] = declPair{n, c.importMap, pkgPath}
 				return false // will be processed in the next stage
 			case *ast.GenDecl:
-				// Filter out generics usage.
-				err := c.checkGenericsGenDecl(n, pkgPath)
-				if err != nil {
-					c.prog.Err = err
-					return false // Program is invalid.
-				}
-
 				// After skipping all funcDecls, we are sure that each value spec
 				// is a globally declared variable or constant. We need to gather global
 				// vars from both main and imported packages.
@@ -491,7 +479,7 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 			ast.Inspect(fd.decl, func(node ast.Node) bool {
 				switch n := node.(type) {
 				case *ast.CallExpr:
-					switch t := n.Fun.(type) {
+					switch t := c.unwrapInstance(n.Fun).(type) {
 					case *ast.Ident:
 						nextDiff[c.getIdentName(fd.path, t.Name)] = true
 					case *ast.SelectorExpr:
@@ -558,54 +546,6 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 	return usage
 }
 
-// checkGenericFuncDecl checks whether provided ast.FuncDecl has generic code.
-func (c *codegen) checkGenericsFuncDecl(n *ast.FuncDecl, funcName string) error {
-	var errGenerics error
-
-	// Generic function receiver.
-	if n.Recv != nil {
-		switch t := n.Recv.List[0].Type.(type) {
-		case *ast.StarExpr:
-			switch t.X.(type) {
-			case *ast.IndexExpr:
-				// func (x *Pointer[T]) Load() *T
-				errGenerics = errors.New("generic pointer function receiver")
-			}
-		case *ast.IndexExpr:
-			// func (x Structure[T]) Load() *T
-			errGenerics = errors.New("generic function receiver")
-		}
-	}
-
-	// Generic function parameters type: func SumInts[V int64 | int32](vals []V) V
-	if n.Type.TypeParams != nil {
-		errGenerics = errors.New("function type parameters")
-	}
-
-	if errGenerics != nil {
-		return fmt.Errorf("%w: %s has %s", ErrGenericsUnsuppored, funcName, errGenerics.Error())
-	}
-
-	return nil
-}
-
-// checkGenericsGenDecl checks whether provided ast.GenDecl has generic code.
-func (c *codegen) checkGenericsGenDecl(n *ast.GenDecl, pkgPath string) error {
-	// Generic type declaration:
-	// 	type List[T any] struct
-	// 	type List[T any] interface
-	if n.Tok == token.TYPE {
-		for _, s := range n.Specs {
-			typeSpec := s.(*ast.TypeSpec)
-			if typeSpec.TypeParams != nil {
-				return fmt.Errorf("%w: type %s is generic", ErrGenericsUnsuppored, c.getIdentName(pkgPath, typeSpec.Name.Name))
-			}
-		}
-	}
-
-	return nil
-}
-
 // nodeContext contains ast node with the corresponding import map, type info and package information
 // required to retrieve fully qualified node name (if so).
 type nodeContext struct {
@@ -750,6 +690,13 @@ func canConvert(s string) bool {
 	return true
 }
 
+// isTokenLibPath returns true if the package is one of the token libraries.
+// These contain complete method implementations calling hooks via type
+// parameters, so they're compiled as regular functions.
+func isTokenLibPath(s string) bool {
+	return s == interopPrefix+"/lib/nep11" || s == interopPrefix+"/lib/nep17"
+}
+
 // canInline returns true if the function is to be inlined.
 // The list of functions that can be inlined is not static, it depends on the function usages.
 // isBuiltin denotes whether code generation for dynamic builtin function will be performed
@@ -762,6 +709,7 @@ func canInline(s string, name string, isBuiltin bool) bool {
 		return false
 	}
 	return !strings.HasPrefix(s[len(interopPrefix):], "/neogointernal") &&
+		!isTokenLibPath(s) &&
 		!(strings.HasPrefix(s[len(interopPrefix):], "/util") && name == "FromAddress") &&
 		!(strings.HasPrefix(s[len(interopPrefix):], "/lib/address") && name == "ToHash160" && isBuiltin)
 }
@@ -0,0 +1,165 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"golang.org/x/tools/go/packages"
+)
+
+// Function literals referencing variables of enclosing functions are
+// closures. Every captured variable is stored in a box (single-element array)
+// by the function declaring it, so that both this function and all closures
+// refer to the same value. A closure value is an array of the function pointer
+// and the environment (array of captured boxes), the environment is passed to
+// the closure as an additional first argument. Function values that don't
+// capture anything are plain pointers as before.
+
+// analyzeClosures finds all function literals capturing variables and fills
+// the set of captured variables.
+func (c *codegen) analyzeClosures() {
+	c.ForEachPackage(func(pkg *packages.Package) {
+		for _, f := range pkg.Syntax {
+			ast.Inspect(f, func(n ast.Node) bool {
+				lit, ok := n.(*ast.FuncLit)
+				if !ok {
+					return true
+				}
+				vars := freeVars(lit, pkg.TypesInfo)
+				if len(vars) != 0 {
+					c.closures[lit] = vars
+					for _, v := range vars {
+						c.captured[v] = true
+					}
+				}
+				return true
+			})
+		}
+	})
+}
+
+// freeVars returns local variables of enclosing functions used in lit (including
+// nested literals) in the order of their first usage.
+func freeVars(lit *ast.FuncLit, info *types.Info) []types.Object {
+	var (
+		res  []types.Object
+		seen = make(map[types.Object]bool)
+	)
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := info.Uses[id].(*types.Var)
+		if !ok || v.IsField() || seen[v] || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() ||
+			lit.Pos() <= v.Pos() && v.Pos() < lit.End() {
+			return true
+		}
+		seen[v] = true
+		res = append(res, v)
+		return true
+	})
+	return res
+}
+
+// isCaptured checks whether the variable referenced by id is captured by
+// some closure.
+func (c *codegen) isCaptured(id *ast.Ident) bool {
+	obj := c.typeInfo.ObjectOf(id)
+	return obj != nil && c.captured[obj]
+}
+
+// newLocalVar creates a new local variable for id, it's boxed if captured.
+func (c *codegen) newLocalVar(id *ast.Ident) {
+	if c.isCaptured(id) {
+		c.scope.vars.newBoxedLocal(id.Name)
+	} else {
+		c.scope.newLocal(id.Name)
+	}
+}
+
+// emitInitVar stores the value from the top of the stack into the newly
+// declared local variable. Boxed variables get a new box, so every execution
+// of the declaration creates a new variable for closures.
+func (c *codegen) emitInitVar(name string) {
+	vi := c.getVarIndex("", name)
+	if vi.boxed {
+		emit.Opcodes(c.prog.BinWriter, opcode.PUSH1, opcode.PACK)
+	}
+	c.emitStoreByIndex(vi.refType, vi.index)
+}
+
+// boxArguments moves arguments captured by closures into boxes, they're
+// replaced with local variables of the same name then. Named results captured
+// are also initialized here.
+func (c *codegen) boxArguments(decl *ast.FuncDecl) {
+	var fields []*ast.Field
+	if decl.Recv != nil {
+		fields = append(fields, decl.Recv.List...)
+	}
+	fields = append(fields, decl.Type.Params.List...)
+	for _, arg := range fields {
+		for _, id := range arg.Names {
+			if c.isCaptured(id) {
+				c.emitLoadVar("", id.Name)
+				c.scope.vars.newBoxedLocal(id.Name)
+				c.emitInitVar(id.Name)
+			}
+		}
+	}
+	if decl.Type.Results == nil {
+		return
+	}
+	for _, res := range decl.Type.Results.List {
+		for _, id := range res.Names {
+			if c.isCaptured(id) {
+				c.emitDefault(c.typeOf(res.Type))
+				c.scope.vars.newBoxedLocal(id.Name)
+				c.emitInitVar(id.Name)
+			}
+		}
+	}
+}
+
+// unpackEnvironment loads boxes of captured variables from the environment
+// into local variables.
+func (c *codegen) unpackEnvironment(f *funcScope) {
+	for i, v := range f.closure {
+		c.emitLoadByIndex(varArgument, 0)
+		emit.Int(c.prog.BinWriter, int64(i))
+		emit.Opcodes(c.prog.BinWriter, opcode.PICKITEM)
+		c.emitStoreByIndex(varLocal, c.scope.vars.newBoxedLocal(v.Name()))
+	}
+}
+
+// emitEnvironment creates an environment for the closure capturing vars.
+func (c *codegen) emitEnvironment(vars []types.Object) {
+	for i := len(vars) - 1; i >= 0; i-- {
+		vi := c.scope.vars.getVarInfo(vars[i].Name())
+		if vi == nil || !vi.boxed {
+			c.prog.Err = fmt.Errorf("%s can't be captured by a closure", vars[i].Name())
+			return
+		}
+		c.emitLoadByIndex(vi.refType, vi.index)
+	}
+	emit.Int(c.prog.BinWriter, int64(len(vars)))
+	emit.Opcodes(c.prog.BinWriter, opcode.PACK)
+}
+
+// emitCallFuncValue calls the function value from the top of the stack which
+// is either a pointer or (if there are closures in the program) a closure.
+func (c *codegen) emitCallFuncValue() {
+	if len(c.closures) != 0 {
+		call := c.newLabel()
+		emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+		emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(stackitem.PointerT)})
+		emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, call)
+		emit.Opcodes(c.prog.BinWriter, opcode.UNPACK, opcode.DROP)
+		c.setLabel(call)
+	}
+	emit.Opcodes(c.prog.BinWriter, opcode.CALLA)
+}
@@ -0,0 +1,214 @@
+package compiler_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+func TestClosure(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			"read captured local",
+			`package foo
+			func Main() int {
+				a := 5
+				f := func(x int) int { return x + a }
+				return f(1) + f(2)
+			}`,
+			big.NewInt(13),
+		},
+		{
+			"modify captured local",
+			`package foo
+			func Main() int {
+				a := 1
+				inc := func() { a++ }
+				inc()
+				inc()
+				return a
+			}`,
+			big.NewInt(3),
+		},
+		{
+			"outer modification is visible",
+			`package foo
+			func Main() int {
+				a := 1
+				get := func() int { return a }
+				a = 10
+				return get()
+			}`,
+			big.NewInt(10),
+		},
+		{
+			"captured argument",
+			`package foo
+			func Main() int {
+				return add(3)(4)
+			}
+			func add(a int) func(int) int {
+				return func(b int) int { return a + b }
+			}`,
+			big.NewInt(7),
+		},
+		{
+			"counter generator",
+			`package foo
+			func counter() func() int {
+				var n int
+				return func() int {
+					n += 1
+					return n
+				}
+			}
+			func Main() int {
+				c1, c2 := counter(), counter()
+				c1()
+				c1()
+				return c1()*10 + c2()
+			}`,
+			big.NewInt(31),
+		},
+		{
+			"nested closures",
+			`package foo
+			func Main() int {
+				a := 1
+				f := func() func() int {
+					b := 10
+					return func() int {
+						a++
+						return a + b
+					}
+				}
+				g := f()
+				return g() + g() + a
+			}`,
+			big.NewInt(28),
+		},
+		{
+			"call in place",
+			`package foo
+			func Main() int {
+				a := 1
+				func() {
+					a += 10
+				}()
+				return a
+			}`,
+			big.NewInt(11),
+		},
+		{
+			"closure as argument",
+			`package foo
+			func forEach(vals []int, f func(int)) {
+				for _, v := range vals {
+					f(v)
+				}
+			}
+			func Main() int {
+				var sum int
+				forEach([]int{1, 2, 3}, func(v int) { sum += v })
+				plain := func(v int) {}
+				forEach([]int{4}, plain)
+				return sum
+			}`,
+			big.NewInt(6),
+		},
+		{
+			"captured struct",
+			`package foo
+			type pair struct { a, b int }
+			func Main() int {
+				p := pair{a: 1}
+				set := func(v int) { p.b = v }
+				set(5)
+				return p.a + p.b
+			}`,
+			big.NewInt(6),
+		},
+		{
+			"named result",
+			`package foo
+			func Main() (res int) {
+				defer func() { res *= 2 }()
+				res = 21
+				return
+			}`,
+			big.NewInt(42),
+		},
+		{
+			"redeclaration keeps the variable",
+			`package foo
+			func two() (int, int) { return 2, 3 }
+			func Main() int {
+				a := 1
+				get := func() int { return a }
+				a, b := two()
+				return get() * b
+			}`,
+			big.NewInt(6),
+		},
+	})
+}
+
+func TestClosureLoopVariables(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			"range",
+			`package foo
+			func Main() []int {
+				var fs []func() int
+				for _, v := range []int{1, 2, 3} {
+					fs = append(fs, func() int { return v })
+				}
+				var res []int
+				for i := range fs {
+					res = append(res, fs[i]())
+				}
+				return res
+			}`,
+			[]stackitem.Item{
+				stackitem.Make(1),
+				stackitem.Make(2),
+				stackitem.Make(3),
+			},
+		},
+		{
+			"for",
+			`package foo
+			func Main() []int {
+				var fs []func() int
+				for i := 0; i < 3; i++ {
+					fs = append(fs, func() int { return i })
+				}
+				var res []int
+				for j := range fs {
+					res = append(res, fs[j]())
+				}
+				return res
+			}`,
+			[]stackitem.Item{
+				stackitem.Make(0),
+				stackitem.Make(1),
+				stackitem.Make(2),
+			},
+		},
+		{
+			"loop variable modified by closure",
+			`package foo
+			func Main() int {
+				var n int
+				for i := 0; i < 10; i++ {
+					skip := func() { i += 2 }
+					skip()
+					n++
+				}
+				return n
+			}`,
+			big.NewInt(4),
+		},
+	})
+}
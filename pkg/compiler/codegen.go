@@ -44,6 +44,10 @@ type codegen struct {
 
 	// A mapping of lambda functions into their scope.
 	lambda map[string]*funcScope
+	// closures contains variables captured by function literals.
+	closures map[*ast.FuncLit][]types.Object
+	// captured is a set of variables captured by closures.
+	captured map[types.Object]bool
 
 	// reverseOffsetMap maps function offsets to a local variable count.
 	reverseOffsetMap map[int]nameWithLocals
@@ -85,6 +89,8 @@ type codegen struct {
 	initEndOffset int
 	// deployEndOffset specifies the end of the deployment method.
 	deployEndOffset int
+	// optimized is set if the code was processed by the optimizer.
+	optimized bool
 
 	// importMap contains mapping from package aliases to full package names for the current file.
 	importMap map[string]string
@@ -115,12 +121,21 @@ type codegen struct {
 
 	// invokedContracts contains invoked methods of other contracts.
 	invokedContracts map[util.Uint160][]string
+	// unresolvedCalls contains contract calls with the contract hash or
+	// method unknown at compile time.
+	unresolvedCalls []UnresolvedCall
 
 	// Label table for recording jump destinations.
 	l []int
 
 	// Tokens for CALLT instruction
 	callTokens []nef.MethodToken
+
+	// funcUsage contains functions to be converted.
+	funcUsage funcUsage
+	// pendingFuncs contains generic function instances (and functions used by
+	// them only) that are to be converted after all the other functions.
+	pendingFuncs []*funcScope
 }
 
 type labelOffsetType byte
@@ -163,7 +178,7 @@ const (
 )
 
 // ErrUnsupportedTypeAssertion is returned when type assertion statement is not supported by the compiler.
-var ErrUnsupportedTypeAssertion = errors.New("type assertion with two return values is not supported")
+var ErrUnsupportedTypeAssertion = errors.New("unsupported type assertion")
 
 // newLabel creates a new label to jump to.
 func (c *codegen) newLabel() (l uint16) {
@@ -296,6 +311,9 @@ func (c *codegen) emitLoadVar(pkg string, name string) {
 		return
 	}
 	c.emitLoadByIndex(vi.refType, vi.index)
+	if vi.boxed {
+		emit.Opcodes(c.prog.BinWriter, opcode.PUSH0, opcode.PICKITEM)
+	}
 }
 
 // emitLoadByIndex loads the specified variable type with index i.
@@ -315,6 +333,11 @@ func (c *codegen) emitStoreVar(pkg string, name string) {
 		return
 	}
 	vi := c.getVarIndex(pkg, name)
+	if vi.boxed {
+		c.emitLoadByIndex(vi.refType, vi.index)
+		emit.Opcodes(c.prog.BinWriter, opcode.SWAP, opcode.PUSH0, opcode.SWAP, opcode.SETITEM)
+		return
+	}
 	c.emitStoreByIndex(vi.refType, vi.index)
 }
 
@@ -468,14 +491,26 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 			if isSyscall(f) {
 				return f
 			}
-			c.setLabel(f.label)
 		} else if f, ok = c.lambda[c.getIdentName("", decl.Name.Name)]; ok {
 			isLambda = ok
-			c.setLabel(f.label)
 		} else {
 			f = c.newFunc(decl)
 		}
 	}
+	c.convertFuncScope(file, f, pkg, isLambda)
+	return f
+}
+
+// convertFuncScope converts the function described by f.
+func (c *codegen) convertFuncScope(file ast.Node, f *funcScope, pkg *types.Package, isLambda bool) {
+	var (
+		decl     = f.decl
+		isInit   = isInitFunc(decl)
+		isDeploy = isDeployFunc(decl)
+	)
+	if !isInit && !isDeploy {
+		c.setLabel(f.label)
+	}
 
 	f.rng.Start = uint16(c.prog.Len())
 	c.scope = f
@@ -514,6 +549,11 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 		}
 	}
 
+	// Closure environment is passed as the first argument.
+	if f.closure != nil {
+		c.scope.newVariable(varArgument, "%env")
+	}
+
 	// Load the arguments in scope.
 	for _, arg := range decl.Type.Params.List {
 		for _, id := range arg.Names {
@@ -521,6 +561,8 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 			c.scope.newVariable(varArgument, id.Name)
 		}
 	}
+	c.unpackEnvironment(f)
+	c.boxArguments(decl)
 
 	ast.Walk(c, decl.Body)
 
@@ -540,15 +582,27 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 	}
 
 	f.rng.End = uint16(c.prog.Len() - 1)
+	f.converted = true
 
 	if !isLambda {
-		for _, f := range c.lambda {
-			if _, ok := c.lambda[c.getIdentName("", f.decl.Name.Name)]; !ok {
+		// Converting lambdas can add nested ones, so convert them one by one
+		// (in the order of appearance) until there are none left.
+		for len(c.lambda) != 0 {
+			var (
+				name string
+				next *funcScope
+			)
+			for n, f := range c.lambda {
+				if next == nil || f.label < next.label {
+					name, next = n, f
+				}
+			}
+			if _, ok := c.lambda[c.getIdentName("", next.decl.Name.Name)]; !ok {
 				panic("ICE: lambda name doesn't match map key")
 			}
-			c.convertFuncDecl(file, f.decl, pkg)
+			c.convertFuncDecl(file, next.decl, pkg)
+			delete(c.lambda, name)
 		}
-		c.lambda = make(map[string]*funcScope)
 	}
 
 	if !isInit && !isDeploy {
@@ -557,7 +611,6 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 			count: f.vars.localsCnt,
 		}
 	}
-	return f
 }
 
 func (c *codegen) Visit(node ast.Node) ast.Visitor {
@@ -570,13 +623,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	//     x = 2
 	// )
 	case *ast.GenDecl:
-		// Filter out generics usage.
-		err := c.checkGenericsGenDecl(n, c.currPkg.PkgPath)
-		if err != nil {
-			c.prog.Err = err
-			return nil // Program is invalid.
-		}
-
 		if n.Tok == token.VAR || n.Tok == token.CONST {
 			c.saveSequencePoint(n)
 		}
@@ -598,14 +644,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		for _, spec := range n.Specs {
 			switch t := spec.(type) {
 			case *ast.ValueSpec:
-				// Filter out type assertion with two return values: var i, ok = v.(int)
-				if len(t.Names) == 2 && len(t.Values) == 1 && n.Tok == token.VAR {
-					err := checkTypeAssertWithOK(t.Values[0])
-					if err != nil {
-						c.prog.Err = err
-						return nil
-					}
-				}
 				multiRet := n.Tok == token.VAR && len(t.Values) != 0 && len(t.Names) != len(t.Values)
 				for _, id := range t.Names {
 					if id.Name != "_" {
@@ -613,7 +651,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 							// it is a global declaration
 							c.newGlobal("", id.Name)
 						} else {
-							c.scope.newLocal(id.Name)
+							c.newLocalVar(id)
 						}
 						if !multiRet {
 							c.registerDebugVariable(id.Name, t.Type)
@@ -623,13 +661,19 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 				for i, id := range t.Names {
 					if id.Name != "_" {
 						if len(t.Values) != 0 {
-							if i == 0 || !multiRet {
+							if multiRet && i == 0 {
+								c.walkMultiValue(t.Values[0])
+							} else if !multiRet {
 								ast.Walk(c, t.Values[i])
 							}
 						} else {
 							c.emitDefault(c.typeOf(t.Type))
 						}
-						c.emitStoreVar("", t.Names[i].Name)
+						if c.scope == nil {
+							c.emitStoreVar("", t.Names[i].Name)
+						} else {
+							c.emitInitVar(t.Names[i].Name)
+						}
 						continue
 					}
 					// If var decl contains call then the code should be emitted for it, otherwise - do not evaluate.
@@ -637,10 +681,12 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 						continue
 					}
 					var hasCall bool
-					if i == 0 || !multiRet {
-						hasCall = containsCall(t.Values[i])
-					}
-					if hasCall {
+					if multiRet && i == 0 {
+						// Multiple values are returned by a call or type assertion.
+						hasCall = true
+						c.walkMultiValue(t.Values[0])
+					} else if !multiRet && containsCall(t.Values[i]) {
+						hasCall = true
 						ast.Walk(c, t.Values[i])
 					}
 					if hasCall || i != 0 && multiRet {
@@ -652,14 +698,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		return nil
 
 	case *ast.AssignStmt:
-		// Filter out type assertion with two return values: i, ok = v.(int)
-		if len(n.Lhs) == 2 && len(n.Rhs) == 1 && (n.Tok == token.DEFINE || n.Tok == token.ASSIGN) {
-			err := checkTypeAssertWithOK(n.Rhs[0])
-			if err != nil {
-				c.prog.Err = err
-				return nil
-			}
-		}
 		multiRet := len(n.Rhs) != len(n.Lhs)
 		c.saveSequencePoint(n)
 		// Assign operations are grouped https://github.com/golang/go/blob/master/src/go/types/stmt.go#L160
@@ -673,22 +711,30 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		for i := 0; i < len(n.Lhs); i++ {
 			switch t := n.Lhs[i].(type) {
 			case *ast.Ident:
+				var isNew bool
 				if n.Tok == token.DEFINE {
 					if !multiRet {
 						c.registerDebugVariable(t.Name, n.Rhs[i])
 					}
-					if t.Name != "_" {
-						c.scope.newLocal(t.Name)
+					// Captured variables redeclared in multi-value
+					// assignments must keep their boxes.
+					if t.Name != "_" && (c.typeInfo.Defs[t] != nil || !c.isCaptured(t)) {
+						c.newLocalVar(t)
+						isNew = true
 					}
 				}
-				if !isAssignOp && (i == 0 || !multiRet) {
-					ast.Walk(c, n.Rhs[i])
+				if !isAssignOp {
+					c.walkAssignValue(n, i, multiRet)
+				}
+				if isNew {
+					c.emitInitVar(t.Name)
+				} else {
+					c.emitStoreVar("", t.Name)
 				}
-				c.emitStoreVar("", t.Name)
 
 			case *ast.SelectorExpr:
 				if !isAssignOp {
-					ast.Walk(c, n.Rhs[i])
+					c.walkAssignValue(n, i, multiRet)
 				}
 				typ := c.typeOf(t.X)
 				if c.isInvalidType(typ) {
@@ -709,7 +755,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			// slice[0] = 10
 			case *ast.IndexExpr:
 				if !isAssignOp {
-					ast.Walk(c, n.Rhs[i])
+					c.walkAssignValue(n, i, multiRet)
 				}
 				ast.Walk(c, t.X)
 				ast.Walk(c, t.Index)
@@ -757,6 +803,8 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		c.dropItems(cnt)
 
 		if len(n.Results) == 0 {
+			// Deferred closures can change named results.
+			c.processDefers()
 			results := c.scope.decl.Type.Results
 			if results.NumFields() != 0 {
 				// function with named returns
@@ -772,10 +820,9 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			for i := len(n.Results) - 1; i >= 0; i-- {
 				ast.Walk(c, n.Results[i])
 			}
+			c.processDefers()
 		}
 
-		c.processDefers()
-
 		c.saveSequencePoint(n)
 		if len(c.pkgInfoInline) == 0 {
 			emit.Opcodes(c.prog.BinWriter, opcode.RET)
@@ -872,6 +919,85 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 
 		return nil
 
+	case *ast.TypeSwitchStmt:
+		if n.Init != nil {
+			ast.Walk(c, n.Init)
+		}
+		var name string
+		switch a := n.Assign.(type) {
+		case *ast.AssignStmt: // switch v := x.(type)
+			name = a.Lhs[0].(*ast.Ident).Name
+			ast.Walk(c, a.Rhs[0].(*ast.TypeAssertExpr).X)
+		case *ast.ExprStmt: // switch x.(type)
+			ast.Walk(c, a.X.(*ast.TypeAssertExpr).X)
+		}
+		switchEnd, label := c.generateLabel(labelEnd)
+
+		lastSwitch := c.currentSwitch
+		c.currentSwitch = label
+		c.pushStackLabel(label, 1)
+
+		// Default clause is checked last irrespective of its position.
+		clauses := make([]*ast.CaseClause, 0, len(n.Body.List))
+		var def *ast.CaseClause
+		for _, stmt := range n.Body.List {
+			if cc := stmt.(*ast.CaseClause); cc.List != nil {
+				clauses = append(clauses, cc)
+			} else {
+				def = cc
+			}
+		}
+		if def != nil {
+			clauses = append(clauses, def)
+		}
+		for _, cc := range clauses {
+			lStart := c.newLabel()
+			lEnd := c.newLabel()
+			for j := range cc.List {
+				emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+				if err := c.emitTypeCheck(c.typeOf(cc.List[j])); err != nil {
+					c.prog.Err = err
+					return nil
+				}
+				if j == len(cc.List)-1 {
+					emit.Jmp(c.prog.BinWriter, opcode.JMPIFNOTL, lEnd)
+				} else {
+					emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, lStart)
+				}
+			}
+
+			c.scope.vars.newScope()
+
+			c.setLabel(lStart)
+			if name != "" && name != "_" {
+				emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+				// Variable has the case type only for single-type clauses.
+				if len(cc.List) == 1 {
+					c.emitAssertConvert(c.typeOf(cc.List[0]))
+				}
+				if obj := c.typeInfo.Implicits[cc]; obj != nil && c.captured[obj] {
+					c.scope.vars.newBoxedLocal(name)
+				} else {
+					c.scope.newLocal(name)
+				}
+				c.emitInitVar(name)
+			}
+			for _, stmt := range cc.Body {
+				ast.Walk(c, stmt)
+			}
+			emit.Jmp(c.prog.BinWriter, opcode.JMPL, switchEnd)
+			c.setLabel(lEnd)
+
+			c.scope.vars.dropScope()
+		}
+
+		c.setLabel(switchEnd)
+		c.dropStackLabel()
+
+		c.currentSwitch = lastSwitch
+
+		return nil
+
 	case *ast.FuncLit:
 		var found bool
 		var l uint16
@@ -887,9 +1013,17 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			c.newLambda(l, n)
 		}
 
+		vars := c.closures[n]
+		if len(vars) != 0 {
+			c.emitEnvironment(vars)
+		}
 		buf := make([]byte, 4)
 		binary.LittleEndian.PutUint16(buf, l)
 		emit.Instruction(c.prog.BinWriter, opcode.PUSHA, buf)
+		if len(vars) != 0 {
+			// Closure is a pair of the pointer and the environment.
+			emit.Opcodes(c.prog.BinWriter, opcode.PUSH2, opcode.PACK)
+		}
 		return nil
 
 	case *ast.BasicLit:
@@ -968,9 +1102,14 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			isLiteral bool
 		)
 
-		switch fun := n.Fun.(type) {
+		switch fun := c.unwrapInstance(n.Fun).(type) {
 		case *ast.Ident:
 			f, ok = c.getFuncFromIdent(fun)
+			if ok && isGenericFunc(f.decl) {
+				if f = c.instantiate(f, fun); f == nil {
+					return nil
+				}
+			}
 			isBuiltin = isGoBuiltin(fun.Name)
 			if !ok && !isBuiltin {
 				name = fun.Name
@@ -988,6 +1127,16 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 
 			f, ok = c.funcs[name]
 			if ok {
+				if isGenericFunc(f.decl) {
+					if f = c.instantiate(f, fun); f == nil {
+						return nil
+					}
+				} else if c.scope != nil && c.scope.typeArgs != nil && !c.funcUsage.funcUsed(name) {
+					// Method called via type parameter, it's not known
+					// to be used before instantiation.
+					c.funcUsage[name] = true
+					c.pendingFuncs = append(c.pendingFuncs, f)
+				}
 				f.selector = fun.X
 				isBuiltin = isPotentialCustomBuiltin(f, n)
 				if canInline(f.pkg.Path(), f.decl.Name.Name, isBuiltin) {
@@ -1021,6 +1170,9 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			return nil
 		case *ast.FuncLit:
 			isLiteral = true
+		case *ast.CallExpr, *ast.IndexExpr:
+			// Function value returned from another call or stored in a slice.
+			isLiteral = true
 		}
 
 		c.saveSequencePoint(n)
@@ -1065,11 +1217,18 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 				c.emitConvert(stackitem.ByteArrayT)
 			} else if isFunc {
 				c.emitLoadVar("", name)
-				emit.Opcodes(c.prog.BinWriter, opcode.CALLA)
+				c.emitCallFuncValue()
 			}
 		case isLiteral:
 			ast.Walk(c, n.Fun)
-			emit.Opcodes(c.prog.BinWriter, opcode.CALLA)
+			if lit, ok := n.Fun.(*ast.FuncLit); ok {
+				if len(c.closures[lit]) != 0 {
+					emit.Opcodes(c.prog.BinWriter, opcode.UNPACK, opcode.DROP)
+				}
+				emit.Opcodes(c.prog.BinWriter, opcode.CALLA)
+			} else {
+				c.emitCallFuncValue()
+			}
 		case isSyscall(f):
 			c.convertSyscall(f, n)
 		default:
@@ -1263,6 +1422,15 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		// Walk body followed by the iterator (post stmt).
 		ast.Walk(c, n.Body)
 		c.setLabel(fpost)
+		// Every iteration has its own copy of loop variables captured by closures.
+		if init, ok := n.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+			for _, lhs := range init.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Name != "_" && c.isCaptured(id) {
+					c.emitLoadVar("", id.Name)
+					c.emitInitVar(id.Name)
+				}
+			}
+		}
 		if n.Post != nil {
 			ast.Walk(c, n.Post)
 		}
@@ -1346,9 +1514,11 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 				emit.Opcodes(c.prog.BinWriter, opcode.DUP)
 			}
 			if n.Tok == token.DEFINE {
-				c.scope.newLocal(keyIdent.Name)
+				c.newLocalVar(keyIdent)
+				c.emitInitVar(keyIdent.Name)
+			} else {
+				c.emitStoreVar("", keyIdent.Name)
 			}
-			c.emitStoreVar("", keyIdent.Name)
 		}
 		if haveVal {
 			if !isMap || !keyLoaded {
@@ -1363,9 +1533,11 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 					opcode.PICKITEM)
 			}
 			if n.Tok == token.DEFINE {
-				c.scope.newLocal(valIdent.Name)
+				c.newLocalVar(valIdent)
+				c.emitInitVar(valIdent.Name)
+			} else {
+				c.emitStoreVar("", valIdent.Name)
 			}
-			c.emitStoreVar("", valIdent.Name)
 		}
 
 		ast.Walk(c, n.Body)
@@ -1393,20 +1565,96 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			return nil
 		}
 
-		goTyp := c.typeOf(n.Type)
-		if canConvert(goTyp.String()) {
-			typ := toNeoType(goTyp)
-			c.emitConvert(typ)
-		}
+		c.emitAssertConvert(c.typeOf(n.Type))
 		return nil
 	}
 	return c
 }
 
-func checkTypeAssertWithOK(n ast.Node) error {
-	if t, ok := n.(*ast.TypeAssertExpr); ok &&
-		t.Type != nil { // not a type switch
-		return ErrUnsupportedTypeAssertion
+// emitAssertConvert converts the top stack item to the given type after
+// successful type assertion.
+func (c *codegen) emitAssertConvert(typ types.Type) {
+	if canConvert(typ.String()) && !types.IsInterface(typ) {
+		if st := toNeoType(typ); st != stackitem.AnyT {
+			c.emitConvert(st)
+		}
+	}
+}
+
+// walkAssignValue emits the value for the i-th LHS expression of the
+// assignment. Multiple values are emitted at once for the first one.
+func (c *codegen) walkAssignValue(n *ast.AssignStmt, i int, multiRet bool) {
+	switch {
+	case !multiRet:
+		ast.Walk(c, n.Rhs[i])
+	case i == 0:
+		c.walkMultiValue(n.Rhs[0])
+	}
+}
+
+// walkMultiValue emits the code for the expression returning multiple values,
+// the first one is on top of the stack.
+func (c *codegen) walkMultiValue(e ast.Expr) {
+	if t, ok := e.(*ast.TypeAssertExpr); ok {
+		c.emitTypeAssertWithOK(t)
+		return
+	}
+	ast.Walk(c, e)
+}
+
+// emitTypeAssertWithOK emits the code for `v, ok := x.(T)` assertion. It
+// leaves ok and v (on top) on the stack, v is a zero value of T if assertion
+// fails.
+func (c *codegen) emitTypeAssertWithOK(n *ast.TypeAssertExpr) {
+	typ := c.typeOf(n.Type)
+	ast.Walk(c, n.X)
+	emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+	if err := c.emitTypeCheck(typ); err != nil {
+		c.prog.Err = err
+		return
+	}
+	success := c.newLabel()
+	end := c.newLabel()
+	emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, success)
+	emit.Opcodes(c.prog.BinWriter, opcode.NIP)
+	c.emitDefault(typ)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPL, end)
+	c.setLabel(success)
+	emit.Opcodes(c.prog.BinWriter, opcode.SWAP)
+	c.emitAssertConvert(typ)
+	c.setLabel(end)
+}
+
+// emitTypeCheck replaces the top stack item with the result of its check
+// against the given type. The check is done using the underlying stack item
+// type, so types with the same representation (like different integer types)
+// are not distinguished. A nil type (from the `case nil` clause) matches Null
+// item only.
+func (c *codegen) emitTypeCheck(typ types.Type) error {
+	if typ == nil || typ == types.Typ[types.UntypedNil] {
+		emit.Opcodes(c.prog.BinWriter, opcode.ISNULL)
+		return nil
+	}
+	if types.IsInterface(typ) {
+		if iface := typ.Underlying().(*types.Interface); !iface.Empty() {
+			return fmt.Errorf("%w: %s", ErrUnsupportedTypeAssertion, typ)
+		}
+		emit.Opcodes(c.prog.BinWriter, opcode.ISNULL, opcode.NOT)
+		return nil
+	}
+	sts := assertTypes(typ)
+	if len(sts) == 0 {
+		return fmt.Errorf("%w: %s", ErrUnsupportedTypeAssertion, typ)
+	}
+	for i := range sts[:len(sts)-1] {
+		emit.Opcodes(c.prog.BinWriter, opcode.DUP)
+		emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(sts[i])})
+		emit.Opcodes(c.prog.BinWriter, opcode.SWAP)
+	}
+	emit.Instruction(c.prog.BinWriter, opcode.ISTYPE, []byte{byte(sts[len(sts)-1])})
+	for range sts[:len(sts)-1] {
+		emit.Opcodes(c.prog.BinWriter, opcode.BOOLOR)
 	}
 	return nil
 }
@@ -2184,7 +2432,11 @@ func (c *codegen) getFuncFromIdent(fun *ast.Ident) (*funcScope, bool) {
 // Second return value is true iff this was a method call, not foreign package call.
 func (c *codegen) getFuncNameFromSelector(e *ast.SelectorExpr) (string, bool) {
 	if c.typeInfo.Selections[e] != nil {
-		typ := c.typeInfo.Types[e.X].Type.String()
+		typ := c.substType(c.typeInfo.Types[e.X].Type).String()
+		// Methods of generic types are declared once for all instances.
+		if i := strings.IndexByte(typ, '['); i >= 0 {
+			typ = typ[:i]
+		}
 		name := c.getIdentName(typ, e.Sel.Name)
 		if name[0] == '*' {
 			name = name[1:]
@@ -2203,6 +2455,10 @@ func (c *codegen) newLambda(u uint16, lit *ast.FuncLit) {
 		Type: lit.Type,
 		Body: lit.Body,
 	}, u)
+	if c.scope != nil {
+		f.typeArgs = c.scope.typeArgs
+	}
+	f.closure = c.closures[lit]
 	c.lambda[c.getFuncNameFromDecl("", f.decl)] = f
 }
 
@@ -2217,6 +2473,8 @@ func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 	if c.prog.Err != nil {
 		return c.prog.Err
 	}
+	c.funcUsage = funUsage
+	c.analyzeClosures()
 
 	// Bring all imported functions into scope.
 	c.ForEachFile(c.resolveFuncDecls)
@@ -2254,13 +2512,14 @@ func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 					pkgPath = pkg.Path()
 				}
 				name := c.getFuncNameFromDecl(pkgPath, n)
-				if !isInitFunc(n) && !isDeployFunc(n) && funUsage.funcUsed(name) &&
-					(!isInteropPath(pkg.Path()) && !canInline(pkg.Path(), n.Name.Name, false)) {
+				if !isInitFunc(n) && !isDeployFunc(n) && !isGenericFunc(n) && funUsage.funcUsed(name) &&
+					(!isInteropPath(pkg.Path()) || isTokenLibPath(pkg.Path())) && !canInline(pkg.Path(), n.Name.Name, false) {
 					c.convertFuncDecl(f, n, pkg)
 				}
 			}
 		}
 	})
+	c.convertPendingFuncs()
 
 	return c.prog.Err
 }
@@ -2272,6 +2531,8 @@ func newCodegen(info *buildInfo, pkg *packages.Package) *codegen {
 		l:                []int{},
 		funcs:            map[string]*funcScope{},
 		lambda:           map[string]*funcScope{},
+		closures:         map[*ast.FuncLit][]types.Object{},
+		captured:         map[types.Object]bool{},
 		reverseOffsetMap: map[int]nameWithLocals{},
 		globals:          map[string]int{},
 		labels:           map[labelWithType]uint16{},
@@ -2305,6 +2566,12 @@ func codeGen(info *buildInfo) (*nef.File, *DebugInfo, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	if info.options != nil && info.options.Optimize {
+		buf, err = c.optimize(buf)
+		if err != nil {
+			return nil, nil, fmt.Errorf("optimization failed: %w", err)
+		}
+	}
 
 	methods := bitfield.New(len(buf))
 	di := c.emitDebugInfo(buf)
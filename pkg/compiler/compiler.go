@@ -62,6 +62,24 @@ type Options struct {
 	// occurrence of event call.
 	GuessEventTypes bool
 
+	// GuessPermissions specifies if manifest permissions need to be generated
+	// from the contract calls found in the code. Permissions are replaced by
+	// the minimal set allowing these calls (see GuessPermissions function),
+	// configured ones are not kept. Calls to the contracts with hash unknown at
+	// compile time are allowed via wildcard contract permission, calls with
+	// method unknown at compile time can't be allowed and are reported via
+	// Warnf.
+	GuessPermissions bool
+
+	// Warnf is used to report non-fatal problems found during compilation,
+	// they're ignored if it's not set.
+	Warnf func(format string, args ...any)
+
+	// Optimize enables an additional optimization pass over the resulting
+	// script performing peephole rewrites, jump threading, dead stores and
+	// unreachable code removal. Debug information is corrected accordingly.
+	Optimize bool
+
 	// Name is a contract's name to be written to manifest.
 	Name string
 
@@ -283,6 +301,18 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if err != nil {
 		return f.Script, err
 	}
+	if o.GuessPermissions {
+		o.Permissions = GuessPermissions(di)
+		if o.Warnf != nil {
+			for _, c := range di.UnresolvedCalls {
+				if c.Method == "" {
+					o.Warnf("%s: method of the contract call can't be determined, it's not allowed by generated permissions", c.Pos)
+				} else {
+					o.Warnf("%s: contract hash for '%s' call can't be determined, any contract is allowed", c.Pos, c.Method)
+				}
+			}
+		}
+	}
 	if o.DebugInfo == "" && o.ManifestFile == "" && o.BindingsFile == "" {
 		return f.Script, nil
 	}
@@ -448,6 +478,78 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	return f.Script, nil
 }
 
+// GuessPermissions returns the minimal set of permissions allowing all the
+// contract calls from di. Every called contract gets a single permission with
+// all of its called methods, calls with contract hash unknown at compile time
+// are allowed for any contract (with the specific methods, which are then not
+// repeated for specific contracts). Calls with method unknown at compile time
+// (see DebugInfo.UnresolvedCalls) are not allowed by the result, they need to
+// be reported to the user.
+func GuessPermissions(di *DebugInfo) []manifest.Permission {
+	var (
+		res     []manifest.Permission
+		hashes  = make([]util.Uint160, 0, len(di.InvokedContracts))
+		anyHash = make(map[string]bool)
+	)
+	for _, m := range di.InvokedContracts[util.Uint160{}] {
+		anyHash[m] = true
+	}
+	for h := range di.InvokedContracts {
+		if !h.Equals(util.Uint160{}) {
+			hashes = append(hashes, h)
+		}
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Less(hashes[j]) })
+	if len(anyHash) != 0 {
+		hashes = append(hashes, util.Uint160{})
+	}
+	for _, h := range hashes {
+		var (
+			knownHash = !h.Equals(util.Uint160{})
+			methods   = make(map[string]bool)
+		)
+		for _, m := range di.InvokedContracts[h] {
+			if !knownHash || !anyHash[m] {
+				methods[m] = true
+			}
+		}
+		if len(methods) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(methods))
+		for m := range methods {
+			names = append(names, m)
+		}
+		sort.Strings(names)
+		desc := manifest.PermissionDesc{Type: manifest.PermissionWildcard}
+		if knownHash {
+			desc = manifest.PermissionDesc{Type: manifest.PermissionHash, Value: h}
+		}
+		p := manifest.Permission{Contract: desc}
+		p.Methods.Restrict()
+		for _, m := range names {
+			p.Methods.Add(m)
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+// isCallAllowed checks whether method of the contract h is allowed to be
+// called by perms. Group or wildcard permissions are ok to try, the same
+// applies to any permissions for the unknown contract.
+func isCallAllowed(perms []manifest.Permission, h util.Uint160, knownHash bool, method string) bool {
+	for _, p := range perms {
+		if knownHash && p.Contract.Type == manifest.PermissionHash && !p.Contract.Hash().Equals(h) {
+			continue
+		}
+		if p.Methods.Contains(method) {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateManifest creates manifest and checks that is is valid.
 func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 	m, err := di.ConvertToManifest(o)
@@ -512,17 +614,9 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 		for h, methods := range di.InvokedContracts {
 			knownHash := !h.Equals(util.Uint160{})
 
-		methodLoop:
 			for _, m := range methods {
-				for _, p := range o.Permissions {
-					// Group or wildcard permission is ok to try.
-					if knownHash && p.Contract.Type == manifest.PermissionHash && !p.Contract.Hash().Equals(h) {
-						continue
-					}
-
-					if p.Methods.Contains(m) {
-						continue methodLoop
-					}
+				if isCallAllowed(o.Permissions, h, knownHash, m) {
+					continue
 				}
 
 				if knownHash {
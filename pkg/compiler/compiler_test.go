@@ -408,6 +408,76 @@ func TestInvokedContractsPermissons(t *testing.T) {
 	})
 }
 
+func TestGuessPermissions(t *testing.T) {
+	hashStr := "aaaaaaaaaaaaaaaaaaaa"
+	src := fmt.Sprintf(`package test
+		import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		import "github.com/nspcc-dev/neo-go/pkg/interop"
+		import "github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+
+		const hash = "%s"
+		var runtimeHash interop.Hash160
+		var runtimeMethod string
+		func Main() {
+			contract.Call(interop.Hash160(hash), "method2", contract.All)
+			contract.Call(interop.Hash160(hash), "method1", contract.All)
+			contract.Call(interop.Hash160(hash), "method3", contract.ReadStates)
+			contract.Call(interop.Hash160(hash), runtimeMethod, contract.All)
+			contract.Call(runtimeHash, "someMethod", contract.All)
+			neo.Transfer(nil, nil, 10, nil)
+		}`, hashStr)
+
+	_, di, err := compiler.CompileWithOptions("permissionTest.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(di.UnresolvedCalls))
+	require.Equal(t, "", di.UnresolvedCalls[0].Method)
+	require.Equal(t, 13, di.UnresolvedCalls[0].Pos.Line)
+	require.Equal(t, "someMethod", di.UnresolvedCalls[1].Method)
+	require.Equal(t, 14, di.UnresolvedCalls[1].Pos.Line)
+
+	var h, nh util.Uint160
+	copy(h[:], hashStr)
+	copy(nh[:], neo.Hash)
+
+	t.Run("from code", func(t *testing.T) {
+		ps := compiler.GuessPermissions(di)
+
+		expected := []manifest.Permission{
+			*manifest.NewPermission(manifest.PermissionWildcard),
+			*manifest.NewPermission(manifest.PermissionHash, nh),
+			*manifest.NewPermission(manifest.PermissionHash, h),
+		}
+		expected[0].Methods.Restrict()
+		expected[0].Methods.Add("someMethod")
+		expected[1].Methods.Restrict()
+		expected[1].Methods.Add("transfer")
+		expected[2].Methods.Restrict()
+		expected[2].Methods.Add("method1")
+		expected[2].Methods.Add("method2")
+		require.ElementsMatch(t, expected, ps)
+
+		_, err := compiler.CreateManifest(di, &compiler.Options{Name: "test", Permissions: ps})
+		require.NoError(t, err)
+	})
+	t.Run("minimal", func(t *testing.T) {
+		h1, h2 := util.Uint160{1}, util.Uint160{2}
+		di := &compiler.DebugInfo{InvokedContracts: map[util.Uint160][]string{
+			h1:             {"b", "a", "b", "c"},
+			h2:             {"c"},
+			util.Uint160{}: {"c", "d"},
+		}}
+		ps := compiler.GuessPermissions(di)
+		require.Equal(t, 2, len(ps))
+		require.True(t, ps[0].Contract.Equals(manifest.PermissionDesc{Type: manifest.PermissionHash, Value: h1}))
+		require.Equal(t, []string{"a", "b"}, ps[0].Methods.Value)
+		require.Equal(t, manifest.PermissionWildcard, ps[1].Contract.Type)
+		require.Equal(t, []string{"c", "d"}, ps[1].Methods.Value)
+
+		require.Nil(t, compiler.GuessPermissions(&compiler.DebugInfo{}))
+	})
+}
+
 func TestUnnamedParameterCheck(t *testing.T) {
 	t.Run("single argument", func(t *testing.T) {
 		src := `
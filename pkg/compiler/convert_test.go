@@ -137,9 +137,7 @@ func TestTypeAssertionWithOK(t *testing.T) {
 					var _, ok = u.(int)	//	*ast.GenDecl
 					return ok
 				}`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.Error(t, err)
-		require.ErrorIs(t, err, compiler.ErrUnsupportedTypeAssertion)
+		eval(t, src, true)
 	})
 	t.Run("inside assignment statement", func(t *testing.T) {
 		src := `package foo
@@ -148,28 +146,165 @@ func TestTypeAssertionWithOK(t *testing.T) {
 					var u any
 					u = a
 					var ok bool
-					_, ok = u.(int)	// *ast.AssignStmt
+					_, ok = u.(string)	// *ast.AssignStmt
 					return ok
 				}`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.Error(t, err)
-		require.ErrorIs(t, err, compiler.ErrUnsupportedTypeAssertion)
+		eval(t, src, false)
 	})
 	t.Run("inside definition statement", func(t *testing.T) {
 		src := `package foo
+				func Main() int {
+					var u any = 42
+					v, ok := u.(int)	// *ast.AssignStmt
+					if !ok {
+						return -1
+					}
+					return v
+				}`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("zero value on failure", func(t *testing.T) {
+		src := `package foo
+				func Main() string {
+					var u any = 42
+					s, ok := u.(string)
+					if ok {
+						return "ok"
+					}
+					return s + "!"
+				}`
+		eval(t, src, []byte("!"))
+	})
+	t.Run("if init", func(t *testing.T) {
+		src := `package foo
+				func Main() int {
+					args := []any{1, "two", []byte{3}, nil, true}
+					var sum int
+					for i := range args {
+						if v, ok := args[i].(int); ok {
+							sum += v
+						} else if s, ok := args[i].(string); ok {
+							sum += 10 * len(s)
+						} else if b, ok := args[i].([]byte); ok {
+							sum += 100 * int(b[0])
+						} else if _, ok := args[i].(any); !ok {
+							sum += 1000
+						}
+					}
+					return sum
+				}`
+		eval(t, src, big.NewInt(1331))
+	})
+	t.Run("struct", func(t *testing.T) {
+		src := `package foo
+				type pair struct { a, b int }
+				func Main() int {
+					var u any = pair{a: 1, b: 2}
+					p, ok := u.(pair)
+					if _, isInt := u.(int); isInt || !ok {
+						return -1
+					}
+					return p.a + p.b
+				}`
+		eval(t, src, big.NewInt(3))
+	})
+	t.Run("to struct field", func(t *testing.T) {
+		src := `package foo
+				type res struct { v int; ok bool }
 				func Main() bool {
-					a := 1
-					var u any
-					u = a
-					_, ok := u.(int)	// *ast.AssignStmt
+					var u any = 42
+					var r res
+					r.v, r.ok = u.(int)
+					return r.ok && r.v == 42
+				}`
+		eval(t, src, true)
+	})
+	t.Run("non-empty interface", func(t *testing.T) {
+		src := `package foo
+				type stringer interface { String() string }
+				func Main() bool {
+					var u any = 42
+					_, ok := u.(stringer)
 					return ok
 				}`
 		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.Error(t, err)
 		require.ErrorIs(t, err, compiler.ErrUnsupportedTypeAssertion)
 	})
 }
 
+func TestTypeSwitch(t *testing.T) {
+	t.Run("with variable", func(t *testing.T) {
+		src := `package foo
+				func Main() int {
+					args := []any{1, "two", []byte{3, 4}, nil, true, []any{1, 2}, map[int]int{1: 2}}
+					var sum int
+					for i := range args {
+						switch v := args[i].(type) {
+						case int:
+							sum += v
+						default:
+							sum += 1000000
+						case string:
+							sum += 10 * len(v)
+						case []byte:
+							sum += 100 * int(v[0]+v[1])
+						case nil:
+							sum += 1000
+						case []any, map[int]int:
+							sum += 10000
+						}
+					}
+					return sum
+				}`
+		eval(t, src, big.NewInt(1021731))
+	})
+	t.Run("without variable", func(t *testing.T) {
+		src := `package foo
+				func classify(x any) int {
+					switch x.(type) {
+					case bool:
+						return 1
+					case int:
+						return 2
+					}
+					return 3
+				}
+				func Main() int {
+					return classify(true)*100 + classify(5)*10 + classify("s")
+				}`
+		eval(t, src, big.NewInt(123))
+	})
+	t.Run("break and init", func(t *testing.T) {
+		src := `package foo
+				func Main() int {
+					res := 0
+					switch x := any(42); y := x.(type) {
+					case int:
+						if y > 10 {
+							res = 1
+							break
+						}
+						res = 2
+					}
+					return res
+				}`
+		eval(t, src, big.NewInt(1))
+	})
+	t.Run("captured variable", func(t *testing.T) {
+		src := `package foo
+				func Main() int {
+					var x any = 41
+					switch v := x.(type) {
+					case int:
+						f := func() int { return v + 1 }
+						return f()
+					}
+					return 0
+				}`
+		eval(t, src, big.NewInt(42))
+	})
+}
+
 func TestTypeConversion(t *testing.T) {
 	src := `package foo
 	type myInt int
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"sort"
 	"strconv"
@@ -39,10 +40,22 @@ type DebugInfo struct {
 	EmittedEvents map[string][]EmittedEventInfo `json:"-"`
 	// InvokedContracts contains foreign contract invocations.
 	InvokedContracts map[util.Uint160][]string `json:"-"`
+	// UnresolvedCalls contains foreign contract invocations with the contract
+	// hash or method unknown at compile time.
+	UnresolvedCalls []UnresolvedCall `json:"-"`
 	// StaticVariables contains a list of static variable names and types.
 	StaticVariables []string `json:"static-variables"`
 }
 
+// UnresolvedCall is a contract call with the contract hash or method that
+// can't be determined at compile time.
+type UnresolvedCall struct {
+	// Pos is the position of the call in the source code.
+	Pos token.Position
+	// Method is the name of the method called, it's empty if not known.
+	Method string
+}
+
 // MethodDebugInfo represents smart-contract's method debug information.
 type MethodDebugInfo struct {
 	// ID is the actual name of the method.
@@ -205,7 +218,13 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 
 	var fnames = make([]string, 0, len(c.funcs))
 	for name, scope := range c.funcs {
-		if scope.rng.Start == scope.rng.End {
+		// Generic declarations are only converted via their instances.
+		if scope.rng.Start == scope.rng.End || isGenericFunc(scope.decl) && scope.typeArgs == nil {
+			continue
+		}
+		// Ranges of functions that were not emitted are not remapped by
+		// the optimizer, so they can't be relied upon.
+		if c.optimized && !scope.converted {
 			continue
 		}
 		fnames = append(fnames, name)
@@ -218,6 +237,7 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 	}
 	d.EmittedEvents = c.emittedEvents
 	d.InvokedContracts = c.invokedContracts
+	d.UnresolvedCalls = c.unresolvedCalls
 	return d
 }
 
@@ -231,6 +251,10 @@ func (c *codegen) registerDebugVariable(name string, expr ast.Expr) {
 }
 
 func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[string]binding.ExtendedType) *MethodDebugInfo {
+	// Generic function instance types depend on the scope.
+	defer func(s *funcScope) { c.scope = s }(c.scope)
+	c.scope = scope
+
 	ps := scope.decl.Type.Params
 	params := make([]DebugParam, 0, ps.NumFields())
 	for i := range ps.List {
@@ -245,8 +269,12 @@ func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[st
 			})
 		}
 	}
+	var targs string
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name, targs = name[:i], name[i:]
+	}
 	ss := strings.Split(name, ".")
-	name = ss[len(ss)-1]
+	name = ss[len(ss)-1] + targs
 	r, n := utf8.DecodeRuneInString(name)
 	st, vt, rt, et := c.scAndVMReturnTypeFromScope(scope, exts)
 
@@ -256,7 +284,7 @@ func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[st
 			Name:      string(unicode.ToLower(r)) + name[n:],
 			Namespace: scope.pkg.Name(),
 		},
-		IsExported:         scope.decl.Name.IsExported(),
+		IsExported:         scope.decl.Name.IsExported() && scope.typeArgs == nil,
 		IsFunction:         scope.decl.Recv == nil,
 		Range:              scope.rng,
 		Parameters:         params,
@@ -264,7 +292,7 @@ func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[st
 		ReturnTypeExtended: et,
 		ReturnTypeReal:     rt,
 		ReturnTypeSC:       st,
-		SeqPoints:          c.sequencePoints[name],
+		SeqPoints:          c.sequencePoints[scope.name],
 		Variables:          scope.variables,
 	}
 }
@@ -390,7 +418,7 @@ func (c *codegen) scAndVMTypeFromType(t types.Type, exts map[string]binding.Exte
 		var extName string
 		if isNamed {
 			over.Package = named.Obj().Pkg().Path()
-			over.TypeName = named.Obj().Pkg().Name() + "." + named.Obj().Name()
+			over.TypeName = namedTypeName(named)
 			_ = c.genStructExtended(t, over.TypeName, exts)
 			extName = over.TypeName
 		} else {
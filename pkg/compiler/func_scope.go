@@ -29,6 +29,8 @@ type funcScope struct {
 
 	// Range of opcodes corresponding to the function.
 	rng DebugRange
+	// converted is set once the code for the function is emitted.
+	converted bool
 	// Variables together with it's type in neo-vm.
 	variables []string
 
@@ -48,6 +50,14 @@ type funcScope struct {
 
 	// Local variable counter.
 	i int
+
+	// typeArgs maps type parameters to type arguments for generic function
+	// instances, it's nil for regular functions.
+	typeArgs map[*types.TypeParam]types.Type
+
+	// closure contains variables captured by the function literal, it's
+	// nil for functions not capturing anything.
+	closure []types.Object
 }
 
 type deferInfo struct {
@@ -92,6 +102,9 @@ func (c *codegen) getFuncNameFromDecl(pkgPath string, decl *ast.FuncDecl) string
 			case *ast.IndexExpr:
 				// Generic func declaration receiver: func (x *Pointer[T]) Load() *T
 				name = t.X.(*ast.IndexExpr).X.(*ast.Ident).Name + "." + name
+			case *ast.IndexListExpr:
+				// Generic func declaration receiver: func (x *Pair[K, V]) Key() K
+				name = t.X.(*ast.IndexListExpr).X.(*ast.Ident).Name + "." + name
 			default:
 				panic(fmt.Errorf("unexpected function `%s` receiver type: %T", name, t.X))
 			}
@@ -103,6 +116,14 @@ func (c *codegen) getFuncNameFromDecl(pkgPath string, decl *ast.FuncDecl) string
 			default:
 				panic(fmt.Errorf("unexpected function `%s` receiver type: %T", name, t.X))
 			}
+		case *ast.IndexListExpr:
+			switch t.X.(type) {
+			case *ast.Ident:
+				// Generic func declaration receiver: func (x Pair[K, V]) Key() K
+				name = t.X.(*ast.Ident).Name + "." + name
+			default:
+				panic(fmt.Errorf("unexpected function `%s` receiver type: %T", name, t.X))
+			}
 		}
 	}
 	return c.getIdentName(pkgPath, name)
@@ -123,6 +144,9 @@ func (c *funcScope) analyzeVoidCalls(node ast.Node) bool {
 
 func (c *funcScope) countArgs() int {
 	n := c.decl.Type.Params.NumFields()
+	if c.closure != nil {
+		n++ // Environment.
+	}
 	if c.decl.Recv != nil {
 		n += c.decl.Recv.NumFields()
 	}
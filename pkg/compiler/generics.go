@@ -0,0 +1,165 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// Generic functions and methods of generic types are monomorphized: the code
+// is generated for every instantiation separately with type parameters
+// substituted by the actual type arguments (see substType), generic
+// declarations themselves are never converted.
+
+// isGenericFunc checks whether the function declaration has type parameters
+// (either its own or the ones of its receiver).
+func isGenericFunc(decl *ast.FuncDecl) bool {
+	if decl.Type.TypeParams != nil {
+		return true
+	}
+	if decl.Recv != nil {
+		t := decl.Recv.List[0].Type
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+		switch t.(type) {
+		case *ast.IndexExpr, *ast.IndexListExpr:
+			return true
+		}
+	}
+	return false
+}
+
+// instanceOf returns instantiation info for the generic function referenced
+// by e (either an identifier or a selector).
+func (c *codegen) instanceOf(e ast.Expr) (types.Instance, bool) {
+	var id *ast.Ident
+	switch t := e.(type) {
+	case *ast.Ident:
+		id = t
+	case *ast.SelectorExpr:
+		id = t.Sel
+	default:
+		return types.Instance{}, false
+	}
+	for i := len(c.pkgInfoInline) - 1; i >= 0; i-- {
+		if inst, ok := c.pkgInfoInline[i].TypesInfo.Instances[id]; ok {
+			return inst, true
+		}
+	}
+	inst, ok := c.typeInfo.Instances[id]
+	return inst, ok
+}
+
+// unwrapInstance strips explicit type arguments from the generic function
+// reference, so that `Max[int]` becomes `Max`. Other expressions are returned
+// as is.
+func (c *codegen) unwrapInstance(e ast.Expr) ast.Expr {
+	var x ast.Expr
+	switch t := e.(type) {
+	case *ast.IndexExpr:
+		x = t.X
+	case *ast.IndexListExpr:
+		x = t.X
+	default:
+		return e
+	}
+	if _, ok := c.instanceOf(x); ok {
+		return x
+	}
+	return e
+}
+
+// instantiate returns the instance of the generic function f referenced by
+// fun creating it if needed. New instances are converted after all regular
+// functions. nil is returned (and the error is set) if type arguments can't
+// be determined.
+func (c *codegen) instantiate(f *funcScope, fun ast.Expr) *funcScope {
+	var targs []types.Type
+	if inst, ok := c.instanceOf(fun); ok {
+		for i := 0; i < inst.TypeArgs.Len(); i++ {
+			targs = append(targs, inst.TypeArgs.At(i))
+		}
+	} else if sel, ok := fun.(*ast.SelectorExpr); ok && f.decl.Recv != nil {
+		// Method of a generic type, type arguments are the receiver's ones.
+		typ := c.typeOf(sel.X)
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if named, ok := typ.(*types.Named); ok {
+			for i := 0; i < named.TypeArgs().Len(); i++ {
+				targs = append(targs, named.TypeArgs().At(i))
+			}
+		}
+	}
+
+	info := c.packageCache[f.pkg.Path()].TypesInfo
+	sig := info.Defs[f.decl.Name].Type().(*types.Signature)
+	tparams := sig.TypeParams()
+	if f.decl.Recv != nil {
+		tparams = sig.RecvTypeParams()
+	}
+	if len(targs) == 0 || tparams.Len() != len(targs) {
+		c.prog.Err = fmt.Errorf("%w: can't instantiate %s", ErrGenericsUnsuppored, f.name)
+		return nil
+	}
+
+	var (
+		m          = make(map[*types.TypeParam]types.Type, len(targs))
+		full, name = make([]string, len(targs)), make([]string, len(targs))
+	)
+	for i := range targs {
+		targs[i] = c.substType(targs[i])
+		m[tparams.At(i)] = targs[i]
+		full[i] = types.TypeString(targs[i], nil)
+		name[i] = types.TypeString(targs[i], pkgNameQualifier)
+	}
+	key := c.getFuncNameFromDecl(f.pkg.Path(), f.decl) + "[" + strings.Join(full, ",") + "]"
+	if inst, ok := c.funcs[key]; ok {
+		return inst
+	}
+	inst := c.newFuncScope(f.decl, c.newLabel())
+	inst.name = f.name + "[" + strings.Join(name, ",") + "]"
+	inst.pkg = f.pkg
+	inst.file = f.file
+	inst.typeArgs = m
+	c.funcs[key] = inst
+	c.pendingFuncs = append(c.pendingFuncs, inst)
+	return inst
+}
+
+// convertPendingFuncs converts generic function instances and functions used
+// only from them.
+func (c *codegen) convertPendingFuncs() {
+	for len(c.pendingFuncs) != 0 && c.prog.Err == nil {
+		f := c.pendingFuncs[0]
+		c.pendingFuncs = c.pendingFuncs[1:]
+
+		pkg := c.packageCache[f.pkg.Path()]
+		c.typeInfo = pkg.TypesInfo
+		c.currPkg = pkg
+		c.fillImportMap(f.file, pkg)
+		c.convertFuncScope(f.file, f, pkg.Types, false)
+		c.scope = nil
+	}
+}
+
+// pkgNameQualifier qualifies types by package names.
+func pkgNameQualifier(p *types.Package) string {
+	return p.Name()
+}
+
+// namedTypeName returns the name of the named type to be used in the debug
+// info and bindings, generic type instances have type arguments appended.
+func namedTypeName(named *types.Named) string {
+	name := named.Obj().Pkg().Name() + "." + named.Obj().Name()
+	if targs := named.TypeArgs(); targs.Len() != 0 {
+		args := make([]string, targs.Len())
+		for i := range args {
+			args[i] = types.TypeString(targs.At(i), pkgNameQualifier)
+		}
+		name += "[" + strings.Join(args, ",") + "]"
+	}
+	return name
+}
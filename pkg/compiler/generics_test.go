@@ -1,6 +1,7 @@
 package compiler_test
 
 import (
+	"math/big"
 	"strings"
 	"testing"
 
@@ -8,84 +9,242 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestGenericMethodReceiver(t *testing.T) {
-	t.Run("star expression", func(t *testing.T) {
-		src := `
-		package receiver
-		type Pointer[T any] struct {
-			value T
+func TestGenericFunc(t *testing.T) {
+	const genericMax = `
+	type ordered interface {
+		~int | ~string
+	}
+	func max[T ordered](a, b T) T {
+		if a > b {
+			return a
 		}
-		func (x *Pointer[T]) Load() *T {
-			return &x.value
+		return b
+	}`
+	const genericSum = `
+	func sum[T int | string](vals ...T) T {
+		var s T
+		for i := range vals {
+			s += vals[i]
 		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
-	})
-	t.Run("ident expression", func(t *testing.T) {
-		src := `
-		package receiver
-		type Pointer[T any] struct {
-			value T
-		}
-		func (x Pointer[T]) Load() *T {
-			return &x.value
-		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
+		return s
+	}`
+	runTestCases(t, []testCase{
+		{
+			"int instance",
+			`package foo
+			func Main() int { return max(1, 5) + max(7, 3) }` + genericMax,
+			big.NewInt(12),
+		},
+		{
+			"string instance",
+			`package foo
+			func Main() string { return max("abc", "abd") }` + genericMax,
+			[]byte("abd"),
+		},
+		{
+			"both instances",
+			`package foo
+			func Main() string {
+				if max(1, 2) == 2 {
+					return max("a", "b")
+				}
+				return ""
+			}` + genericMax,
+			[]byte("b"),
+		},
+		{
+			"explicit instantiation",
+			`package foo
+			func Main() int { return max[int](3, 4) }` + genericMax,
+			big.NewInt(4),
+		},
+		{
+			"zero value and ADD",
+			`package foo
+			func Main() int { return sum(1, 2, 3) + sum[int]() }` + genericSum,
+			big.NewInt(6),
+		},
+		{
+			"zero value and CAT",
+			`package foo
+			func Main() string { return sum("a", "b", "c") + sum[string]() }` + genericSum,
+			[]byte("abc"),
+		},
+		{
+			"nested generic calls",
+			`package foo
+			func max3[T ~int | ~string](a, b, c T) T { return max(max(a, b), c) }
+			func Main() int { return max3(1, 9, 4) }` + genericMax,
+			big.NewInt(9),
+		},
+		{
+			"named type argument",
+			`package foo
+			type Amount int
+			func Main() Amount { return max(Amount(2), Amount(1)) }` + genericMax,
+			big.NewInt(2),
+		},
+		{
+			"lambda inside generic function",
+			`package foo
+			func apply[T any](vals []T, f func(T) T) []T {
+				res := make([]T, len(vals))
+				for i := range vals {
+					res[i] = f(vals[i])
+				}
+				return res
+			}
+			func twice[T int | string](v T) T {
+				f := func(x T) T { return x + x }
+				return f(v)
+			}
+			func Main() int {
+				r := apply([]int{1, 2}, func(x int) int { return x * 10 })
+				return r[0] + r[1] + twice(100)
+			}`,
+			big.NewInt(230),
+		},
+		{
+			"constraint method",
+			`package foo
+			type valuer interface { Value() int }
+			type num struct { v int }
+			func (n num) Value() int { return n.v * 2 }
+			func total[T valuer](vals []T) int {
+				var s int
+				for _, v := range vals {
+					s += v.Value()
+				}
+				return s
+			}
+			func Main() int { return total([]num{{1}, {2}}) }`,
+			big.NewInt(6),
+		},
 	})
 }
 
-func TestGenericFuncArgument(t *testing.T) {
-	src := `
-		package sum
-		func SumInts[V int64 | int32 | int16](vals []V) V { // doesn't make sense with NeoVM, but still it's a valid go code.
-			var s V
-			for i := range vals {
-				s += vals[i]
+func TestGenericType(t *testing.T) {
+	runTestCases(t, []testCase{
+		{
+			"pointer receiver",
+			`package foo
+			type Stack[T any] struct {
+				items []T
+				n     int
 			}
-			return s
-		}
-`
-	_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-	require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
+			func (s *Stack[T]) Push(v T) {
+				s.items = append(s.items, v)
+				s.n += 1
+			}
+			func (s *Stack[T]) Pop() T {
+				s.n -= 1
+				return s.items[s.n]
+			}
+			func Main() int {
+				s := &Stack[int]{}
+				s.Push(1)
+				s.Push(2)
+				ss := &Stack[string]{}
+				ss.Push("abc")
+				return s.Pop() * 10 + len(ss.Pop())
+			}`,
+			big.NewInt(23),
+		},
+		{
+			"value receiver",
+			`package foo
+			type Box[T any] struct {
+				v T
+			}
+			func (b Box[T]) Get() T { return b.v }
+			func Main() int {
+				b := Box[int]{v: 42}
+				return b.Get()
+			}`,
+			big.NewInt(42),
+		},
+		{
+			"multiple type parameters",
+			`package foo
+			type Pair[K, V comparable] struct {
+				Key K
+				Val V
+			}
+			func (p Pair[K, V]) Swap() Pair[V, K] { return Pair[V, K]{Key: p.Val, Val: p.Key} }
+			func Main() string {
+				p := Pair[int, string]{Key: 1, Val: "one"}.Swap()
+				return p.Key
+			}`,
+			[]byte("one"),
+		},
+		{
+			"generic function with generic type",
+			`package foo
+			type List[T any] struct {
+				next *List[T]
+				val  T
+			}
+			func count[T any](l *List[T]) int {
+				var n int
+				for ; l != nil; l = l.next {
+					n++
+				}
+				return n
+			}
+			func Main() int {
+				l := &List[int]{val: 1, next: &List[int]{val: 2}}
+				return count(l)
+			}`,
+			big.NewInt(2),
+		},
+	})
 }
 
-func TestGenericTypeDecl(t *testing.T) {
-	t.Run("global scope", func(t *testing.T) {
-		src := `
-		package sum
-		type List[T any] struct {
-			next *List[T]
-			val  T
+func TestGenericDebugInfo(t *testing.T) {
+	src := `package foo
+	type Box[T any] struct {
+		V T
+	}
+	func (b *Box[T]) Get() T { return b.V }
+	func Max[T int | string](a, b T) T {
+		if a > b {
+			return a
 		}
+		return b
+	}
+	func Main() int {
+		b := &Box[string]{V: Max("a", "b")}
+		return Max(1, 2) + len(b.Get())
+	}`
+	_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
 
-		func Main() any {
-			l := List[int]{}
-			return l
+	var names []string
+	for _, m := range di.Methods {
+		names = append(names, m.ID)
+		if m.ID != "Main" {
+			require.False(t, m.IsExported, m.ID)
 		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
-	})
-	t.Run("local scope", func(t *testing.T) {
-		src := `
-		package sum
-		func Main() any {
-			type (
-				SomeGoodType int
-			
-				List[T any] struct {
-					next *List[T]
-					val  T
-				}
-			)
-			l := List[int]{}
-			return l
+	}
+	require.ElementsMatch(t, []string{"Main", "Max[int]", "Max[string]", "Get[string]"}, names)
+
+	m, err := di.ConvertToManifest(&compiler.Options{Name: "foo"})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(m.ABI.Methods))
+	require.Equal(t, "main", m.ABI.Methods[0].Name)
+}
+
+func TestGenericFuncUnused(t *testing.T) {
+	src := `package foo
+	func Max[T int | string](a, b T) T {
+		if a > b {
+			return a
 		}
-`
-		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
-		require.ErrorIs(t, err, compiler.ErrGenericsUnsuppored)
-	})
+		return b
+	}
+	func Main() int { return 1 }`
+	_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(di.Methods))
+	require.Equal(t, "Main", di.Methods[0].ID)
 }
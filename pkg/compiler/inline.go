@@ -261,19 +261,25 @@ func (c *codegen) processContractCall(f *funcScope, args []ast.Expr) {
 		}
 	}
 
-	value := c.typeAndValueOf(args[1]).Value
-	if value == nil {
-		return
+	var (
+		method string
+		flag   = uint64(callflag.All)
+	)
+	if value := c.typeAndValueOf(args[1]).Value; value != nil {
+		method = constant.StringVal(value)
 	}
-
-	method := constant.StringVal(value)
-
-	value = c.typeAndValueOf(args[2]).Value
-	if value == nil {
+	if value := c.typeAndValueOf(args[2]).Value; value != nil {
+		flag, _ = constant.Uint64Val(value)
+	}
+	if (method == "" || u.Equals(util.Uint160{})) && flag&uint64(callflag.WriteStates|callflag.AllowNotify) != 0 {
+		c.unresolvedCalls = append(c.unresolvedCalls, UnresolvedCall{
+			Pos:    c.buildInfo.config.Fset.Position(args[0].Pos()),
+			Method: method,
+		})
+	}
+	if method == "" || c.typeAndValueOf(args[2]).Value == nil {
 		return
 	}
-
-	flag, _ := constant.Uint64Val(value)
 	c.appendInvokedContract(u, method, flag)
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -28,6 +29,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -622,6 +624,237 @@ func TestCallWithVersion(t *testing.T) {
 	})
 }
 
+var transferEvent = compiler.HybridEvent{
+	Name: "Transfer",
+	Parameters: []compiler.HybridParameter{
+		{Parameter: manifest.NewParameter("from", smartcontract.Hash160Type)},
+		{Parameter: manifest.NewParameter("to", smartcontract.Hash160Type)},
+		{Parameter: manifest.NewParameter("amount", smartcontract.IntegerType)},
+	},
+}
+
+// compileTokenLibContract compiles the given source as a part of testdata
+// module that uses local pkg/interop (root module depends on the released
+// one which may not have token libraries yet).
+func compileTokenLibContract(t *testing.T, sender util.Uint160, src string, opts *compiler.Options) *neotest.Contract {
+	config.Version = "neotest"
+	name, err := filepath.Abs(filepath.Join("testdata", "tokenlib", "contract.go"))
+	require.NoError(t, err)
+	ne, di, err := compiler.CompileWithOptions(name, strings.NewReader(src), opts)
+	require.NoError(t, err)
+	m, err := compiler.CreateManifest(di, opts)
+	require.NoError(t, err)
+	return &neotest.Contract{
+		Hash:     state.CreateContractHash(sender, ne.Checksum, m.Name),
+		NEF:      ne,
+		Manifest: m,
+	}
+}
+
+func TestNEP17Lib(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/lib/nep17"
+		)
+		type hooks struct{}
+		func (hooks) CheckTransfer(from, to interop.Hash160, amount int, data any) bool {
+			return data != "reject"
+		}
+		func (hooks) CheckMint(to interop.Hash160, amount int) bool {
+			return amount <= 1000
+		}
+		func (hooks) CheckBurn(from interop.Hash160, amount int) bool {
+			return amount <= 10
+		}
+		var token = nep17.Token[hooks]{
+			Symbol:        "TOK",
+			Decimals:      2,
+			BalancePrefix: "b",
+			SupplyKey:     "s",
+		}
+		func Symbol() string { return token.Symbol }
+		func Decimals() int { return token.Decimals }
+		func TotalSupply() int { return token.TotalSupply() }
+		func BalanceOf(account interop.Hash160) int { return token.BalanceOf(account) }
+		func Transfer(from, to interop.Hash160, amount int, data any) bool {
+			return token.Transfer(from, to, amount, data)
+		}
+		func Mint(to interop.Hash160, amount int) { token.Mint(to, amount, nil) }
+		func Burn(from interop.Hash160, amount int) { token.Burn(from, amount) }`
+	ctr := compileTokenLibContract(t, e.CommitteeHash, src, &compiler.Options{
+		Name:                       "Token",
+		ContractEvents:             []compiler.HybridEvent{transferEvent},
+		ContractSupportedStandards: []string{manifest.NEP17StandardName},
+		SafeMethods:                []string{"symbol", "decimals", "totalSupply", "balanceOf"},
+		Permissions:                []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+	})
+	require.NoError(t, standard.Check(ctr.Manifest, manifest.NEP17StandardName))
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+
+	receiverSrc := `package receiver
+		import "github.com/nspcc-dev/neo-go/pkg/interop"
+		func OnNEP17Payment(from interop.Hash160, amount int, data any) {
+			if amount > 50 {
+				panic("too much")
+			}
+		}`
+	receiver := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(receiverSrc), &compiler.Options{Name: "Receiver"})
+	e.DeployContract(t, receiver, nil)
+
+	owner := e.CommitteeHash
+	other := e.NewAccount(t).ScriptHash()
+
+	c.Invoke(t, "TOK", "symbol")
+	c.Invoke(t, 2, "decimals")
+	c.InvokeFail(t, "minting is not allowed", "mint", owner, 1001)
+	c.Invoke(t, nil, "mint", owner, 1000)
+	c.Invoke(t, 1000, "totalSupply")
+	c.Invoke(t, 1000, "balanceOf", owner)
+	c.InvokeFail(t, "invalid account", "balanceOf", []byte{1, 2, 3})
+
+	t.Run("transfer", func(t *testing.T) {
+		c.Invoke(t, true, "transfer", owner, other, 100, nil)
+		c.Invoke(t, 900, "balanceOf", owner)
+		c.Invoke(t, 100, "balanceOf", other)
+		c.Invoke(t, true, "transfer", owner, owner, 100, nil)
+		c.Invoke(t, 900, "balanceOf", owner)
+	})
+	t.Run("no witness", func(t *testing.T) {
+		c.Invoke(t, false, "transfer", other, owner, 1, nil)
+	})
+	t.Run("insufficient funds", func(t *testing.T) {
+		c.Invoke(t, false, "transfer", owner, other, 901, nil)
+	})
+	t.Run("rejected by hook", func(t *testing.T) {
+		c.Invoke(t, false, "transfer", owner, other, 1, "reject")
+	})
+	t.Run("negative amount", func(t *testing.T) {
+		c.InvokeFail(t, "negative amount", "transfer", owner, other, -1, nil)
+	})
+	t.Run("contract receiver", func(t *testing.T) {
+		c.Invoke(t, true, "transfer", owner, receiver.Hash, 50, nil)
+		c.Invoke(t, 50, "balanceOf", receiver.Hash)
+		c.InvokeFail(t, "too much", "transfer", owner, receiver.Hash, 51, nil)
+	})
+	t.Run("burn", func(t *testing.T) {
+		c.InvokeFail(t, "burning is not allowed", "burn", owner, 11)
+		c.InvokeFail(t, "insufficient funds", "burn", other, 101)
+		c.Invoke(t, nil, "burn", other, 10)
+		c.Invoke(t, 90, "balanceOf", other)
+		c.Invoke(t, 990, "totalSupply")
+	})
+}
+
+func TestNEP11Lib(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+			"github.com/nspcc-dev/neo-go/pkg/interop/lib/nep11"
+		)
+		type hooks struct{}
+		func (hooks) CheckTransfer(from, to interop.Hash160, tokenID []byte, data any) bool {
+			return data != "reject"
+		}
+		func (hooks) CheckMint(to interop.Hash160, tokenID []byte) bool {
+			return string(tokenID) != "forbidden"
+		}
+		func (hooks) CheckBurn(owner interop.Hash160, tokenID []byte) bool {
+			return string(tokenID) != "eternal"
+		}
+		var token = nep11.Token[hooks]{
+			Symbol:        "NFT",
+			SupplyKey:     "s",
+			BalancePrefix: "b",
+			AccountPrefix: "a",
+			TokenPrefix:   "t",
+		}
+		func Symbol() string { return token.Symbol }
+		func Decimals() int { return 0 }
+		func TotalSupply() int { return token.TotalSupply() }
+		func BalanceOf(owner interop.Hash160) int { return token.BalanceOf(owner) }
+		func TokensOf(owner interop.Hash160) iterator.Iterator { return token.TokensOf(owner) }
+		func Tokens() iterator.Iterator { return token.Tokens() }
+		func OwnerOf(tokenId []byte) interop.Hash160 { return token.OwnerOf(tokenId) }
+		func Transfer(to interop.Hash160, tokenId []byte, data any) bool {
+			return token.Transfer(to, tokenId, data)
+		}
+		func Mint(to interop.Hash160, tokenId []byte) { token.Mint(to, tokenId, nil) }
+		func Burn(tokenId []byte) { token.Burn(tokenId) }
+		func FirstTokenOf(owner interop.Hash160) []byte {
+			it := token.TokensOf(owner)
+			if !iterator.Next(it) {
+				return nil
+			}
+			return iterator.Value(it).([]byte)
+		}`
+	ctr := compileTokenLibContract(t, e.CommitteeHash, src, &compiler.Options{
+		Name: "NFT",
+		ContractEvents: []compiler.HybridEvent{{
+			Name: "Transfer",
+			Parameters: append(transferEvent.Parameters,
+				compiler.HybridParameter{Parameter: manifest.NewParameter("tokenId", smartcontract.ByteArrayType)}),
+		}},
+		ContractSupportedStandards: []string{manifest.NEP11StandardName},
+		SafeMethods:                []string{"symbol", "decimals", "totalSupply", "balanceOf", "tokensOf", "tokens", "ownerOf"},
+		Permissions:                []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+	})
+	require.NoError(t, standard.Check(ctr.Manifest, manifest.NEP11StandardName))
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+
+	owner := e.CommitteeHash
+	other := e.NewAccount(t).ScriptHash()
+
+	c.InvokeFail(t, "minting is not allowed", "mint", owner, []byte("forbidden"))
+	c.Invoke(t, nil, "mint", owner, []byte("first"))
+	c.Invoke(t, nil, "mint", owner, []byte("eternal"))
+	c.InvokeFail(t, "token already exists", "mint", other, []byte("first"))
+	c.Invoke(t, 2, "totalSupply")
+	c.Invoke(t, 2, "balanceOf", owner)
+	c.Invoke(t, owner.BytesBE(), "ownerOf", []byte("first"))
+	c.InvokeFail(t, "unknown token", "ownerOf", []byte("none"))
+
+	t.Run("transfer", func(t *testing.T) {
+		c.Invoke(t, false, "transfer", other, []byte("first"), "reject")
+		c.Invoke(t, true, "transfer", other, []byte("first"), nil)
+		c.Invoke(t, other.BytesBE(), "ownerOf", []byte("first"))
+		c.Invoke(t, 1, "balanceOf", owner)
+		c.Invoke(t, 1, "balanceOf", other)
+		c.Invoke(t, stackitem.NewBuffer([]byte("first")), "firstTokenOf", other)
+		c.Invoke(t, false, "transfer", owner, []byte("first"), nil)
+		c.InvokeFail(t, "unknown token", "transfer", owner, []byte("none"), nil)
+	})
+	t.Run("contract receiver", func(t *testing.T) {
+		receiverSrc := `package receiver
+			import "github.com/nspcc-dev/neo-go/pkg/interop"
+			func OnNEP11Payment(from interop.Hash160, amount int, tokenId []byte, data any) {
+				if data == "refuse" {
+					panic("refused")
+				}
+			}`
+		receiver := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(receiverSrc), &compiler.Options{Name: "Receiver"})
+		e.DeployContract(t, receiver, nil)
+		c.InvokeFail(t, "refused", "transfer", receiver.Hash, []byte("eternal"), "refuse")
+		c.Invoke(t, true, "transfer", receiver.Hash, []byte("eternal"), nil)
+		c.Invoke(t, receiver.Hash.BytesBE(), "ownerOf", []byte("eternal"))
+	})
+	t.Run("burn", func(t *testing.T) {
+		c.InvokeFail(t, "burning is not allowed", "burn", []byte("eternal"))
+		c.Invoke(t, nil, "burn", []byte("first"))
+		c.Invoke(t, 0, "balanceOf", other)
+		c.Invoke(t, nil, "firstTokenOf", other)
+		c.Invoke(t, 1, "totalSupply")
+		c.InvokeFail(t, "unknown token", "burn", []byte("first"))
+	})
+}
+
 func TestForcedNotifyArgumentsConversion(t *testing.T) {
 	const methodWithEllipsis = "withEllipsis"
 	const methodWithoutEllipsis = "withoutEllipsis"
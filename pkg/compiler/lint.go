@@ -0,0 +1,504 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"golang.org/x/tools/go/packages"
+)
+
+// Lint rules reported via LintIssue.
+const (
+	// LintMissingWitness is reported for storage modifications in public
+	// methods not preceded by a witness (or calling script hash) check.
+	LintMissingWitness = "missing-witness"
+	// LintReentrancy is reported for storage modifications following a call
+	// to another contract in public methods.
+	LintReentrancy = "reentrancy"
+	// LintUncheckedCall is reported for contract calls with the result ignored
+	// (except for calls with NoneFlag and calls of standard callbacks like
+	// onNEP17Payment that return nothing).
+	LintUncheckedCall = "unchecked-call"
+	// LintStorageCollision is reported for storage keys that can clash with
+	// the keys from other prefixes.
+	LintStorageCollision = "storage-collision"
+	// LintPermissions is reported for manifest permissions allowing more than
+	// the contract actually calls.
+	LintPermissions = "permissions"
+)
+
+// LintIssue is a potential problem found in the contract by Lint.
+type LintIssue struct {
+	// Pos is the position of the issue in the source code, it's not valid
+	// for the issues found in the configuration (permissions).
+	Pos token.Position
+	// Rule is the rule that has found the issue.
+	Rule string
+	// Message is a human-readable description of the issue.
+	Message string
+}
+
+// String implements the fmt.Stringer interface.
+func (i LintIssue) String() string {
+	pos := "config"
+	if i.Pos.IsValid() {
+		pos = i.Pos.String()
+	}
+	return fmt.Sprintf("%s: [%s] %s", pos, i.Rule, i.Message)
+}
+
+// linter contains the state of contract analysis.
+type linter struct {
+	fset *token.FileSet
+	main *packages.Package
+	// pkgs contains all non-interop packages of the program (token
+	// libraries are included, they're regular contract code).
+	pkgs []*packages.Package
+	// decls contains declarations of all functions from pkgs.
+	decls map[*types.Func]*ast.FuncDecl
+	infos map[*ast.FuncDecl]*types.Info
+	// dynamicCalls is set if some contract.Call uses non-constant method name.
+	dynamicCalls bool
+	issues       []LintIssue
+}
+
+// methodState is the state of public method analysis, the method is walked
+// in the order of the source code, so every condition and loop body is
+// treated as being executed.
+type methodState struct {
+	name       string
+	authorized bool
+	// externalCall is the position of the first call to another contract.
+	externalCall token.Pos
+	visited      map[*ast.FuncDecl]bool
+	reported     map[string]bool
+}
+
+// Lint compiles the contract and performs static analysis of it looking for
+// common security problems. Permissions from the options (if any) are checked
+// against the contract calls made. `name` and `r` have the same meaning as
+// for CompileWithOptions. Issues are sorted by their position.
+func Lint(name string, r io.Reader, o *Options) ([]LintIssue, error) {
+	ctx, err := getBuildInfo(name, r)
+	if err != nil {
+		return nil, err
+	}
+	ctx.options = o
+	_, di, err := codeGen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{
+		fset:  ctx.config.Fset,
+		main:  ctx.program[0],
+		decls: make(map[*types.Func]*ast.FuncDecl),
+		infos: make(map[*ast.FuncDecl]*types.Info),
+	}
+	packages.Visit(ctx.program, nil, func(pkg *packages.Package) {
+		if isInteropPath(pkg.PkgPath) && !isTokenLibPath(pkg.PkgPath) {
+			return
+		}
+		l.pkgs = append(l.pkgs, pkg)
+		for _, f := range pkg.Syntax {
+			for _, d := range f.Decls {
+				if decl, ok := d.(*ast.FuncDecl); ok && decl.Body != nil {
+					if fn, ok := pkg.TypesInfo.Defs[decl.Name].(*types.Func); ok {
+						l.decls[fn] = decl
+						l.infos[decl] = pkg.TypesInfo
+					}
+				}
+			}
+		}
+	})
+
+	l.checkMethods()
+	l.checkCalls()
+	l.checkStorageKeys()
+	if o != nil {
+		for _, c := range di.UnresolvedCalls {
+			if c.Method == "" {
+				l.dynamicCalls = true
+			}
+		}
+		l.checkPermissions(di.InvokedContracts, o.Permissions)
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		a, b := l.issues[i].Pos, l.issues[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.issues, nil
+}
+
+func (l *linter) report(pos token.Pos, rule string, format string, args ...any) {
+	issue := LintIssue{Rule: rule, Message: fmt.Sprintf(format, args...)}
+	if pos.IsValid() {
+		issue.Pos = l.fset.Position(pos)
+	}
+	for _, i := range l.issues {
+		if i == issue {
+			return
+		}
+	}
+	l.issues = append(l.issues, issue)
+}
+
+// calledFunc returns the function called by call or nil if it's not a call
+// of the declared function (e.g. a type conversion or a function value call).
+func calledFunc(call *ast.CallExpr, info *types.Info) *types.Func {
+	var id *ast.Ident
+	switch fun := unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	case *ast.IndexExpr:
+		return calledFunc(&ast.CallExpr{Fun: fun.X}, info)
+	case *ast.IndexListExpr:
+		return calledFunc(&ast.CallExpr{Fun: fun.X}, info)
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// isInteropFunc checks whether fn is the function `name` from the interop
+// package `pkg` (relative to the interop root).
+func isInteropFunc(fn *types.Func, pkg string, names ...string) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != interopPrefix+"/"+pkg {
+		return false
+	}
+	return containsString(names, fn.Name())
+}
+
+func containsString(ss []string, s string) bool {
+	for i := range ss {
+		if ss[i] == s {
+			return true
+		}
+	}
+	return false
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
+
+// isExternalCall checks whether fn calls another contract which can call
+// back into this one.
+func isExternalCall(fn *types.Func) bool {
+	return isInteropFunc(fn, "contract", "Call") ||
+		isInteropFunc(fn, "native/neo", "Transfer") ||
+		isInteropFunc(fn, "native/gas", "Transfer")
+}
+
+// checkMethods checks public methods for storage modifications without
+// witness checks and after external calls.
+func (l *linter) checkMethods() {
+	for _, f := range l.main.Syntax {
+		for _, d := range f.Decls {
+			decl, ok := d.(*ast.FuncDecl)
+			if !ok || decl.Body == nil || decl.Recv != nil || !decl.Name.IsExported() || isGenericFunc(decl) {
+				continue
+			}
+			st := &methodState{
+				name:     decl.Name.Name,
+				visited:  map[*ast.FuncDecl]bool{decl: true},
+				reported: make(map[string]bool),
+			}
+			l.walk(decl.Body, l.main.TypesInfo, st)
+		}
+	}
+}
+
+// walk processes all calls from n in the order of their execution (arguments
+// before the call itself). Function literals are skipped as their execution
+// point is not known.
+func (l *linter) walk(n ast.Node, info *types.Info, st *methodState) {
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			ast.Inspect(n.Fun, visit)
+			for _, arg := range n.Args {
+				ast.Inspect(arg, visit)
+			}
+			l.processCall(n, info, st)
+			return false
+		}
+		return true
+	}
+	ast.Inspect(n, visit)
+}
+
+func (l *linter) processCall(call *ast.CallExpr, info *types.Info, st *methodState) {
+	fn := calledFunc(call, info)
+	switch {
+	case fn == nil:
+	case isInteropFunc(fn, "runtime", "CheckWitness", "GetCallingScriptHash"):
+		st.authorized = true
+	case isInteropFunc(fn, "storage", "Put", "Delete"):
+		if !st.authorized && !st.reported[LintMissingWitness] {
+			st.reported[LintMissingWitness] = true
+			l.report(call.Pos(), LintMissingWitness,
+				"storage is modified in public method %s without prior witness check", st.name)
+		}
+		if st.externalCall.IsValid() && !st.reported[LintReentrancy] {
+			st.reported[LintReentrancy] = true
+			l.report(call.Pos(), LintReentrancy,
+				"storage is modified in public method %s after the call to another contract at %s",
+				st.name, l.fset.Position(st.externalCall))
+		}
+	case isExternalCall(fn):
+		if !st.externalCall.IsValid() {
+			st.externalCall = call.Pos()
+		}
+	default:
+		// Methods of generic types are instantiated, but declared once.
+		if decl, ok := l.decls[fn.Origin()]; ok && !st.visited[decl] {
+			st.visited[decl] = true
+			l.walk(decl.Body, l.infos[decl], st)
+		}
+	}
+}
+
+// checkCalls looks for contract calls with ignored results.
+func (l *linter) checkCalls() {
+	for _, pkg := range l.pkgs {
+		for _, f := range pkg.Syntax {
+			ast.Inspect(f, func(n ast.Node) bool {
+				var expr ast.Expr
+				switch n := n.(type) {
+				case *ast.ExprStmt:
+					expr = n.X
+				case *ast.AssignStmt:
+					if len(n.Rhs) != 1 || !isBlankAssign(n.Lhs) {
+						return true
+					}
+					expr = n.Rhs[0]
+				default:
+					return true
+				}
+				call, ok := unparen(expr).(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				if fn := calledFunc(call, pkg.TypesInfo); isExternalCall(fn) && !isResultless(call, fn, pkg.TypesInfo) {
+					l.report(call.Pos(), LintUncheckedCall, "result of %s.%s is not checked",
+						fn.Pkg().Name(), fn.Name())
+				}
+				return true
+			})
+		}
+	}
+}
+
+// isResultless checks whether the result of the contract call doesn't need
+// to be checked: the call is made with NoneFlag (so it can't change anything)
+// or the method called is a standard callback that returns nothing.
+func isResultless(call *ast.CallExpr, fn *types.Func, info *types.Info) bool {
+	if !isInteropFunc(fn, "contract", "Call") || len(call.Args) < 3 {
+		return false
+	}
+	if tv := info.Types[call.Args[2]]; tv.Value != nil {
+		if f, ok := constant.Int64Val(tv.Value); ok && f == 0 {
+			return true
+		}
+	}
+	tv := info.Types[call.Args[1]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return false
+	}
+	name := constant.StringVal(tv.Value)
+	for _, s := range []*standard.Standard{standard.Nep11Payable, standard.Nep17Payable} {
+		if m := s.ABI.GetMethod(name, -1); m != nil && m.ReturnType == smartcontract.VoidType {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlankAssign(lhs []ast.Expr) bool {
+	for _, e := range lhs {
+		if id, ok := e.(*ast.Ident); !ok || id.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
+
+// storageKey is a constant storage key or a constant prefix of a key.
+type storageKey struct {
+	key   string
+	exact bool
+	pos   token.Pos
+}
+
+// checkStorageKeys looks for storage keys that can clash with keys from
+// other prefixes.
+func (l *linter) checkStorageKeys() {
+	var keys []storageKey
+	for _, pkg := range l.pkgs {
+		for _, f := range pkg.Syntax {
+			ast.Inspect(f, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) < 2 ||
+					!isInteropFunc(calledFunc(call, pkg.TypesInfo), "storage", "Get", "Put", "Delete") {
+					return true
+				}
+				key, exact, ok := constKeyPrefix(call.Args[1], pkg.TypesInfo)
+				if !ok || len(key) == 0 {
+					return true
+				}
+				for _, k := range keys {
+					if k.key == key && k.exact == exact {
+						return true
+					}
+				}
+				keys = append(keys, storageKey{key: key, exact: exact, pos: call.Args[1].Pos()})
+				return true
+			})
+		}
+	}
+	for i := range keys {
+		for j := 0; j < i; j++ {
+			a, b := keys[j], keys[i]
+			if overlaps(a, b) || overlaps(b, a) {
+				l.report(b.pos, LintStorageCollision, "storage %s %q overlaps with %s %q at %s",
+					keyKind(b), b.key, keyKind(a), a.key, l.fset.Position(a.pos))
+			}
+		}
+	}
+}
+
+// overlaps checks whether keys with prefix a can clash with b.
+func overlaps(a, b storageKey) bool {
+	return !a.exact && strings.HasPrefix(b.key, a.key) && (b.key != a.key || b.exact)
+}
+
+func keyKind(k storageKey) string {
+	if k.exact {
+		return "key"
+	}
+	return "prefix"
+}
+
+// constKeyPrefix returns the constant part of the storage key expression e and
+// whether the key is completely constant.
+func constKeyPrefix(e ast.Expr, info *types.Info) (string, bool, bool) {
+	if tv := info.Types[e]; tv.Value != nil {
+		if tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value), true, true
+		}
+		return "", false, false
+	}
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return constKeyPrefix(e.X, info)
+	case *ast.CompositeLit:
+		buf := make([]byte, 0, len(e.Elts))
+		for _, elt := range e.Elts {
+			v, ok := constant.Uint64Val(info.Types[elt].Value)
+			if !ok || v > 255 {
+				return "", false, false
+			}
+			buf = append(buf, byte(v))
+		}
+		return string(buf), true, true
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false, false
+		}
+		key, _, ok := constKeyPrefix(e.X, info)
+		return key, false, ok
+	case *ast.CallExpr:
+		if len(e.Args) == 0 {
+			return "", false, false
+		}
+		if info.Types[e.Fun].IsType() {
+			return constKeyPrefix(e.Args[0], info)
+		}
+		if b, ok := info.Uses[identOf(e.Fun)].(*types.Builtin); ok && b.Name() == "append" {
+			key, exact, ok := constKeyPrefix(e.Args[0], info)
+			return key, exact && len(e.Args) == 1, ok
+		}
+	}
+	return "", false, false
+}
+
+func identOf(e ast.Expr) *ast.Ident {
+	id, _ := unparen(e).(*ast.Ident)
+	return id
+}
+
+// checkPermissions looks for permissions allowing more than the contract
+// calls. Group permissions can't be checked as group members are not known.
+func (l *linter) checkPermissions(invoked map[util.Uint160][]string, perms []manifest.Permission) {
+	var (
+		unknown  = invoked[util.Uint160{}]
+		resolved = len(unknown) == 0 && !l.dynamicCalls
+	)
+	for _, p := range perms {
+		switch p.Contract.Type {
+		case manifest.PermissionWildcard:
+			if !resolved {
+				continue
+			}
+			if len(invoked) == 0 {
+				l.report(token.NoPos, LintPermissions, "any contract is allowed to be called, but no contract is called")
+				continue
+			}
+			hashes := make([]string, 0, len(invoked))
+			for h := range invoked {
+				hashes = append(hashes, h.StringLE())
+			}
+			sort.Strings(hashes)
+			l.report(token.NoPos, LintPermissions, "any contract is allowed to be called, but only %s are called",
+				strings.Join(hashes, ", "))
+		case manifest.PermissionHash:
+			h := p.Contract.Hash()
+			methods := append(append([]string{}, invoked[h]...), unknown...)
+			switch {
+			case len(methods) == 0 && !l.dynamicCalls:
+				l.report(token.NoPos, LintPermissions, "contract %s is allowed to be called, but it's never called",
+					h.StringLE())
+			case l.dynamicCalls:
+			case p.Methods.IsWildcard():
+				l.report(token.NoPos, LintPermissions, "any method of contract %s is allowed, but only %s are called",
+					h.StringLE(), strings.Join(methods, ", "))
+			default:
+				for _, m := range p.Methods.Value {
+					if !containsString(methods, m) {
+						l.report(token.NoPos, LintPermissions, "method %s of contract %s is allowed, but it's never called",
+							m, h.StringLE())
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package compiler_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func lintRules(t *testing.T, src string, o *compiler.Options) []string {
+	issues, err := compiler.Lint("foo.go", strings.NewReader(src), o)
+	require.NoError(t, err)
+	var rules []string
+	for _, i := range issues {
+		rules = append(rules, i.Rule)
+	}
+	return rules
+}
+
+func TestLintWitness(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		func Set(v int) {
+			storage.Put(storage.GetContext(), "key", v)
+		}`
+		require.Equal(t, []string{compiler.LintMissingWitness}, lintRules(t, src, nil))
+	})
+	t.Run("in helper", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		func Set(v int) { set(v) }
+		func set(v int) { storage.Delete(storage.GetContext(), "key") }`
+		issues, err := compiler.Lint("foo.go", strings.NewReader(src), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(issues))
+		require.Equal(t, compiler.LintMissingWitness, issues[0].Rule)
+		require.Equal(t, 4, issues[0].Pos.Line)
+		require.Contains(t, issues[0].String(), "public method Set")
+	})
+	t.Run("checked", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Set(owner interop.Hash160, v int) {
+			if !runtime.CheckWitness(owner) {
+				panic("not allowed")
+			}
+			storage.Put(storage.GetContext(), "key", v)
+		}
+		func set(v int) { storage.Put(storage.GetContext(), "key", v) }`
+		require.Empty(t, lintRules(t, src, nil))
+	})
+}
+
+func TestLintReentrancy(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func Withdraw(to interop.Hash160, amount int) {
+		if !runtime.CheckWitness(to) {
+			panic("not allowed")
+		}
+		ctx := storage.GetContext()
+		if !gas.Transfer(runtime.GetExecutingScriptHash(), to, amount, nil) {
+			panic("transfer failed")
+		}
+		storage.Put(ctx, to, 0)
+	}`
+	require.Equal(t, []string{compiler.LintReentrancy}, lintRules(t, src, nil))
+}
+
+func TestLintUncheckedCall(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+	)
+	func Main(h interop.Hash160) int {
+		contract.Call(h, "a", contract.ReadOnly)
+		_ = neo.Transfer(h, h, 1, nil)
+		contract.Call(h, "c", contract.NoneFlag)
+		contract.Call(h, "onNEP17Payment", contract.All, h, 1, nil)
+		return contract.Call(h, "b", contract.ReadOnly).(int)
+	}`
+	require.Equal(t, []string{compiler.LintUncheckedCall, compiler.LintUncheckedCall}, lintRules(t, src, nil))
+}
+
+func TestLintTokenLib(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/lib/nep17"
+	)
+	var token = nep17.Token[nep17.NoHooks]{Symbol: "TOK", BalancePrefix: "b", SupplyKey: "s"}
+	func Transfer(from, to interop.Hash160, amount int, data any) bool {
+		return token.Transfer(from, to, amount, data)
+	}
+	func Mint(to interop.Hash160, amount int) { token.Mint(to, amount, nil) }`
+	// Token library code is analyzed like any other code.
+	name, err := filepath.Abs(filepath.Join("testdata", "tokenlib", "contract.go"))
+	require.NoError(t, err)
+	issues, err := compiler.Lint(name, strings.NewReader(src), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(issues))
+	require.Equal(t, compiler.LintMissingWitness, issues[0].Rule)
+	require.Contains(t, issues[0].Message, "public method Mint")
+}
+
+func TestLintStorageCollision(t *testing.T) {
+	src := `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	const (
+		prefixBalance = "b"
+		keyTotal      = "bt"
+	)
+	func Main(id []byte) any {
+		ctx := storage.GetReadOnlyContext()
+		storage.Get(ctx, append([]byte(prefixBalance), id...))
+		storage.Get(ctx, []byte{0x01, 0x02})
+		storage.Get(ctx, append([]byte{0x01}, id...))
+		storage.Get(ctx, "x"+string(id))
+		storage.Get(ctx, "y"+string(id))
+		return storage.Get(ctx, keyTotal)
+	}`
+	issues, err := compiler.Lint("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(issues))
+	for _, i := range issues {
+		require.Equal(t, compiler.LintStorageCollision, i.Rule)
+	}
+	require.Contains(t, issues[0].Message, `prefix "\x01" overlaps with key "\x01\x02"`)
+	require.Contains(t, issues[1].Message, `key "bt" overlaps with prefix "b"`)
+}
+
+func TestLintPermissions(t *testing.T) {
+	const src = `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	)
+	func Main() bool {
+		if contract.Call(interop.Hash160(gas.Hash), "balanceOf", contract.All, runtime.GetExecutingScriptHash()).(int) == 0 {
+			return false
+		}
+		return gas.Transfer(runtime.GetExecutingScriptHash(), runtime.GetExecutingScriptHash(), 1, nil)
+	}`
+	gasHash, err := util.Uint160DecodeStringLE("d2a4cff31913016155e38e474a2c06d08be276cf")
+	require.NoError(t, err)
+	other := util.Uint160{1, 2, 3}
+
+	check := func(t *testing.T, perms []manifest.Permission, issues int) {
+		rules := lintRules(t, src, &compiler.Options{Permissions: perms})
+		require.Equal(t, issues, len(rules), rules)
+		for _, r := range rules {
+			require.Equal(t, compiler.LintPermissions, r)
+		}
+	}
+	exact := *manifest.NewPermission(manifest.PermissionHash, gasHash)
+	exact.Methods.Restrict()
+	exact.Methods.Add("balanceOf")
+	exact.Methods.Add("transfer")
+
+	t.Run("exact", func(t *testing.T) {
+		check(t, []manifest.Permission{exact}, 0)
+	})
+	t.Run("wildcard contract", func(t *testing.T) {
+		check(t, []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}, 1)
+	})
+	t.Run("wildcard methods", func(t *testing.T) {
+		check(t, []manifest.Permission{*manifest.NewPermission(manifest.PermissionHash, gasHash)}, 1)
+	})
+	t.Run("extra method", func(t *testing.T) {
+		p := *manifest.NewPermission(manifest.PermissionHash, gasHash)
+		p.Methods.Restrict()
+		p.Methods.Add("balanceOf")
+		p.Methods.Add("transfer")
+		p.Methods.Add("symbol")
+		check(t, []manifest.Permission{p}, 1)
+	})
+	t.Run("unused contract", func(t *testing.T) {
+		check(t, []manifest.Permission{exact, *manifest.NewPermission(manifest.PermissionHash, other)}, 1)
+	})
+}
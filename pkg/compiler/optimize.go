@@ -0,0 +1,522 @@
+package compiler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Optimization pass (see Options.Optimize) works on the final script. It's
+// decoded into a list of instructions with jumps referencing instructions
+// rather than offsets, then jump threading, peephole rewrites, dead stores and
+// unreachable code removal are performed until nothing changes. Instructions
+// are never reordered, so function ranges and sequence points are remapped
+// using original instruction offsets.
+
+// optInstr is a single instruction of the script being optimized. Jumps are
+// always stored in their long form.
+type optInstr struct {
+	op    opcode.Opcode
+	param []byte
+	// offset is the original instruction offset.
+	offset int
+	// targets contains indices of jump targets (catch and finally blocks for
+	// TRYL, -1 means no block).
+	targets []int
+	entry   bool
+	removed bool
+}
+
+type optimizer struct {
+	ins []*optInstr
+}
+
+// optimize performs the optimization pass over b and corrects debug
+// information accordingly.
+func (c *codegen) optimize(b []byte) ([]byte, error) {
+	entries := []int{0}
+	if c.deployEndOffset >= 0 {
+		entries = append(entries, c.initEndOffset+1)
+	}
+	for _, f := range c.funcs {
+		if f.converted {
+			entries = append(entries, int(f.rng.Start))
+		}
+	}
+	o, err := newOptimizer(b, entries)
+	if err != nil {
+		return nil, err
+	}
+	o.run()
+	res, remap := o.encode()
+
+	end := func(old int) int { return remap(old+1) - 1 }
+	for _, f := range c.funcs {
+		if f.converted {
+			f.rng.Start, f.rng.End = uint16(remap(int(f.rng.Start))), uint16(end(int(f.rng.End)))
+		}
+	}
+	for _, points := range c.sequencePoints {
+		for i := range points {
+			points[i].Opcode = remap(points[i].Opcode)
+		}
+	}
+	if c.initEndOffset > 0 {
+		c.initEndOffset = end(c.initEndOffset)
+	}
+	if c.deployEndOffset >= 0 {
+		c.deployEndOffset = end(c.deployEndOffset)
+	}
+	c.optimized = true
+	return res, nil
+}
+
+func newOptimizer(b []byte, entries []int) (*optimizer, error) {
+	var (
+		o        = new(optimizer)
+		byOffset = make(map[int]int)
+		ctx      = vm.NewContext(b)
+	)
+	for op, param, err := ctx.Next(); ctx.IP() < len(b); op, param, err = ctx.Next() {
+		if err != nil {
+			return nil, err
+		}
+		byOffset[ctx.IP()] = len(o.ins)
+		o.ins = append(o.ins, &optInstr{op: op, param: bytes.Clone(param), offset: ctx.IP()})
+	}
+	index := func(ip, offset int) (int, error) {
+		i, ok := byOffset[ip+offset]
+		if !ok {
+			return 0, fmt.Errorf("invalid jump target at %d: %d", ip, ip+offset)
+		}
+		return i, nil
+	}
+	for _, in := range o.ins {
+		var offsets []int
+		switch in.op {
+		case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE, opcode.CALL, opcode.ENDTRY:
+			offsets = []int{int(int8(in.param[0]))}
+			in.op = toLongForm(in.op)
+		case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+			opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL, opcode.CALLL,
+			opcode.ENDTRYL, opcode.PUSHA:
+			offsets = []int{int(int32(binary.LittleEndian.Uint32(in.param)))}
+		case opcode.TRY:
+			offsets = []int{int(int8(in.param[0])), int(int8(in.param[1]))}
+			in.op = opcode.TRYL
+		case opcode.TRYL:
+			offsets = []int{int(int32(binary.LittleEndian.Uint32(in.param))),
+				int(int32(binary.LittleEndian.Uint32(in.param[4:])))}
+		default:
+			continue
+		}
+		in.param = nil
+		for _, off := range offsets {
+			if off == 0 && in.op == opcode.TRYL {
+				in.targets = append(in.targets, -1)
+				continue
+			}
+			i, err := index(in.offset, off)
+			if err != nil {
+				return nil, err
+			}
+			in.targets = append(in.targets, i)
+		}
+	}
+	for _, e := range entries {
+		i, ok := byOffset[e]
+		if !ok {
+			return nil, fmt.Errorf("invalid entry point: %d", e)
+		}
+		o.ins[i].entry = true
+	}
+	return o, nil
+}
+
+// run performs all optimizations until the script can't be improved further.
+func (o *optimizer) run() {
+	o.removeDeadStores()
+	for changed := true; changed; {
+		changed = o.threadJumps()
+		changed = o.peephole() || changed
+		changed = o.removeUnreachable() || changed
+		o.compact()
+	}
+}
+
+// compact drops removed instructions redirecting jumps to the next remaining
+// ones.
+func (o *optimizer) compact() {
+	var (
+		next = make([]int, len(o.ins)+1)
+		res  = make([]*optInstr, 0, len(o.ins))
+	)
+	next[len(o.ins)] = -1
+	for i := len(o.ins) - 1; i >= 0; i-- {
+		if o.ins[i].removed {
+			next[i] = next[i+1]
+		} else {
+			next[i] = i
+		}
+	}
+	newIndex := make([]int, len(o.ins))
+	for i, in := range o.ins {
+		if in.removed && in.entry && next[i] >= 0 {
+			o.ins[next[i]].entry = true
+		}
+		if !in.removed {
+			newIndex[i] = len(res)
+			res = append(res, in)
+		}
+	}
+	for _, in := range res {
+		for j, t := range in.targets {
+			if t >= 0 {
+				// Jumps to the end of the script are impossible, it
+				// always ends with RET.
+				in.targets[j] = newIndex[next[t]]
+			}
+		}
+	}
+	o.ins = res
+}
+
+// threadJumps redirects jumps targeting unconditional jumps to their final
+// destination and replaces unconditional jumps to RET with RET.
+func (o *optimizer) threadJumps() bool {
+	var changed bool
+	for _, in := range o.ins {
+		if !isOptJump(in.op) {
+			continue
+		}
+		t := in.targets[0]
+		for hops := 0; o.ins[t].op == opcode.JMPL && o.ins[t].targets[0] != t && hops < len(o.ins); hops++ {
+			t = o.ins[t].targets[0]
+		}
+		if t != in.targets[0] {
+			in.targets[0] = t
+			changed = true
+		}
+		if in.op == opcode.JMPL && o.ins[t].op == opcode.RET {
+			in.op, in.targets = opcode.RET, nil
+			changed = true
+		}
+	}
+	return changed
+}
+
+// peephole performs simple rewrites of adjacent instructions.
+func (o *optimizer) peephole() bool {
+	var (
+		changed  bool
+		isTarget = make([]bool, len(o.ins))
+	)
+	for _, in := range o.ins {
+		for _, t := range in.targets {
+			if t >= 0 {
+				isTarget[t] = true
+			}
+		}
+	}
+	nextKept := func(i int) int {
+		for i++; i < len(o.ins) && o.ins[i].removed; i++ {
+		}
+		return i
+	}
+	for i := 0; i < len(o.ins); i++ {
+		in := o.ins[i]
+		j := nextKept(i)
+		// The last instruction is always kept, so that there is
+		// something to jump to.
+		if in.removed || j == len(o.ins) {
+			continue
+		}
+		if in.op == opcode.NOP || in.op == opcode.JMPL && in.targets[0] == j {
+			in.removed, changed = true, true
+			continue
+		}
+		if isTarget[j] || o.ins[j].entry || nextKept(j) == len(o.ins) {
+			continue
+		}
+		next := o.ins[j]
+		switch {
+		case next.op == opcode.DROP && isPushOp(in.op),
+			in.op == opcode.SWAP && next.op == opcode.SWAP:
+			in.removed, next.removed, changed = true, true, true
+		case in.op == opcode.NOT && (next.op == opcode.JMPIFL || next.op == opcode.JMPIFNOTL):
+			in.removed, changed = true, true
+			next.op = negateJmp(next.op)
+		case (in.op == opcode.PUSHT || in.op == opcode.PUSHF) &&
+			(next.op == opcode.JMPIFL || next.op == opcode.JMPIFNOTL):
+			in.removed, changed = true, true
+			if (in.op == opcode.PUSHT) == (next.op == opcode.JMPIFL) {
+				next.op = opcode.JMPL
+			} else {
+				next.removed = true
+			}
+		}
+	}
+	return changed
+}
+
+// removeUnreachable removes instructions that can't be reached from entry
+// points.
+func (o *optimizer) removeUnreachable() bool {
+	var (
+		reached = make([]bool, len(o.ins))
+		queue   []int
+	)
+	for i, in := range o.ins {
+		if in.entry {
+			reached[i] = true
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) != 0 {
+		i := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		in := o.ins[i]
+		next := in.targets
+		switch in.op {
+		case opcode.RET, opcode.THROW, opcode.ABORT, opcode.ABORTMSG, opcode.ENDFINALLY,
+			opcode.JMPL, opcode.ENDTRYL:
+		default:
+			if i+1 < len(o.ins) {
+				next = append([]int{i + 1}, next...)
+			}
+		}
+		for _, t := range next {
+			if t >= 0 && !reached[t] {
+				reached[t] = true
+				queue = append(queue, t)
+			}
+		}
+	}
+	var changed bool
+	for i, in := range o.ins {
+		if !reached[i] && !in.removed {
+			in.removed, changed = true, true
+		}
+	}
+	return changed
+}
+
+// removeDeadStores replaces stores to local and static slots never loaded
+// with DROP. Functions are assumed to be laid out contiguously each starting
+// with INITSLOT (if it has any slots), so locals are tracked between INITSLOT
+// instructions. INITSLOT is dropped for functions using no slots at all.
+func (o *optimizer) removeDeadStores() {
+	var (
+		loadedStatic = make(map[int]bool)
+		start        int
+	)
+	for _, in := range o.ins {
+		if idx, ok := slotIndex(in, opcode.LDSFLD0, opcode.LDSFLD); ok {
+			loadedStatic[idx] = true
+		}
+	}
+	for i := 0; i <= len(o.ins); i++ {
+		if i != len(o.ins) && (i == 0 || o.ins[i].op != opcode.INITSLOT) {
+			continue
+		}
+		o.removeDeadLocals(start, i)
+		start = i
+	}
+	for _, in := range o.ins {
+		if idx, ok := slotIndex(in, opcode.STSFLD0, opcode.STSFLD); ok && !loadedStatic[idx] {
+			in.op, in.param = opcode.DROP, nil
+		}
+	}
+}
+
+// removeDeadLocals removes dead local stores of a function located at
+// [start, end).
+func (o *optimizer) removeDeadLocals(start, end int) {
+	loaded := make(map[int]bool)
+	for _, in := range o.ins[start:end] {
+		if idx, ok := slotIndex(in, opcode.LDLOC0, opcode.LDLOC); ok {
+			loaded[idx] = true
+		}
+	}
+	for _, in := range o.ins[start:end] {
+		idx, ok := slotIndex(in, opcode.STLOC0, opcode.STLOC)
+		if ok && !loaded[idx] {
+			in.op, in.param = opcode.DROP, nil
+		}
+	}
+	if init := o.ins[start]; init.op == opcode.INITSLOT && len(loaded) == 0 && init.param[0] != 0 {
+		if init.param[1] == 0 {
+			init.removed = true
+		} else {
+			init.param[0] = 0
+		}
+	}
+}
+
+// encode returns the resulting script and a function mapping original
+// offsets to the new ones (removed instructions are mapped to the next
+// remaining one).
+func (o *optimizer) encode() ([]byte, func(int) int) {
+	var (
+		short   = make([]bool, len(o.ins))
+		offsets = make([]int, len(o.ins)+1)
+	)
+	for changed := true; changed; {
+		changed = false
+		for i, in := range o.ins {
+			offsets[i+1] = offsets[i] + optInstrSize(in, short[i])
+		}
+		for i, in := range o.ins {
+			if short[i] || in.targets == nil || in.op == opcode.PUSHA {
+				continue
+			}
+			fits := true
+			for _, t := range in.targets {
+				if t >= 0 && !fitsInt8(offsets[t]-offsets[i]) {
+					fits = false
+				}
+			}
+			if fits {
+				short[i], changed = true, true
+			}
+		}
+	}
+
+	buf := make([]byte, 0, offsets[len(o.ins)])
+	for i, in := range o.ins {
+		rel := make([]int, len(in.targets))
+		for j, t := range in.targets {
+			if t >= 0 {
+				rel[j] = offsets[t] - offsets[i]
+			}
+		}
+		switch {
+		case in.op == opcode.TRYL && short[i]:
+			buf = append(buf, byte(opcode.TRY), byte(rel[0]), byte(rel[1]))
+		case in.op == opcode.TRYL:
+			buf = append(buf, byte(opcode.TRYL))
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(rel[0]))
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(rel[1]))
+		case in.targets != nil && short[i]:
+			buf = append(buf, byte(toShortForm(in.op)), byte(rel[0]))
+		case in.targets != nil:
+			buf = append(buf, byte(in.op))
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(rel[0]))
+		default:
+			buf = append(buf, byte(in.op))
+			switch in.op {
+			case opcode.PUSHDATA1:
+				buf = append(buf, byte(len(in.param)))
+			case opcode.PUSHDATA2:
+				buf = binary.LittleEndian.AppendUint16(buf, uint16(len(in.param)))
+			case opcode.PUSHDATA4:
+				buf = binary.LittleEndian.AppendUint32(buf, uint32(len(in.param)))
+			}
+			buf = append(buf, in.param...)
+		}
+	}
+
+	remap := func(old int) int {
+		return offsets[sort.Search(len(o.ins), func(i int) bool { return o.ins[i].offset >= old })]
+	}
+	return buf, remap
+}
+
+func optInstrSize(in *optInstr, short bool) int {
+	switch {
+	case in.op == opcode.TRYL && short:
+		return 3
+	case in.op == opcode.TRYL:
+		return 9
+	case in.targets != nil && short:
+		return 2
+	case in.targets != nil:
+		return 5
+	}
+	size := 1 + len(in.param)
+	switch in.op {
+	case opcode.PUSHDATA1:
+		size++
+	case opcode.PUSHDATA2:
+		size += 2
+	case opcode.PUSHDATA4:
+		size += 4
+	}
+	return size
+}
+
+func fitsInt8(n int) bool {
+	return math.MinInt8 <= n && n <= math.MaxInt8
+}
+
+// isOptJump checks whether op is a jump (in its long form).
+func isOptJump(op opcode.Opcode) bool {
+	switch op {
+	case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+		opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL:
+		return true
+	}
+	return false
+}
+
+// isPushOp checks whether op only pushes a single item without side-effects.
+func isPushOp(op opcode.Opcode) bool {
+	switch {
+	case op <= opcode.PUSHINT256, opcode.PUSHT <= op && op <= opcode.PUSH16,
+		opcode.LDSFLD0 <= op && op <= opcode.LDSFLD, opcode.LDLOC0 <= op && op <= opcode.LDLOC,
+		opcode.LDARG0 <= op && op <= opcode.LDARG:
+		return true
+	}
+	switch op {
+	case opcode.DUP, opcode.OVER:
+		return true
+	}
+	return false
+}
+
+// slotIndex returns the slot index of the instruction if it belongs to the
+// family starting with base (like LDLOC0) with the generic form of generic.
+func slotIndex(in *optInstr, base, generic opcode.Opcode) (int, bool) {
+	switch {
+	case in.op == generic:
+		return int(in.param[0]), true
+	case base <= in.op && in.op < generic:
+		return int(in.op - base), true
+	}
+	return 0, false
+}
+
+func toLongForm(op opcode.Opcode) opcode.Opcode {
+	switch op {
+	case opcode.JMP:
+		return opcode.JMPL
+	case opcode.JMPIF:
+		return opcode.JMPIFL
+	case opcode.JMPIFNOT:
+		return opcode.JMPIFNOTL
+	case opcode.JMPEQ:
+		return opcode.JMPEQL
+	case opcode.JMPNE:
+		return opcode.JMPNEL
+	case opcode.JMPGT:
+		return opcode.JMPGTL
+	case opcode.JMPGE:
+		return opcode.JMPGEL
+	case opcode.JMPLE:
+		return opcode.JMPLEL
+	case opcode.JMPLT:
+		return opcode.JMPLTL
+	case opcode.CALL:
+		return opcode.CALLL
+	case opcode.ENDTRY:
+		return opcode.ENDTRYL
+	default:
+		panic(fmt.Errorf("invalid opcode: %s", op))
+	}
+}
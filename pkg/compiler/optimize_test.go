@@ -0,0 +1,176 @@
+package compiler_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimize(t *testing.T) {
+	tcases := []testCase{
+		{
+			"dead stores and unused locals",
+			`package foo
+			func Main() int {
+				a := 1
+				b := 2
+				_ = b
+				a = 3
+				return a
+			}`,
+			big.NewInt(3),
+		},
+		{
+			"negated conditions",
+			`package foo
+			func Main() int {
+				var n int
+				for i := 0; i < 10; i++ {
+					if !(i%2 == 0) {
+						continue
+					}
+					n += i
+				}
+				return n
+			}`,
+			big.NewInt(20),
+		},
+		{
+			"constant conditions",
+			`package foo
+			const debug = false
+			func Main() int {
+				if debug {
+					return 1
+				}
+				for true {
+					return 2
+				}
+				return 3
+			}`,
+			big.NewInt(2),
+		},
+		{
+			"switch and jump chains",
+			`package foo
+			func f(i int) int {
+				switch i {
+				case 1:
+					return 10
+				case 2:
+					if i > 1 {
+						break
+					}
+					return 20
+				default:
+				}
+				return i
+			}
+			func Main() int { return f(1) + f(2) + f(3) }`,
+			big.NewInt(15),
+		},
+		{
+			"globals and init",
+			`package foo
+			var a, b = 1, 2
+			var unused int
+			func init() { a += b }
+			func Main() int { return a }`,
+			big.NewInt(3),
+		},
+		{
+			"defer and closures",
+			`package foo
+			func Main() (res int) {
+				a := 5
+				defer func() { res += a }()
+				res = 1
+				return
+			}`,
+			big.NewInt(6),
+		},
+		{
+			"large function with long jumps",
+			`package foo
+			func Main() []byte {
+				var res []byte
+				for i := 0; i < 3; i++ {
+					res = append(res, byte(i))
+					res = append(res, []byte("0123456789012345678901234567890123456789")...)
+					res = append(res, []byte("0123456789012345678901234567890123456789")...)
+					res = append(res, []byte("0123456789012345678901234567890123456789")...)
+					res = append(res, []byte("0123456789012345678901234567890123456789")...)
+				}
+				return res[40:42]
+			}`,
+			[]byte("90"),
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			plain, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(tc.src), nil)
+			require.NoError(t, err)
+			b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(tc.src), &compiler.Options{Optimize: true})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(b.Script), len(plain.Script))
+
+			offsets := instructionOffsets(t, b.Script)
+			for _, m := range di.Methods {
+				require.True(t, offsets[int(m.Range.Start)], m.ID)
+				require.True(t, offsets[int(m.Range.End)], m.ID)
+			}
+
+			v := vm.New()
+			invokeMethod(t, testMainIdent, b.Script, v, di)
+			runAndCheck(t, v, tc.result)
+		})
+	}
+}
+
+func TestOptimizeTryCatch(t *testing.T) {
+	src := `package foo
+	var n int
+	func f(fail bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				n += 10
+			}
+		}()
+		if fail {
+			panic("oops")
+		}
+		n += 1
+	}
+	func Main() int {
+		f(false)
+		f(true)
+		return n
+	}`
+	for _, opt := range []bool{false, true} {
+		b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), &compiler.Options{Optimize: opt})
+		require.NoError(t, err)
+
+		v := vm.New()
+		invokeMethod(t, testMainIdent, b.Script, v, di)
+		runAndCheck(t, v, big.NewInt(11))
+	}
+}
+
+// instructionOffsets returns the set of offsets instructions start at.
+func instructionOffsets(t *testing.T, script []byte) map[int]bool {
+	res := make(map[int]bool)
+	ctx := vm.NewContext(script)
+	for {
+		_, _, err := ctx.Next()
+		require.NoError(t, err)
+		if ctx.IP() >= len(script) {
+			break
+		}
+		res[ctx.IP()] = true
+	}
+	return res
+}
@@ -0,0 +1,7 @@
+module github.com/nspcc-dev/neo-go/pkg/compiler/testdata/tokenlib
+
+go 1.20
+
+require github.com/nspcc-dev/neo-go/pkg/interop v0.0.0-20240322141543-1840c057bdd7
+
+replace github.com/nspcc-dev/neo-go/pkg/interop => ../../../interop
@@ -15,13 +15,14 @@ func (c *codegen) typeAndValueOf(e ast.Expr) types.TypeAndValue {
 	}
 
 	if tv, ok := c.typeInfo.Types[e]; ok {
+		tv.Type = c.substType(tv.Type)
 		return tv
 	}
 
 	se, ok := e.(*ast.SelectorExpr)
 	if ok {
 		if tv, ok := c.typeInfo.Selections[se]; ok {
-			return types.TypeAndValue{Type: tv.Type()}
+			return types.TypeAndValue{Type: c.substType(tv.Type())}
 		}
 	}
 	return types.TypeAndValue{}
@@ -30,18 +31,116 @@ func (c *codegen) typeAndValueOf(e ast.Expr) types.TypeAndValue {
 func (c *codegen) typeOf(e ast.Expr) types.Type {
 	for i := len(c.pkgInfoInline) - 1; i >= 0; i-- {
 		if typ := c.pkgInfoInline[i].TypesInfo.TypeOf(e); typ != nil {
-			return typ
+			return c.substType(typ)
 		}
 	}
 	for _, p := range c.packageCache {
 		typ := p.TypesInfo.TypeOf(e)
 		if typ != nil {
-			return typ
+			return c.substType(typ)
 		}
 	}
 	return nil
 }
 
+// substType replaces type parameters of the generic function instance being
+// converted with the actual type arguments.
+func (c *codegen) substType(typ types.Type) types.Type {
+	if typ == nil || c.scope == nil || len(c.scope.typeArgs) == 0 {
+		return typ
+	}
+	return substTypeParams(typ, c.scope.typeArgs)
+}
+
+// substTypeParams returns typ with type parameters replaced according to m.
+func substTypeParams(typ types.Type, m map[*types.TypeParam]types.Type) types.Type {
+	switch t := typ.(type) {
+	case *types.TypeParam:
+		if r, ok := m[t]; ok {
+			return r
+		}
+	case *types.Pointer:
+		if elem := substTypeParams(t.Elem(), m); elem != t.Elem() {
+			return types.NewPointer(elem)
+		}
+	case *types.Slice:
+		if elem := substTypeParams(t.Elem(), m); elem != t.Elem() {
+			return types.NewSlice(elem)
+		}
+	case *types.Array:
+		if elem := substTypeParams(t.Elem(), m); elem != t.Elem() {
+			return types.NewArray(elem, t.Len())
+		}
+	case *types.Map:
+		key, elem := substTypeParams(t.Key(), m), substTypeParams(t.Elem(), m)
+		if key != t.Key() || elem != t.Elem() {
+			return types.NewMap(key, elem)
+		}
+	case *types.Tuple:
+		if vars, ok := substVars(t, m); ok {
+			return types.NewTuple(vars...)
+		}
+	case *types.Signature:
+		params, pok := substVars(t.Params(), m)
+		results, rok := substVars(t.Results(), m)
+		if pok || rok {
+			return types.NewSignatureType(t.Recv(), nil, nil,
+				types.NewTuple(params...), types.NewTuple(results...), t.Variadic())
+		}
+	case *types.Struct:
+		var (
+			changed bool
+			fields  = make([]*types.Var, t.NumFields())
+			tags    = make([]string, t.NumFields())
+		)
+		for i := range fields {
+			f := t.Field(i)
+			ft := substTypeParams(f.Type(), m)
+			changed = changed || ft != f.Type()
+			fields[i] = types.NewField(f.Pos(), f.Pkg(), f.Name(), ft, f.Embedded())
+			tags[i] = t.Tag(i)
+		}
+		if changed {
+			return types.NewStruct(fields, tags)
+		}
+	case *types.Named:
+		targs := t.TypeArgs()
+		if targs.Len() == 0 {
+			return t
+		}
+		var (
+			changed bool
+			args    = make([]types.Type, targs.Len())
+		)
+		for i := range args {
+			args[i] = substTypeParams(targs.At(i), m)
+			changed = changed || args[i] != targs.At(i)
+		}
+		if changed {
+			if inst, err := types.Instantiate(nil, t.Origin(), args, false); err == nil {
+				return inst
+			}
+		}
+	}
+	return typ
+}
+
+// substVars substitutes type parameters in the tuple variables types, the
+// second value is true if any of them was changed.
+func substVars(t *types.Tuple, m map[*types.TypeParam]types.Type) ([]*types.Var, bool) {
+	var (
+		changed bool
+		vars    = make([]*types.Var, t.Len())
+	)
+	for i := range vars {
+		v := t.At(i)
+		vt := substTypeParams(v.Type(), m)
+		changed = changed || vt != v.Type()
+		vars[i] = types.NewParam(v.Pos(), v.Pkg(), v.Name(), vt)
+	}
+	return vars, changed
+}
+
 func isBasicTypeOfKind(typ types.Type, ks ...types.BasicKind) bool {
 	if t, ok := typ.Underlying().(*types.Basic); ok {
 		k := t.Kind()
@@ -86,6 +185,43 @@ func isByteSlice(typ types.Type) bool {
 	return ok && isByte(t.Elem())
 }
 
+// assertTypes returns the list of stack item types that can represent a value
+// of the given type, it's used for type assertion checks. An empty list is
+// returned for types that can't be checked.
+func assertTypes(typ types.Type) []stackitem.Type {
+	if t, ok := typ.(*types.Pointer); ok {
+		typ = t.Elem()
+	}
+	if isInteropPath(typ.String()) && !canConvert(typ.String()) {
+		switch typ.String() {
+		case interopPrefix + "/iterator.Iterator", interopPrefix + "/storage.Context":
+			return []stackitem.Type{stackitem.InteropT}
+		}
+	}
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		info := t.Info()
+		switch {
+		case info&types.IsInteger != 0:
+			return []stackitem.Type{stackitem.IntegerT}
+		case info&types.IsBoolean != 0:
+			return []stackitem.Type{stackitem.BooleanT}
+		case info&types.IsString != 0:
+			return []stackitem.Type{stackitem.ByteArrayT}
+		}
+	case *types.Map:
+		return []stackitem.Type{stackitem.MapT}
+	case *types.Struct:
+		return []stackitem.Type{stackitem.StructT, stackitem.ArrayT}
+	case *types.Slice:
+		if isByte(t.Elem()) {
+			return []stackitem.Type{stackitem.ByteArrayT, stackitem.BufferT}
+		}
+		return []stackitem.Type{stackitem.ArrayT, stackitem.StructT}
+	}
+	return nil
+}
+
 func toNeoType(typ types.Type) stackitem.Type {
 	if typ == nil {
 		return stackitem.AnyT
@@ -20,6 +20,9 @@ type varContext struct {
 type varInfo struct {
 	refType varType
 	index   int
+	// boxed is set for variables captured by closures, the slot contains
+	// a single-element array holding the value then.
+	boxed bool
 	// ctx is set for inline arguments and contains
 	// context for expression traversal.
 	ctx *varContext
@@ -89,6 +92,17 @@ func (c *varScope) newVariable(t varType, name string) int {
 	return n
 }
 
+// newBoxedLocal creates a new local variable holding a box in the current
+// scope.
+func (c *varScope) newBoxedLocal(name string) int {
+	i := c.newLocal(name)
+	m := c.locals[len(c.locals)-1]
+	vi := m[name]
+	vi.boxed = true
+	m[name] = vi
+	return i
+}
+
 // newLocal creates a new local variable in the current scope.
 func (c *varScope) newLocal(name string) int {
 	idx := len(c.locals) - 1
@@ -4,4 +4,8 @@ package config
 type Wallet struct {
 	Path     string `yaml:"Path"`
 	Password string `yaml:"Password"`
+	// RemoteSigner is an endpoint of the remote signing service (see
+	// wallet/remotesigner package) to be used instead of decrypting wallet
+	// keys. It's only supported by CLI commands.
+	RemoteSigner string `yaml:"RemoteSigner,omitempty"`
 }
@@ -1449,6 +1449,30 @@ func (bc *Blockchain) Close() {
 	_ = bc.log.Sync()
 }
 
+// CopyStorage copies all the chain data (including the changes that are not
+// yet persisted) into the given Store. No blocks are added while copying, so
+// the copy is consistent. It's mostly useful for tests that need to snapshot
+// the chain state: a new Blockchain instance created over the copy continues
+// from the same state.
+func (bc *Blockchain) CopyStorage(dst storage.Store) error {
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+
+	var puts, stores = make(map[string][]byte), make(map[string][]byte)
+	for p := 0; p <= math.MaxUint8; p++ {
+		var m = puts
+		switch storage.KeyPrefix(p) {
+		case storage.STStorage, storage.STTempStorage:
+			m = stores
+		}
+		bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(p)}}, func(k, v []byte) bool {
+			m[string(k)] = bytes.Clone(v)
+			return true
+		})
+	}
+	return dst.PutChangeSet(puts, stores)
+}
+
 // AddBlock accepts successive block for the Blockchain, verifies it and
 // stores internally. Eventually it will be persisted to the backing storage.
 func (bc *Blockchain) AddBlock(block *block.Block) error {
@@ -2801,40 +2825,95 @@ func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *
 
 // GetTestHistoricVM returns an interop context with VM set up for a test run.
 func (bc *Blockchain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32) (*interop.Context, error) {
-	if bc.config.Ledger.KeepOnlyLatestState {
-		return nil, errors.New("only latest state is supported")
-	}
 	b, err := bc.getFakeNextBlock(nextBlockHeight)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create fake block for height %d: %w", nextBlockHeight, err)
 	}
+	dTrie, err := bc.getHistoricDAO(b.Index)
+	if err != nil {
+		return nil, err
+	}
+	systemInterop := bc.newInteropContext(t, dTrie, b, tx)
+	_ = systemInterop.SpawnVM() // All the other code suppose that the VM is ready.
+	return systemInterop, nil
+}
+
+// GetTracedTxVM returns an interop context with VM set up for the re-execution
+// of the transaction with the specified hash. The state used is the one the
+// transaction was originally executed with: the state of the previous block
+// with OnPersist and all the preceding transactions of the same block applied.
+// The transaction script is not loaded into the VM.
+func (bc *Blockchain) GetTracedTxVM(h util.Uint256) (*interop.Context, error) {
+	tx, height, err := bc.dao.GetTransaction(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transaction: %w", err)
+	}
+	b, err := bc.GetBlock(bc.GetHeaderHash(height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve block %d: %w", height, err)
+	}
+	dTrie, err := bc.getHistoricDAO(b.Index)
+	if err != nil {
+		return nil, err
+	}
+	_, v, err := bc.runPersist(bc.contracts.GetPersistScript(), b, dTrie, trigger.OnPersist, nil)
+	if err != nil {
+		return nil, fmt.Errorf("onPersist failed: %w", err)
+	}
+	for _, prev := range b.Transactions {
+		if prev.Hash().Equals(h) {
+			break
+		}
+		systemInterop := bc.newInteropContext(trigger.Application, dTrie, b, prev)
+		systemInterop.ReuseVM(v)
+		v.LoadScriptWithFlags(prev.Script, callflag.All)
+		v.GasLimit = prev.SystemFee
+		_ = systemInterop.Exec()
+		if !v.HasFailed() {
+			_, err = systemInterop.DAO.Persist()
+			if err != nil {
+				return nil, fmt.Errorf("failed to persist invocation results of %s: %w", prev.Hash().StringLE(), err)
+			}
+		}
+	}
+	systemInterop := bc.newInteropContext(trigger.Application, dTrie, b, tx)
+	_ = systemInterop.SpawnVM() // All the other code suppose that the VM is ready.
+	systemInterop.VM.GasLimit = tx.SystemFee
+	return systemInterop, nil
+}
+
+// getHistoricDAO returns an MPT-backed DAO containing the state of the block
+// preceding the one with the specified index. Native contract caches of the
+// resulting DAO are initialized.
+func (bc *Blockchain) getHistoricDAO(index uint32) (*dao.Simple, error) {
+	if bc.config.Ledger.KeepOnlyLatestState {
+		return nil, errors.New("only latest state is supported")
+	}
 	var mode = mpt.ModeAll
 	if bc.config.Ledger.RemoveUntraceableBlocks {
-		if b.Index < bc.BlockHeight()-bc.config.MaxTraceableBlocks {
-			return nil, fmt.Errorf("state for height %d is outdated and removed from the storage", b.Index)
+		if index < bc.BlockHeight()-bc.config.MaxTraceableBlocks {
+			return nil, fmt.Errorf("state for height %d is outdated and removed from the storage", index)
 		}
 		mode |= mpt.ModeGCFlag
 	}
-	if b.Index < 1 || b.Index > bc.BlockHeight()+1 {
-		return nil, fmt.Errorf("unsupported historic chain's height: requested state for %d, chain height %d", b.Index, bc.blockHeight)
+	if index < 1 || index > bc.BlockHeight()+1 {
+		return nil, fmt.Errorf("unsupported historic chain's height: requested state for %d, chain height %d", index, bc.blockHeight)
 	}
 	// Assuming that block N-th is processing during historic call, the historic invocation should be based on the storage state of height N-1.
-	sr, err := bc.stateRoot.GetStateRoot(b.Index - 1)
+	sr, err := bc.stateRoot.GetStateRoot(index - 1)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve stateroot for height %d: %w", b.Index, err)
+		return nil, fmt.Errorf("failed to retrieve stateroot for height %d: %w", index, err)
 	}
 	s := mpt.NewTrieStore(sr.Root, mode, storage.NewPrivateMemCachedStore(bc.dao.Store))
 	dTrie := dao.NewSimple(s, bc.config.StateRootInHeader)
 	dTrie.Version = bc.dao.Version
 	// Initialize native cache before passing DAO to interop context constructor, because
 	// the constructor will call BaseExecFee/StoragePrice policy methods on the passed DAO.
-	err = bc.initializeNativeCache(b.Index, dTrie)
+	err = bc.initializeNativeCache(index, dTrie)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize native cache backed by historic DAO: %w", err)
 	}
-	systemInterop := bc.newInteropContext(t, dTrie, b, tx)
-	_ = systemInterop.SpawnVM() // All the other code suppose that the VM is ready.
-	return systemInterop, nil
+	return dTrie, nil
 }
 
 // getFakeNextBlock returns fake block with the specified index and pre-filled Timestamp field.
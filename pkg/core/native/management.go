@@ -689,6 +689,26 @@ func PutContractState(d *dao.Simple, cs *state.Contract) error {
 	return putContractState(d, cs, true)
 }
 
+// ImportContractState saves given contract state into given DAO bypassing
+// deployment. Contract ID and hash are preserved, the next available contract
+// ID is adjusted to not collide with it. Management cache is not updated, so
+// it's only suitable for DAOs used to initialize a new Blockchain instance
+// (like when contracts are cloned from another network in tests).
+func ImportContractState(d *dao.Simple, cs *state.Contract) error {
+	if err := putContractState(d, cs, false); err != nil {
+		return err
+	}
+	d.PutStorageItem(ManagementContractID, putHashKey(make([]byte, 5), cs.ID), cs.Hash.BytesBE())
+	si := d.GetStorageItem(ManagementContractID, keyNextAvailableID)
+	if si == nil {
+		return errors.New("nextAvailableID is not initialized")
+	}
+	if bigint.FromBytes(si).Int64() <= int64(cs.ID) {
+		d.PutBigInt(ManagementContractID, keyNextAvailableID, big.NewInt(int64(cs.ID)+1))
+	}
+	return nil
+}
+
 // putContractState is an internal PutContractState representation.
 func putContractState(d *dao.Simple, cs *state.Contract, updateCache bool) error {
 	key := MakeContractKey(cs.Hash)
@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/nspcc-dev/neo-go/pkg/util/slice"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/scrypt"
 	"golang.org/x/text/unicode/norm"
 )
@@ -41,15 +42,70 @@ func NEP2ScryptParams() ScryptParams {
 	}
 }
 
+// Argon2idParams is a json-serializable container for Argon2id KDF parameters.
+// Argon2id can be used instead of scrypt in NEP-2 key derivation as a NeoGo
+// extension of the standard, keys encrypted this way can't be decrypted by
+// other NEP-2 implementations.
+type Argon2idParams struct {
+	// Time is the number of passes over the memory.
+	Time uint32 `json:"time"`
+	// Memory is the amount of memory used in KiB.
+	Memory uint32 `json:"memory"`
+	// Threads is the number of threads (lanes) used.
+	Threads uint8 `json:"threads"`
+}
+
+// DefaultArgon2idParams returns Argon2id parameters recommended by RFC 9106
+// for memory-constrained environments (3 passes, 64 MiB, 4 lanes).
+func DefaultArgon2idParams() Argon2idParams {
+	return Argon2idParams{
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+}
+
+// scryptKDF returns NEP-2 key derivation function using scrypt with the given
+// parameters.
+func scryptKDF(params ScryptParams) func(phrase, salt []byte) ([]byte, error) {
+	return func(phrase, salt []byte) ([]byte, error) {
+		return scrypt.Key(phrase, salt, params.N, params.R, params.P, keyLen)
+	}
+}
+
+// argon2idKDF returns NEP-2 key derivation function using Argon2id with the
+// given parameters.
+func argon2idKDF(params Argon2idParams) func(phrase, salt []byte) ([]byte, error) {
+	return func(phrase, salt []byte) ([]byte, error) {
+		if params.Time == 0 || params.Threads == 0 {
+			return nil, errors.New("invalid Argon2id parameters: time and threads must be positive")
+		}
+		if params.Memory < 8*uint32(params.Threads) {
+			return nil, fmt.Errorf("invalid Argon2id parameters: memory must be at least %d KiB", 8*uint32(params.Threads))
+		}
+		return argon2.IDKey(phrase, salt, params.Time, params.Memory, params.Threads, keyLen), nil
+	}
+}
+
 // NEP2Encrypt encrypts a the PrivateKey using the given passphrase
 // under the NEP-2 standard.
 func NEP2Encrypt(priv *PrivateKey, passphrase string, params ScryptParams) (s string, err error) {
+	return nep2Encrypt(priv, passphrase, scryptKDF(params))
+}
+
+// NEP2EncryptArgon2id encrypts the PrivateKey using the given passphrase in
+// the NEP-2 format with Argon2id used instead of scrypt for key derivation.
+func NEP2EncryptArgon2id(priv *PrivateKey, passphrase string, params Argon2idParams) (string, error) {
+	return nep2Encrypt(priv, passphrase, argon2idKDF(params))
+}
+
+func nep2Encrypt(priv *PrivateKey, passphrase string, kdf func(phrase, salt []byte) ([]byte, error)) (s string, err error) {
 	address := priv.Address()
 
 	addrHash := hash.Checksum([]byte(address))
 	// Normalize the passphrase according to the NFC standard.
 	phraseNorm := norm.NFC.Bytes([]byte(passphrase))
-	derivedKey, err := scrypt.Key(phraseNorm, addrHash, params.N, params.R, params.P, keyLen)
+	derivedKey, err := kdf(phraseNorm, addrHash)
 	if err != nil {
 		return s, err
 	}
@@ -84,6 +140,16 @@ func NEP2Encrypt(priv *PrivateKey, passphrase string, params ScryptParams) (s st
 // NEP2Decrypt decrypts an encrypted key using the given passphrase
 // under the NEP-2 standard.
 func NEP2Decrypt(key, passphrase string, params ScryptParams) (*PrivateKey, error) {
+	return nep2Decrypt(key, passphrase, scryptKDF(params))
+}
+
+// NEP2DecryptArgon2id decrypts a key encrypted with NEP2EncryptArgon2id using
+// the given passphrase.
+func NEP2DecryptArgon2id(key, passphrase string, params Argon2idParams) (*PrivateKey, error) {
+	return nep2Decrypt(key, passphrase, argon2idKDF(params))
+}
+
+func nep2Decrypt(key, passphrase string, kdf func(phrase, salt []byte) ([]byte, error)) (*PrivateKey, error) {
 	b, err := base58.CheckDecode(key)
 	if err != nil {
 		return nil, err
@@ -95,7 +161,7 @@ func NEP2Decrypt(key, passphrase string, params ScryptParams) (*PrivateKey, erro
 	addrHash := b[3:7]
 	// Normalize the passphrase according to the NFC standard.
 	phraseNorm := norm.NFC.Bytes([]byte(passphrase))
-	derivedKey, err := scrypt.Key(phraseNorm, addrHash, params.N, params.R, params.P, keyLen)
+	derivedKey, err := kdf(phraseNorm, addrHash)
 	if err != nil {
 		return nil, err
 	}
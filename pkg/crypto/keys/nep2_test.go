@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,6 +58,40 @@ func TestNEP2DecryptErrors(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestNEP2Argon2id(t *testing.T) {
+	params := Argon2idParams{Time: 1, Memory: 64, Threads: 1}
+	privKey, err := NewPrivateKey()
+	assert.NoError(t, err)
+
+	encrypted, err := NEP2EncryptArgon2id(privKey, "qwerty", params)
+	assert.NoError(t, err)
+	assert.NoError(t, validateNEP2Format(mustCheckDecode(t, encrypted)))
+
+	decrypted, err := NEP2DecryptArgon2id(encrypted, "qwerty", params)
+	assert.NoError(t, err)
+	assert.Equal(t, privKey.Bytes(), decrypted.Bytes())
+
+	_, err = NEP2DecryptArgon2id(encrypted, "qwertz", params)
+	assert.Error(t, err)
+	_, err = NEP2DecryptArgon2id(encrypted, "qwerty", Argon2idParams{Time: 2, Memory: 64, Threads: 1})
+	assert.Error(t, err)
+	_, err = NEP2Decrypt(encrypted, "qwerty", ScryptParams{N: 2, R: 1, P: 1})
+	assert.Error(t, err)
+
+	_, err = NEP2EncryptArgon2id(privKey, "qwerty", Argon2idParams{Time: 0, Memory: 64, Threads: 1})
+	assert.Error(t, err)
+	_, err = NEP2EncryptArgon2id(privKey, "qwerty", Argon2idParams{Time: 1, Memory: 64, Threads: 0})
+	assert.Error(t, err)
+	_, err = NEP2EncryptArgon2id(privKey, "qwerty", Argon2idParams{Time: 1, Memory: 7, Threads: 1})
+	assert.Error(t, err)
+}
+
+func mustCheckDecode(t *testing.T, s string) []byte {
+	b, err := base58.CheckDecode(s)
+	assert.NoError(t, err)
+	return b
+}
+
 func TestValidateNEP2Format(t *testing.T) {
 	// Wrong length.
 	s := []byte("gobbledygook")
@@ -0,0 +1,17 @@
+/*
+Package shamir implements Shamir's secret sharing over GF(2^8).
+
+A secret is split into N shares, any M of which (M being the threshold) are
+enough to restore it. Every byte of the secret is shared independently using
+a random polynomial of degree M-1, so shares have the same size as the
+secret. Shares also carry the threshold and a short secret checksum (the
+first 4 bytes of its SHA-256 hash) which allows to detect mixing shares of
+different secrets and corrupted data on restoration. They can be converted
+to/from printable Base58Check strings.
+
+Fewer than M shares reveal nothing about the secret data except for its
+length and the checksum. The checksum is the same in every share and allows
+to check guesses of the secret, so low-entropy secrets (like passwords) can
+be brute-forced given a single share. Use it for random secrets (like keys).
+*/
+package shamir
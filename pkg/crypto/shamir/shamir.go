@@ -0,0 +1,217 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/base58"
+)
+
+const (
+	// MaxShares is the maximum number of shares a secret can be split into.
+	MaxShares = 255
+	// checksumLen is the length of secret checksum stored in shares.
+	checksumLen = 4
+	// headerLen is the length of share header (checksum, threshold, index).
+	headerLen = checksumLen + 2
+)
+
+// Share is a single part of the shared secret.
+type Share struct {
+	// Checksum is a secret checksum, it's the same for all shares of
+	// the secret and is checked after restoration.
+	Checksum [checksumLen]byte
+	// Threshold is the number of shares needed to restore the secret.
+	Threshold byte
+	// Index is a non-zero unique share index (an x coordinate).
+	Index byte
+	// Data contains share values for every secret byte.
+	Data []byte
+}
+
+// exp and log are GF(2^8) (with 0x11b reduction polynomial) exponent and
+// logarithm tables for generator 3.
+var (
+	exp [510]byte
+	log [256]byte
+)
+
+func init() {
+	var x byte = 1
+	for i := 0; i < 255; i++ {
+		exp[i] = x
+		exp[i+255] = x
+		log[x] = byte(i)
+		// Multiply by 3 (x*2 ^ x).
+		x2 := x << 1
+		if x&0x80 != 0 {
+			x2 ^= 0x1b
+		}
+		x ^= x2
+	}
+}
+
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return exp[int(log[a])+int(log[b])]
+}
+
+func div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return exp[int(log[a])+255-int(log[b])]
+}
+
+func checksum(secret []byte) [checksumLen]byte {
+	var res [checksumLen]byte
+	h := sha256.Sum256(secret)
+	copy(res[:], h[:])
+	return res
+}
+
+// Split splits the secret into n shares any threshold of which are enough to
+// restore it. The threshold must be at least 2 and n must not be less than
+// the threshold and not more than MaxShares.
+func Split(secret []byte, n, threshold int) ([]*Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("empty secret")
+	}
+	if threshold < 2 {
+		return nil, errors.New("threshold must be at least 2")
+	}
+	if n < threshold || n > MaxShares {
+		return nil, fmt.Errorf("number of shares must be in [%d, %d] range", threshold, MaxShares)
+	}
+	var (
+		sum    = checksum(secret)
+		shares = make([]*Share, n)
+		coeffs = make([]byte, threshold-1)
+	)
+	for i := range shares {
+		shares[i] = &Share{
+			Checksum:  sum,
+			Threshold: byte(threshold),
+			Index:     byte(i + 1),
+			Data:      make([]byte, len(secret)),
+		}
+	}
+	for j, b := range secret {
+		if _, err := rand.Read(coeffs); err != nil {
+			return nil, fmt.Errorf("can't generate polynomial: %w", err)
+		}
+		for _, s := range shares {
+			// Horner's method, free coefficient is the secret byte.
+			var y byte
+			for k := len(coeffs) - 1; k >= 0; k-- {
+				y = mul(y^coeffs[k], s.Index)
+			}
+			s.Data[j] = y ^ b
+		}
+	}
+	for i := range coeffs {
+		coeffs[i] = 0
+	}
+	return shares, nil
+}
+
+// Combine restores the secret from the given shares. At least threshold
+// distinct shares of the same secret must be provided, extra ones are
+// ignored. The result is checked against the checksum stored in shares.
+func Combine(shares []*Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares")
+	}
+	var (
+		first = shares[0]
+		used  = make([]*Share, 0, first.Threshold)
+		seen  = make(map[byte]bool)
+	)
+	for _, s := range shares {
+		if s.Checksum != first.Checksum || s.Threshold != first.Threshold {
+			return nil, errors.New("shares belong to different secrets")
+		}
+		if len(s.Data) != len(first.Data) {
+			return nil, errors.New("shares have different lengths")
+		}
+		if s.Index == 0 {
+			return nil, errors.New("invalid share index")
+		}
+		if seen[s.Index] {
+			continue
+		}
+		seen[s.Index] = true
+		if len(used) < int(first.Threshold) {
+			used = append(used, s)
+		}
+	}
+	if len(used) < int(first.Threshold) || first.Threshold < 2 {
+		return nil, fmt.Errorf("not enough shares: %d out of %d", len(used), first.Threshold)
+	}
+
+	// Lagrange basis polynomials evaluated at 0.
+	basis := make([]byte, len(used))
+	for i, si := range used {
+		var l byte = 1
+		for j, sj := range used {
+			if i != j {
+				l = mul(l, div(sj.Index, sj.Index^si.Index))
+			}
+		}
+		basis[i] = l
+	}
+	secret := make([]byte, len(first.Data))
+	for k := range secret {
+		for i, s := range used {
+			secret[k] ^= mul(basis[i], s.Data[k])
+		}
+	}
+	if checksum(secret) != first.Checksum {
+		return nil, errors.New("checksum mismatch, invalid or corrupted shares")
+	}
+	return secret, nil
+}
+
+// Bytes returns a binary representation of the share.
+func (s *Share) Bytes() []byte {
+	b := make([]byte, 0, headerLen+len(s.Data))
+	b = append(b, s.Checksum[:]...)
+	b = append(b, s.Threshold, s.Index)
+	return append(b, s.Data...)
+}
+
+// NewShareFromBytes decodes the share from its binary representation.
+func NewShareFromBytes(b []byte) (*Share, error) {
+	if len(b) <= headerLen {
+		return nil, errors.New("share is too short")
+	}
+	s := &Share{
+		Threshold: b[checksumLen],
+		Index:     b[checksumLen+1],
+		Data:      bytes.Clone(b[headerLen:]),
+	}
+	copy(s.Checksum[:], b)
+	if s.Threshold < 2 || s.Index == 0 {
+		return nil, errors.New("invalid share header")
+	}
+	return s, nil
+}
+
+// String returns a printable Base58Check representation of the share.
+func (s *Share) String() string {
+	return base58.CheckEncode(s.Bytes())
+}
+
+// NewShareFromString decodes the share from its Base58Check representation.
+func NewShareFromString(str string) (*Share, error) {
+	b, err := base58.CheckDecode(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid share: %w", err)
+	}
+	return NewShareFromBytes(b)
+}
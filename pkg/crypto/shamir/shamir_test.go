@@ -0,0 +1,101 @@
+package shamir
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGF(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			require.Equal(t, byte(a), div(mul(byte(a), byte(b)), byte(b)))
+		}
+	}
+	require.Equal(t, byte(0), mul(0, 5))
+	require.Equal(t, byte(0), div(0, 5))
+	require.Equal(t, byte(0xc1), mul(0x57, 0x83)) // FIPS-197 example.
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("some very secret data")
+
+	t.Run("bad parameters", func(t *testing.T) {
+		_, err := Split(nil, 3, 2)
+		require.Error(t, err)
+		_, err = Split(secret, 3, 1)
+		require.Error(t, err)
+		_, err = Split(secret, 2, 3)
+		require.Error(t, err)
+		_, err = Split(secret, MaxShares+1, 3)
+		require.Error(t, err)
+	})
+
+	shares, err := Split(secret, 5, 3)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(shares))
+	for i, s := range shares {
+		require.Equal(t, byte(i+1), s.Index)
+		require.Equal(t, byte(3), s.Threshold)
+		require.Equal(t, len(secret), len(s.Data))
+		require.NotEqual(t, secret, s.Data)
+	}
+
+	t.Run("any threshold shares", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				for k := j + 1; k < 5; k++ {
+					res, err := Combine([]*Share{shares[k], shares[i], shares[j]})
+					require.NoError(t, err)
+					require.Equal(t, secret, res)
+				}
+			}
+		}
+	})
+	t.Run("all shares", func(t *testing.T) {
+		res, err := Combine(shares)
+		require.NoError(t, err)
+		require.Equal(t, secret, res)
+	})
+	t.Run("not enough shares", func(t *testing.T) {
+		_, err := Combine(nil)
+		require.Error(t, err)
+		_, err = Combine(shares[:2])
+		require.Error(t, err)
+		_, err = Combine([]*Share{shares[0], shares[1], shares[1]})
+		require.Error(t, err)
+	})
+	t.Run("different secrets", func(t *testing.T) {
+		other, err := Split([]byte("other secret data...."), 5, 3)
+		require.NoError(t, err)
+		_, err = Combine([]*Share{shares[0], shares[1], other[2]})
+		require.Error(t, err)
+	})
+	t.Run("corrupted share", func(t *testing.T) {
+		s := *shares[2]
+		s.Data = append([]byte{}, s.Data...)
+		s.Data[0] ^= 1
+		_, err := Combine([]*Share{shares[0], shares[1], &s})
+		require.Error(t, err)
+	})
+}
+
+func TestShareEncoding(t *testing.T) {
+	shares, err := Split([]byte{1, 2, 3}, 2, 2)
+	require.NoError(t, err)
+
+	for _, s := range shares {
+		actual, err := NewShareFromString(s.String())
+		require.NoError(t, err)
+		require.Equal(t, s, actual)
+	}
+
+	_, err = NewShareFromString("not a share")
+	require.Error(t, err)
+	_, err = NewShareFromBytes([]byte{1, 2, 3, 4, 2, 1})
+	require.Error(t, err)
+	_, err = NewShareFromBytes([]byte{1, 2, 3, 4, 1, 1, 0})
+	require.Error(t, err)
+	_, err = NewShareFromBytes([]byte{1, 2, 3, 4, 2, 0, 0})
+	require.Error(t, err)
+}
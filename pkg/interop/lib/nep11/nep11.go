@@ -0,0 +1,247 @@
+/*
+Package nep11 contains reusable non-divisible NEP-11 token implementation.
+Token stores balances, account tokens, token owners and total supply using
+configurable storage prefixes and implements all of the standard logic
+(checks, Transfer event, onNEP11Payment callback), so contract only has to
+export methods calling it:
+
+	var token = nep11.Token[nep11.NoHooks]{
+		Symbol:        "NFT",
+		SupplyKey:     "s",
+		BalancePrefix: "b",
+		AccountPrefix: "a",
+		TokenPrefix:   "t",
+	}
+
+	func Symbol() string                                { return token.Symbol }
+	func Decimals() int                                 { return 0 }
+	func TotalSupply() int                              { return token.TotalSupply() }
+	func BalanceOf(owner interop.Hash160) int           { return token.BalanceOf(owner) }
+	func TokensOf(owner interop.Hash160) iterator.Iterator { return token.TokensOf(owner) }
+	func OwnerOf(tokenId []byte) interop.Hash160        { return token.OwnerOf(tokenId) }
+	func Transfer(to interop.Hash160, tokenId []byte, data any) bool {
+		return token.Transfer(to, tokenId, data)
+	}
+
+Minting and burning are not a part of the standard, so it's up to contract to
+export methods for them (with appropriate access checks). Additional checks
+for transfers, minting and burning can be implemented via Hooks.
+*/
+package nep11
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// Hooks contains additional token-specific checks performed by Token before
+// changing ownership. Every check is done after the standard ones (like
+// witness and token existence checks) succeed.
+type Hooks interface {
+	// CheckTransfer returns false if transfer is not allowed, Transfer
+	// returns false then.
+	CheckTransfer(from, to interop.Hash160, tokenID []byte, data any) bool
+	// CheckMint returns false if minting is not allowed, Mint panics then.
+	CheckMint(to interop.Hash160, tokenID []byte) bool
+	// CheckBurn returns false if burning is not allowed, Burn panics then.
+	CheckBurn(owner interop.Hash160, tokenID []byte) bool
+}
+
+// NoHooks is a Hooks implementation allowing everything.
+type NoHooks struct{}
+
+// Token is a non-divisible NEP-11 token using the given Hooks for additional
+// checks. All prefixes must be distinct and not be prefixes of each other.
+type Token[H Hooks] struct {
+	// Symbol is a token symbol.
+	Symbol string
+	// SupplyKey is a storage key for the total supply value.
+	SupplyKey string
+	// BalancePrefix is a storage key prefix for account balances, account
+	// hash is appended to it.
+	BalancePrefix string
+	// AccountPrefix is a storage key prefix for account tokens, account hash
+	// and token ID are appended to it.
+	AccountPrefix string
+	// TokenPrefix is a storage key prefix for token owners, token ID is
+	// appended to it.
+	TokenPrefix string
+	// Hooks contains additional token checks.
+	Hooks H
+}
+
+// CheckTransfer implements Hooks interface allowing any transfer.
+func (NoHooks) CheckTransfer(from, to interop.Hash160, tokenID []byte, data any) bool {
+	return true
+}
+
+// CheckMint implements Hooks interface allowing any minting.
+func (NoHooks) CheckMint(to interop.Hash160, tokenID []byte) bool {
+	return true
+}
+
+// CheckBurn implements Hooks interface allowing any burning.
+func (NoHooks) CheckBurn(owner interop.Hash160, tokenID []byte) bool {
+	return true
+}
+
+// TotalSupply returns the number of tokens minted.
+func (t Token[H]) TotalSupply() int {
+	return getInt(storage.GetReadOnlyContext(), []byte(t.SupplyKey))
+}
+
+// BalanceOf returns the number of tokens owned by the specified account, it
+// panics if account hash is invalid.
+func (t Token[H]) BalanceOf(owner interop.Hash160) int {
+	checkHash(owner, "invalid owner")
+	return getInt(storage.GetReadOnlyContext(), t.balanceKey(owner))
+}
+
+// TokensOf returns an iterator with all token IDs owned by the specified
+// account, it panics if account hash is invalid.
+func (t Token[H]) TokensOf(owner interop.Hash160) iterator.Iterator {
+	checkHash(owner, "invalid owner")
+	return storage.Find(storage.GetReadOnlyContext(), t.accountPrefix(owner), storage.ValuesOnly)
+}
+
+// Tokens returns an iterator with all token IDs minted by the contract.
+func (t Token[H]) Tokens() iterator.Iterator {
+	return storage.Find(storage.GetReadOnlyContext(), []byte(t.TokenPrefix), storage.RemovePrefix|storage.KeysOnly)
+}
+
+// OwnerOf returns the owner of the specified token, it panics if there is no
+// such token.
+func (t Token[H]) OwnerOf(tokenID []byte) interop.Hash160 {
+	return t.getOwner(storage.GetReadOnlyContext(), tokenID)
+}
+
+// Transfer implements NEP-11 transfer method. It panics if the receiver
+// address is invalid or the token doesn't exist and returns false if there is
+// no witness for the owner or if Hooks don't allow this transfer.
+func (t Token[H]) Transfer(to interop.Hash160, tokenID []byte, data any) bool {
+	checkHash(to, "invalid 'to' address")
+	ctx := storage.GetContext()
+	owner := t.getOwner(ctx, tokenID)
+	if !runtime.CheckWitness(owner) {
+		return false
+	}
+	if !t.Hooks.CheckTransfer(owner, to, tokenID, data) {
+		return false
+	}
+	if !owner.Equals(to) {
+		t.removeToken(ctx, owner, tokenID)
+		t.addToken(ctx, to, tokenID)
+	}
+	postTransfer(owner, to, tokenID, data)
+	return true
+}
+
+// Mint creates a new token with the given ID owned by the specified account.
+// It doesn't check any witnesses, so it's up to contract to check whether the
+// caller is allowed to mint. It panics if the token already exists or Hooks
+// don't allow minting.
+func (t Token[H]) Mint(to interop.Hash160, tokenID []byte, data any) {
+	checkHash(to, "invalid 'to' address")
+	ctx := storage.GetContext()
+	if storage.Get(ctx, t.tokenKey(tokenID)) != nil {
+		panic("token already exists")
+	}
+	if !t.Hooks.CheckMint(to, tokenID) {
+		panic("minting is not allowed")
+	}
+	t.addToken(ctx, to, tokenID)
+	storage.Put(ctx, []byte(t.SupplyKey), getInt(ctx, []byte(t.SupplyKey))+1)
+	postTransfer(nil, to, tokenID, data)
+}
+
+// Burn destroys the specified token. It doesn't check any witnesses, so it's
+// up to contract to check whether the caller is allowed to burn. It panics if
+// there is no such token or Hooks don't allow burning.
+func (t Token[H]) Burn(tokenID []byte) {
+	ctx := storage.GetContext()
+	owner := t.getOwner(ctx, tokenID)
+	if !t.Hooks.CheckBurn(owner, tokenID) {
+		panic("burning is not allowed")
+	}
+	t.removeToken(ctx, owner, tokenID)
+	storage.Put(ctx, []byte(t.SupplyKey), getInt(ctx, []byte(t.SupplyKey))-1)
+	runtime.Notify("Transfer", owner, nil, 1, tokenID)
+}
+
+// balanceKey returns storage key for the account balance.
+func (t Token[H]) balanceKey(owner interop.Hash160) []byte {
+	return append([]byte(t.BalancePrefix), owner...)
+}
+
+// accountPrefix returns storage key prefix for the account tokens.
+func (t Token[H]) accountPrefix(owner interop.Hash160) []byte {
+	return append([]byte(t.AccountPrefix), owner...)
+}
+
+// accountKey returns storage key for the account token.
+func (t Token[H]) accountKey(owner interop.Hash160, tokenID []byte) []byte {
+	return append(t.accountPrefix(owner), tokenID...)
+}
+
+// tokenKey returns storage key for the token owner.
+func (t Token[H]) tokenKey(tokenID []byte) []byte {
+	return append([]byte(t.TokenPrefix), tokenID...)
+}
+
+// getOwner returns the current owner of the token, it panics if there is no
+// such token.
+func (t Token[H]) getOwner(ctx storage.Context, tokenID []byte) interop.Hash160 {
+	val := storage.Get(ctx, t.tokenKey(tokenID))
+	if val == nil {
+		panic("unknown token")
+	}
+	return interop.Hash160(val.([]byte))
+}
+
+// addToken makes the account an owner of the token.
+func (t Token[H]) addToken(ctx storage.Context, owner interop.Hash160, tokenID []byte) {
+	storage.Put(ctx, t.tokenKey(tokenID), owner)
+	storage.Put(ctx, t.accountKey(owner, tokenID), tokenID)
+	storage.Put(ctx, t.balanceKey(owner), getInt(ctx, t.balanceKey(owner))+1)
+}
+
+// removeToken removes the token from the account deleting its owner record.
+func (t Token[H]) removeToken(ctx storage.Context, owner interop.Hash160, tokenID []byte) {
+	storage.Delete(ctx, t.tokenKey(tokenID))
+	storage.Delete(ctx, t.accountKey(owner, tokenID))
+	key := t.balanceKey(owner)
+	balance := getInt(ctx, key) - 1
+	if balance > 0 {
+		storage.Put(ctx, key, balance)
+	} else {
+		storage.Delete(ctx, key)
+	}
+}
+
+// postTransfer emits Transfer event and calls onNEP11Payment if the receiver
+// is a contract.
+func postTransfer(from, to interop.Hash160, tokenID []byte, data any) {
+	runtime.Notify("Transfer", from, to, 1, tokenID)
+	if management.GetContract(to) != nil {
+		contract.Call(to, "onNEP11Payment", contract.All, from, 1, tokenID, data)
+	}
+}
+
+// getInt returns integer stored by the key or 0 if there is no value.
+func getInt(ctx storage.Context, key []byte) int {
+	val := storage.Get(ctx, key)
+	if val == nil {
+		return 0
+	}
+	return val.(int)
+}
+
+func checkHash(h interop.Hash160, msg string) {
+	if len(h) != interop.Hash160Len {
+		panic(msg)
+	}
+}
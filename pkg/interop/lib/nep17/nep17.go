@@ -0,0 +1,205 @@
+/*
+Package nep17 contains reusable NEP-17 token implementation. Token stores
+balances and total supply using configurable storage keys and implements all
+of the standard logic (checks, Transfer event, onNEP17Payment callback), so
+contract only has to export methods calling it:
+
+	var token = nep17.Token[nep17.NoHooks]{
+		Symbol:        "TOK",
+		Decimals:      8,
+		BalancePrefix: "b",
+		SupplyKey:     "s",
+	}
+
+	func Symbol() string                            { return token.Symbol }
+	func Decimals() int                             { return token.Decimals }
+	func TotalSupply() int                          { return token.TotalSupply() }
+	func BalanceOf(account interop.Hash160) int     { return token.BalanceOf(account) }
+	func Transfer(from, to interop.Hash160, amount int, data any) bool {
+		return token.Transfer(from, to, amount, data)
+	}
+
+Minting and burning are not a part of the standard, so it's up to contract to
+export methods for them (with appropriate access checks). Additional checks
+for transfers, minting and burning can be implemented via Hooks.
+*/
+package nep17
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// Hooks contains additional token-specific checks performed by Token before
+// changing balances. Every check is done after the standard ones (like witness
+// and balance checks) succeed.
+type Hooks interface {
+	// CheckTransfer returns false if transfer is not allowed, Transfer
+	// returns false then.
+	CheckTransfer(from, to interop.Hash160, amount int, data any) bool
+	// CheckMint returns false if minting is not allowed, Mint panics then.
+	CheckMint(to interop.Hash160, amount int) bool
+	// CheckBurn returns false if burning is not allowed, Burn panics then.
+	CheckBurn(from interop.Hash160, amount int) bool
+}
+
+// NoHooks is a Hooks implementation allowing everything.
+type NoHooks struct{}
+
+// Token is a NEP-17 token using the given Hooks for additional checks.
+type Token[H Hooks] struct {
+	// Symbol is a token symbol.
+	Symbol string
+	// Decimals is the number of token decimals.
+	Decimals int
+	// BalancePrefix is a storage key prefix for account balances, account
+	// hash is appended to it.
+	BalancePrefix string
+	// SupplyKey is a storage key for the total supply value. It must not
+	// collide with BalancePrefix.
+	SupplyKey string
+	// Hooks contains additional token checks.
+	Hooks H
+}
+
+// CheckTransfer implements Hooks interface allowing any transfer.
+func (NoHooks) CheckTransfer(from, to interop.Hash160, amount int, data any) bool {
+	return true
+}
+
+// CheckMint implements Hooks interface allowing any minting.
+func (NoHooks) CheckMint(to interop.Hash160, amount int) bool {
+	return true
+}
+
+// CheckBurn implements Hooks interface allowing any burning.
+func (NoHooks) CheckBurn(from interop.Hash160, amount int) bool {
+	return true
+}
+
+// TotalSupply returns the total token supply.
+func (t Token[H]) TotalSupply() int {
+	return getInt(storage.GetReadOnlyContext(), []byte(t.SupplyKey))
+}
+
+// BalanceOf returns the balance of the given account, it panics if account
+// hash is invalid.
+func (t Token[H]) BalanceOf(account interop.Hash160) int {
+	checkHash(account, "invalid account")
+	return getInt(storage.GetReadOnlyContext(), t.balanceKey(account))
+}
+
+// Transfer implements NEP-17 transfer method. It panics if addresses or the
+// amount are invalid and returns false if there is no witness for the sender,
+// it doesn't have enough tokens or if Hooks don't allow this transfer.
+func (t Token[H]) Transfer(from, to interop.Hash160, amount int, data any) bool {
+	checkHash(from, "invalid 'from' address")
+	checkHash(to, "invalid 'to' address")
+	if amount < 0 {
+		panic("negative amount")
+	}
+	if !runtime.CheckWitness(from) {
+		return false
+	}
+	ctx := storage.GetContext()
+	if getInt(ctx, t.balanceKey(from)) < amount {
+		return false
+	}
+	if !t.Hooks.CheckTransfer(from, to, amount, data) {
+		return false
+	}
+	if amount != 0 && !from.Equals(to) {
+		t.addBalance(ctx, from, -amount)
+		t.addBalance(ctx, to, amount)
+	}
+	postTransfer(from, to, amount, data)
+	return true
+}
+
+// Mint creates the given amount of tokens on the account. It doesn't check
+// any witnesses, so it's up to contract to check whether the caller is
+// allowed to mint. It panics if Hooks don't allow minting.
+func (t Token[H]) Mint(to interop.Hash160, amount int, data any) {
+	checkHash(to, "invalid 'to' address")
+	if amount < 0 {
+		panic("negative amount")
+	}
+	if !t.Hooks.CheckMint(to, amount) {
+		panic("minting is not allowed")
+	}
+	if amount == 0 {
+		return
+	}
+	ctx := storage.GetContext()
+	t.addBalance(ctx, to, amount)
+	storage.Put(ctx, []byte(t.SupplyKey), getInt(ctx, []byte(t.SupplyKey))+amount)
+	postTransfer(nil, to, amount, data)
+}
+
+// Burn destroys the given amount of tokens on the account. It doesn't check
+// any witnesses, so it's up to contract to check whether the caller is
+// allowed to burn. It panics if the account doesn't have enough tokens or
+// Hooks don't allow burning.
+func (t Token[H]) Burn(from interop.Hash160, amount int) {
+	checkHash(from, "invalid 'from' address")
+	if amount < 0 {
+		panic("negative amount")
+	}
+	ctx := storage.GetContext()
+	if getInt(ctx, t.balanceKey(from)) < amount {
+		panic("insufficient funds")
+	}
+	if !t.Hooks.CheckBurn(from, amount) {
+		panic("burning is not allowed")
+	}
+	if amount == 0 {
+		return
+	}
+	t.addBalance(ctx, from, -amount)
+	storage.Put(ctx, []byte(t.SupplyKey), getInt(ctx, []byte(t.SupplyKey))-amount)
+	runtime.Notify("Transfer", from, nil, amount)
+}
+
+// balanceKey returns storage key for the account balance.
+func (t Token[H]) balanceKey(account interop.Hash160) []byte {
+	return append([]byte(t.BalancePrefix), account...)
+}
+
+// addBalance adds amount (that can be negative) to the account balance
+// deleting zero balances from the storage.
+func (t Token[H]) addBalance(ctx storage.Context, account interop.Hash160, amount int) {
+	key := t.balanceKey(account)
+	balance := getInt(ctx, key) + amount
+	if balance == 0 {
+		storage.Delete(ctx, key)
+	} else {
+		storage.Put(ctx, key, balance)
+	}
+}
+
+// postTransfer emits Transfer event and calls onNEP17Payment if the receiver
+// is a contract.
+func postTransfer(from, to interop.Hash160, amount int, data any) {
+	runtime.Notify("Transfer", from, to, amount)
+	if management.GetContract(to) != nil {
+		contract.Call(to, "onNEP17Payment", contract.All, from, amount, data)
+	}
+}
+
+// getInt returns integer stored by the key or 0 if there is no value.
+func getInt(ctx storage.Context, key []byte) int {
+	val := storage.Get(ctx, key)
+	if val == nil {
+		return 0
+	}
+	return val.(int)
+}
+
+func checkHash(h interop.Hash160, msg string) {
+	if len(h) != interop.Hash160Len {
+		panic(msg)
+	}
+}
@@ -76,6 +76,30 @@ type (
 		transaction.Signer
 		transaction.Witness
 	}
+
+	// StateOverride is a set of contract scripts and storage items replacing
+	// the ones stored in the chain for the duration of a single test
+	// invocation, it's used by `invokescriptwithoverrides` and
+	// `tracetransaction` calls.
+	StateOverride struct {
+		Contracts []ContractOverride `json:"contracts"`
+	}
+
+	// ContractOverride describes overridden state of a single deployed
+	// contract. Script (if set) replaces the contract's NEF script, manifest
+	// is left intact, so method offsets should be kept compatible.
+	ContractOverride struct {
+		Hash    util.Uint160      `json:"hash"`
+		Script  []byte            `json:"script,omitempty"`
+		Storage []StorageOverride `json:"storage,omitempty"`
+	}
+
+	// StorageOverride is a single overridden contract storage item. Nil
+	// Value (JSON null) deletes the item.
+	StorageOverride struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	}
 )
 
 // signerWithWitnessAux is an auxiliary struct for JSON marshalling. We need it because of
@@ -14,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -34,6 +35,13 @@ type Executor struct {
 	Committee     Signer
 	CommitteeHash util.Uint160
 	Contracts     map[string]*Contract
+
+	// snapshots are chain state copies made by Snapshot.
+	snapshots []storage.Store
+	// closeChain closes the chain created by Revert.
+	closeChain func()
+	// next contains pinned header fields of the next block.
+	next *blockOverrides
 }
 
 // NewExecutor creates a new executor instance from the provided blockchain and committee.
@@ -327,7 +335,8 @@ func AddNetworkFee(t testing.TB, bc *core.Blockchain, tx *transaction.Transactio
 	tx.NetworkFee += int64(size)*bc.FeePerByte() + bc.CalculateAttributesFee(tx)
 }
 
-// NewUnsignedBlock creates a new unsigned block from txs.
+// NewUnsignedBlock creates a new unsigned block from txs. Header fields pinned
+// with SetNextBlockTime, SetNextBlockNonce and SetNextBlockPrevHash are applied.
 func (e *Executor) NewUnsignedBlock(t testing.TB, txs ...*transaction.Transaction) *block.Block {
 	lastBlock := e.TopBlock(t)
 	b := &block.Block{
@@ -346,6 +355,7 @@ func (e *Executor) NewUnsignedBlock(t testing.TB, txs ...*transaction.Transactio
 	}
 	b.PrevHash = lastBlock.Hash()
 	b.Index = e.Chain.BlockHeight() + 1
+	e.applyOverrides(b)
 	b.RebuildMerkleRoot()
 	return b
 }
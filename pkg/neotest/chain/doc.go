@@ -3,5 +3,9 @@ Package chain contains functions creating new test blockchain instances.
 Different configurations can be used, but all chains created here use
 well-known keys. Most of the time, a single-node chain is the best choice to use
 unless you specifically need multiple validators and a large committee.
+
+NewFork creates a single-node chain with contracts forked from a real network
+(via RPC), their storage is fetched lazily and can be cached for offline use.
+Forked state is not covered by local state roots.
 */
 package chain
@@ -0,0 +1,424 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// ForkSource is a remote node providing the state for NewFork, it's
+// implemented by rpcclient.Client. The node must keep historic states
+// (KeepOnlyLatestState disabled) for the height requested.
+type ForkSource interface {
+	GetStateRootByHeight(height uint32) (*state.MPTRoot, error)
+	GetProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalKey []byte) (*result.ProofWithKey, error)
+	FindStates(stateroot util.Uint256, historicalContractHash util.Uint160, historicalPrefix []byte,
+		start []byte, maxCount *int) (result.FindStates, error)
+}
+
+// ForkConfig describes the remote state used by NewFork.
+type ForkConfig struct {
+	// Source is the remote node the state is fetched from. It can be nil if
+	// Cache already contains all the data needed.
+	Source ForkSource
+	// Height is the remote chain height to take the state at.
+	Height uint32
+	// Contracts are the remote (non-native) contracts to make available in
+	// the local chain.
+	Contracts []util.Uint160
+	// Cache is an optional Store (usually a persistent one) used to keep the
+	// data fetched from Source, subsequent runs with the same Height don't
+	// need Source then and work offline.
+	Cache storage.Store
+}
+
+// Cache key prefixes.
+const (
+	forkCacheRoot byte = iota + 1
+	forkCacheContract
+	forkCacheStorageDone
+	forkCacheStorage
+)
+
+// forkPageSize is the number of storage items requested with one findstates
+// call.
+const forkPageSize = 1000
+
+// forkRemote fetches the remote state via ForkSource and the cache.
+type forkRemote struct {
+	src   ForkSource
+	cache storage.Store
+}
+
+// forkStore is a MemoryStore that lazily fetches storage items of forked
+// contracts on first access (only the keys or prefixes requested).
+type forkStore struct {
+	*storage.MemoryStore
+
+	t      testing.TB
+	remote *forkRemote
+	root   util.Uint256
+
+	lock      sync.Mutex
+	contracts map[int32]util.Uint160
+	// fetched contains contract storage prefixes (without contract ID)
+	// fetched already.
+	fetched map[int32][][]byte
+	// written contains storage keys changed locally, remote items never
+	// override them.
+	written map[string]struct{}
+}
+
+// NewFork creates a new single-node chain (just like NewSingleWithCustomConfig
+// does) with the specified contracts forked from the remote chain at the given
+// height. Contract states are fetched with getproof (and verified against the
+// remote state root) when the chain is created, while contract storage items
+// are fetched with findstates only when they're accessed for the first time
+// (only the item requested or the items matching the prefix searched for).
+// The first and the last items of every findstates result are verified
+// against the remote state root with the proofs returned. Local transactions
+// then modify the local copy only. Failures to fetch remote items are reported
+// via t. Native contracts can't
+// be forked, so their state (like NEO/GAS balances) is the local one.
+//
+// Forked contract states and storage items are put into the local store
+// directly, they're not a part of the local MPT (only the changes made by
+// local transactions are). Local state roots (and proofs or findstates
+// results based on them) thus don't cover the forked state, so they can't be
+// checked against the remote ones and StateRootInHeader can't be enabled for
+// the forked chain.
+func NewFork(t testing.TB, fc ForkConfig, f func(*config.Blockchain)) (*core.Blockchain, neotest.Signer) {
+	var r = &forkRemote{src: fc.Source, cache: fc.Cache}
+
+	cfg := func(c *config.Blockchain) {
+		if f != nil {
+			f(c)
+		}
+		require.False(t, c.StateRootInHeader, "state roots in headers can't be used with a forked chain")
+	}
+
+	root, err := r.stateRoot(fc.Height)
+	require.NoError(t, err)
+
+	st := &forkStore{
+		MemoryStore: storage.NewMemoryStore(),
+		t:           t,
+		remote:      r,
+		root:        root,
+		contracts:   make(map[int32]util.Uint160),
+		fetched:     make(map[int32][][]byte),
+		written:     make(map[string]struct{}),
+	}
+	// Genesis block and native contracts are local, forked contracts are
+	// imported on top of them.
+	gen, _ := NewSingleWithCustomConfigAndStore(t, cfg, nil, false)
+	require.NoError(t, gen.CopyStorage(st.MemoryStore))
+
+	d := dao.NewSimple(st.MemoryStore, gen.GetConfig().StateRootInHeader)
+	for _, h := range fc.Contracts {
+		cs, err := r.contract(root, h)
+		require.NoError(t, err, "contract %s", h.StringLE())
+		require.True(t, cs.ID > 0, "native contract %s can't be forked", h.StringLE())
+		require.NoError(t, native.ImportContractState(d, cs))
+		st.contracts[cs.ID] = h
+	}
+	_, err = d.Persist()
+	require.NoError(t, err)
+
+	return NewSingleWithCustomConfigAndStore(t, cfg, st, true)
+}
+
+// stateRoot returns the remote state root hash for the given height.
+func (r *forkRemote) stateRoot(height uint32) (util.Uint256, error) {
+	key := binary.BigEndian.AppendUint32([]byte{forkCacheRoot}, height)
+	b, err := r.cached(key, func() ([]byte, error) {
+		sr, err := r.src.GetStateRootByHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state root: %w", err)
+		}
+		return sr.Root.BytesBE(), nil
+	})
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(b)
+}
+
+// contract returns the remote contract state at the given state root.
+func (r *forkRemote) contract(root util.Uint256, h util.Uint160) (*state.Contract, error) {
+	mgmt := state.CreateNativeContractHash(nativenames.Management)
+	b, err := r.cached(forkCacheKey(forkCacheContract, root, h), func() ([]byte, error) {
+		p, err := r.src.GetProof(root, mgmt, native.MakeContractKey(h))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contract state proof: %w", err)
+		}
+		var id int32 = native.ManagementContractID
+		key := binary.LittleEndian.AppendUint32(nil, uint32(id))
+		key = append(key, native.MakeContractKey(h)...)
+		if !bytes.Equal(p.Key, key) {
+			return nil, errors.New("invalid contract state proof key")
+		}
+		v, ok := mpt.VerifyProof(root, p.Key, p.Proof)
+		if !ok {
+			return nil, errors.New("invalid contract state proof")
+		}
+		return v, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	cs := new(state.Contract)
+	return cs, stackitem.DeserializeConvertible(b, cs)
+}
+
+// storage returns the remote storage items of the contract with the given ID
+// matching the prefix at the given state root (keys are returned without
+// contract ID).
+func (r *forkRemote) storage(root util.Uint256, h util.Uint160, id int32, prefix []byte) ([]storage.KeyValue, error) {
+	var (
+		base = forkCacheKey(forkCacheStorage, root, h)
+		kvs  []storage.KeyValue
+	)
+	if r.cache != nil {
+		for i := 0; i <= len(prefix); i++ {
+			if _, err := r.cache.Get(append(forkCacheKey(forkCacheStorageDone, root, h), prefix[:i]...)); err != nil {
+				continue
+			}
+			pref := append(bytes.Clone(base), prefix...)
+			r.cache.Seek(storage.SeekRange{Prefix: pref}, func(k, v []byte) bool {
+				kvs = append(kvs, storage.KeyValue{Key: bytes.Clone(k[len(base):]), Value: bytes.Clone(v)})
+				return true
+			})
+			return kvs, nil
+		}
+	}
+	if r.src == nil {
+		return nil, fmt.Errorf("storage of %s is not cached and no source given", h.StringLE())
+	}
+	var (
+		start []byte
+		count = forkPageSize
+	)
+	for {
+		res, err := r.src.FindStates(root, h, prefix, start, &count)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find states of %s: %w", h.StringLE(), err)
+		}
+		if err := verifyFindStates(root, id, prefix, start, res); err != nil {
+			return nil, fmt.Errorf("invalid states of %s: %w", h.StringLE(), err)
+		}
+		for _, kv := range res.Results {
+			kvs = append(kvs, storage.KeyValue{Key: kv.Key, Value: kv.Value})
+		}
+		if !res.Truncated || len(res.Results) == 0 {
+			break
+		}
+		start = res.Results[len(res.Results)-1].Key
+	}
+	if r.cache != nil {
+		puts := make(map[string][]byte, len(kvs)+1)
+		for _, kv := range kvs {
+			puts[string(append(bytes.Clone(base), kv.Key...))] = kv.Value
+		}
+		puts[string(append(forkCacheKey(forkCacheStorageDone, root, h), prefix...))] = []byte{}
+		if err := r.cache.PutChangeSet(puts, nil); err != nil {
+			return nil, err
+		}
+	}
+	return kvs, nil
+}
+
+// verifyFindStates checks that findstates results are ordered, match the
+// prefix and that the first and the last of them are proven to be a part of
+// the state with the given root.
+func verifyFindStates(root util.Uint256, id int32, prefix []byte, start []byte, res result.FindStates) error {
+	for i, kv := range res.Results {
+		if !bytes.HasPrefix(kv.Key, prefix) {
+			return fmt.Errorf("key %x doesn't match prefix", kv.Key)
+		}
+		if (i == 0 && start != nil && bytes.Compare(kv.Key, start) <= 0) ||
+			(i > 0 && bytes.Compare(kv.Key, res.Results[i-1].Key) <= 0) {
+			return fmt.Errorf("key %x is out of order", kv.Key)
+		}
+	}
+	if len(res.Results) == 0 {
+		return nil
+	}
+	if err := verifyStorageProof(root, id, res.Results[0], res.FirstProof); err != nil {
+		return fmt.Errorf("first item: %w", err)
+	}
+	if len(res.Results) > 1 {
+		if err := verifyStorageProof(root, id, res.Results[len(res.Results)-1], res.LastProof); err != nil {
+			return fmt.Errorf("last item: %w", err)
+		}
+	}
+	return nil
+}
+
+// verifyStorageProof checks that the storage item of the contract with the
+// given ID is proven to be a part of the state with the given root.
+func verifyStorageProof(root util.Uint256, id int32, kv result.KeyValue, p *result.ProofWithKey) error {
+	if p == nil {
+		return errors.New("no proof")
+	}
+	key := binary.LittleEndian.AppendUint32(nil, uint32(id))
+	if !bytes.Equal(p.Key, append(key, kv.Key...)) {
+		return errors.New("invalid proof key")
+	}
+	v, ok := mpt.VerifyProof(root, p.Key, p.Proof)
+	if !ok || !bytes.Equal(v, kv.Value) {
+		return errors.New("invalid proof")
+	}
+	return nil
+}
+
+// cached returns the value from the cache or fetches and caches it.
+func (r *forkRemote) cached(key []byte, fetch func() ([]byte, error)) ([]byte, error) {
+	if r.cache != nil {
+		if v, err := r.cache.Get(key); err == nil {
+			return v, nil
+		}
+	}
+	if r.src == nil {
+		return nil, errors.New("data is not cached and no source given")
+	}
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if r.cache != nil {
+		if err := r.cache.PutChangeSet(map[string][]byte{string(key): v}, nil); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func forkCacheKey(prefix byte, root util.Uint256, h util.Uint160) []byte {
+	key := append([]byte{prefix}, root.BytesBE()...)
+	return append(key, h.BytesBE()...)
+}
+
+// fetch fetches remote storage items of forked contracts matching the given
+// storage key (or key prefix). Items fetched already or changed locally are
+// not touched.
+func (s *forkStore) fetch(key []byte) error {
+	if len(key) > 0 && key[0] != byte(storage.STStorage) {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var rest []byte
+	if len(key) > 0 {
+		rest = key[1:]
+	}
+	for id, h := range s.contracts {
+		var (
+			idKey  = binary.LittleEndian.AppendUint32(nil, uint32(id))
+			prefix []byte
+		)
+		if len(rest) <= len(idKey) {
+			if !bytes.HasPrefix(idKey, rest) {
+				continue
+			}
+		} else {
+			if !bytes.Equal(idKey, rest[:len(idKey)]) {
+				continue
+			}
+			prefix = rest[len(idKey):]
+		}
+		if s.isFetched(id, prefix) {
+			continue
+		}
+		kvs, err := s.remote.storage(s.root, h, id, prefix)
+		if err != nil {
+			return err
+		}
+		stor := make(map[string][]byte, len(kvs))
+		for _, kv := range kvs {
+			k := string(append(append([]byte{byte(storage.STStorage)}, idKey...), kv.Key...))
+			if _, ok := s.written[k]; !ok {
+				stor[k] = kv.Value
+			}
+		}
+		if err := s.MemoryStore.PutChangeSet(nil, stor); err != nil {
+			return err
+		}
+		s.fetched[id] = append(s.fetched[id], bytes.Clone(prefix))
+	}
+	return nil
+}
+
+// isFetched checks whether the items of the contract with the given ID
+// matching the prefix are fetched already.
+func (s *forkStore) isFetched(id int32, prefix []byte) bool {
+	for _, p := range s.fetched[id] {
+		if bytes.HasPrefix(prefix, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchOrFail fetches remote items and reports an error via t if it fails,
+// callers up the stack can ignore the error returned.
+func (s *forkStore) fetchOrFail(key []byte) error {
+	err := s.fetch(key)
+	if err != nil {
+		s.t.Errorf("failed to fetch forked contract storage: %v", err)
+	}
+	return err
+}
+
+// Get implements the storage.Store interface.
+func (s *forkStore) Get(key []byte) ([]byte, error) {
+	if err := s.fetchOrFail(key); err != nil {
+		return nil, err
+	}
+	return s.MemoryStore.Get(key)
+}
+
+// PutChangeSet implements the storage.Store interface. Changed items are
+// remembered, so that remote items fetched later never override them.
+func (s *forkStore) PutChangeSet(puts map[string][]byte, stor map[string][]byte) error {
+	s.lock.Lock()
+	for k := range stor {
+		s.written[k] = struct{}{}
+	}
+	s.lock.Unlock()
+	return s.MemoryStore.PutChangeSet(puts, stor)
+}
+
+// Seek implements the storage.Store interface. If remote items can't be
+// fetched, the error is reported via t and only local items are iterated
+// over.
+func (s *forkStore) Seek(rng storage.SeekRange, f func(k, v []byte) bool) {
+	_ = s.fetchOrFail(rng.Prefix)
+	s.MemoryStore.Seek(rng, f)
+}
+
+// SeekGC implements the storage.Store interface.
+func (s *forkStore) SeekGC(rng storage.SeekRange, keep func(k, v []byte) bool) error {
+	if err := s.fetchOrFail(rng.Prefix); err != nil {
+		return err
+	}
+	return s.MemoryStore.SeekGC(rng, keep)
+}
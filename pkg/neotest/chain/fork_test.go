@@ -0,0 +1,270 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+const forkSrc = `package foo
+import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+func Put(k string, v int) {
+	storage.Put(storage.GetContext(), k, v)
+}
+func Get(k string) int {
+	v := storage.Get(storage.GetReadOnlyContext(), k)
+	if v == nil {
+		return -1
+	}
+	return v.(int)
+}
+func Del(k string) {
+	storage.Delete(storage.GetContext(), k)
+}`
+
+// chainSource implements ForkSource over a local chain.
+type chainSource struct {
+	bc        *core.Blockchain
+	findCalls int
+	tamper    func(*result.FindStates)
+}
+
+func (s *chainSource) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	return s.bc.GetStateModule().GetStateRoot(height)
+}
+
+func (s *chainSource) storageKey(h util.Uint160, key []byte) ([]byte, error) {
+	cs := s.bc.GetContractState(h)
+	if cs == nil {
+		return nil, storage.ErrKeyNotFound
+	}
+	return append(binary.LittleEndian.AppendUint32(nil, uint32(cs.ID)), key...), nil
+}
+
+func (s *chainSource) GetProof(root util.Uint256, h util.Uint160, key []byte) (*result.ProofWithKey, error) {
+	skey, err := s.storageKey(h, key)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := s.bc.GetStateModule().GetStateProof(root, skey)
+	if err != nil {
+		return nil, err
+	}
+	return &result.ProofWithKey{Key: skey, Proof: proof}, nil
+}
+
+func (s *chainSource) FindStates(root util.Uint256, h util.Uint160, prefix []byte, start []byte, maxCount *int) (result.FindStates, error) {
+	s.findCalls++
+	var res result.FindStates
+	pkey, err := s.storageKey(h, prefix)
+	if err != nil {
+		return res, err
+	}
+	if start != nil {
+		start = start[len(prefix):]
+	}
+	kvs, err := s.bc.GetStateModule().FindStates(root, pkey, start, *maxCount+1)
+	if err != nil && !errors.Is(err, mpt.ErrNotFound) {
+		return res, err
+	}
+	if len(kvs) > *maxCount {
+		res.Truncated = true
+		kvs = kvs[:*maxCount]
+	}
+	for i, kv := range kvs {
+		if i == 0 || i == len(kvs)-1 {
+			proof, err := s.bc.GetStateModule().GetStateProof(root, kv.Key)
+			if err != nil {
+				return res, err
+			}
+			p := &result.ProofWithKey{Key: kv.Key, Proof: proof}
+			if i == 0 {
+				res.FirstProof = p
+			} else {
+				res.LastProof = p
+			}
+		}
+		res.Results = append(res.Results, result.KeyValue{Key: kv.Key[4:], Value: kv.Value})
+	}
+	if s.tamper != nil {
+		s.tamper(&res)
+	}
+	return res, nil
+}
+
+func TestNewFork(t *testing.T) {
+	rbc, racc := NewSingle(t)
+	re := neotest.NewExecutor(t, rbc, racc, racc)
+	ctr := neotest.CompileSource(t, re.CommitteeHash, strings.NewReader(forkSrc), &compiler.Options{Name: "Forked"})
+	re.DeployContract(t, ctr, nil)
+	rc := re.CommitteeInvoker(ctr.Hash)
+	rc.Invoke(t, nil, "put", "a", 1)
+	rc.Invoke(t, nil, "put", "b", 2)
+	height := rbc.BlockHeight()
+	rc.Invoke(t, nil, "put", "a", 10)
+
+	var (
+		src   = &chainSource{bc: rbc}
+		cache = storage.NewMemoryStore()
+		fc    = ForkConfig{
+			Source:    src,
+			Height:    height,
+			Contracts: []util.Uint160{ctr.Hash},
+			Cache:     cache,
+		}
+	)
+	bc, acc := NewFork(t, fc, nil)
+	require.Equal(t, 0, src.findCalls)
+
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := e.CommitteeInvoker(ctr.Hash)
+	c.Invoke(t, 1, "get", "a")
+	c.Invoke(t, 1, "get", "a")
+	require.Equal(t, 1, src.findCalls) // Only the item requested is fetched.
+	c.Invoke(t, 2, "get", "b")
+	require.Equal(t, 2, src.findCalls)
+
+	c.Invoke(t, nil, "put", "a", 5)
+	c.Invoke(t, nil, "del", "b")
+	c.Invoke(t, 5, "get", "a")
+	c.Invoke(t, -1, "get", "b")
+
+	// Local deployments don't collide with forked contracts.
+	local := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(forkSrc), &compiler.Options{Name: "Local"})
+	e.DeployContract(t, local, nil)
+	lc := e.CommitteeInvoker(local.Hash)
+	lc.Invoke(t, nil, "put", "a", 7)
+	lc.Invoke(t, 7, "get", "a")
+	c.Invoke(t, 5, "get", "a")
+
+	t.Run("other keys changed first", func(t *testing.T) {
+		bc, acc := NewFork(t, fc, nil)
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		c := e.CommitteeInvoker(ctr.Hash)
+		c.Invoke(t, nil, "del", "c")
+		c.Invoke(t, nil, "put", "d", 4)
+		c.Invoke(t, 1, "get", "a")
+		c.Invoke(t, 2, "get", "b")
+		c.Invoke(t, 4, "get", "d")
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		src := &chainSource{bc: rbc}
+		fc := fc
+		fc.Source = src
+		fc.Cache = nil
+		st := newTestForkStore(t, t, fc)
+		key := st.idKey(t)
+
+		var keys []string
+		seek := func(prefix []byte) {
+			keys = keys[:0]
+			st.Seek(storage.SeekRange{Prefix: prefix}, func(k, v []byte) bool {
+				keys = append(keys, string(k[len(key):]))
+				return true
+			})
+		}
+		seek(append(bytes.Clone(key), 'b'))
+		require.Equal(t, []string{"b"}, keys)
+		require.Equal(t, 1, src.findCalls)
+
+		// Local changes are not overridden.
+		require.NoError(t, st.PutChangeSet(nil, map[string][]byte{string(append(bytes.Clone(key), 'a')): nil}))
+		seek(key)
+		require.Equal(t, []string{"b"}, keys)
+		require.Equal(t, 2, src.findCalls)
+
+		// Everything is fetched already.
+		_, err := st.Get(append(bytes.Clone(key), 'b'))
+		require.NoError(t, err)
+		seek([]byte{byte(storage.STStorage)})
+		require.Equal(t, 2, src.findCalls)
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		src := &chainSource{bc: rbc, tamper: func(res *result.FindStates) {
+			for i := range res.Results {
+				res.Results[i].Value = []byte{0x21, 0x01, 0x63} // Integer 99.
+			}
+		}}
+		fc := fc
+		fc.Source = src
+		fc.Cache = nil
+		rec := &errRecorder{TB: t}
+		st := newTestForkStore(t, rec, fc)
+		_, err := st.Get(append(st.idKey(t), 'a'))
+		require.ErrorContains(t, err, "invalid proof")
+		st.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STStorage)}}, func(k, v []byte) bool {
+			require.Fail(t, "nothing is to be found")
+			return true
+		})
+		require.Equal(t, 2, len(rec.errs))
+
+		src.tamper = func(res *result.FindStates) { res.FirstProof = nil }
+		_, err = st.Get(append(st.idKey(t), 'a'))
+		require.ErrorContains(t, err, "no proof")
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		fc.Source = nil
+		bc, acc := NewFork(t, fc, nil)
+		c := neotest.NewExecutor(t, bc, acc, acc).CommitteeInvoker(ctr.Hash)
+		c.Invoke(t, 1, "get", "a")
+		c.Invoke(t, 2, "get", "b")
+	})
+}
+
+// errRecorder records errors reported instead of failing the test.
+type errRecorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *errRecorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+// newTestForkStore creates a forkStore for the contracts of the given
+// configuration without a chain.
+func newTestForkStore(t *testing.T, rt testing.TB, fc ForkConfig) *forkStore {
+	r := &forkRemote{src: fc.Source, cache: fc.Cache}
+	root, err := r.stateRoot(fc.Height)
+	require.NoError(t, err)
+	st := &forkStore{
+		MemoryStore: storage.NewMemoryStore(),
+		t:           rt,
+		remote:      r,
+		root:        root,
+		contracts:   make(map[int32]util.Uint160),
+		fetched:     make(map[int32][][]byte),
+		written:     make(map[string]struct{}),
+	}
+	for _, h := range fc.Contracts {
+		cs, err := r.contract(root, h)
+		require.NoError(t, err)
+		st.contracts[cs.ID] = h
+	}
+	return st
+}
+
+// idKey returns the storage key prefix of the only contract of the store.
+func (s *forkStore) idKey(t *testing.T) []byte {
+	require.Equal(t, 1, len(s.contracts))
+	for id := range s.contracts {
+		return binary.LittleEndian.AppendUint32([]byte{byte(storage.STStorage)}, uint32(id))
+	}
+	return nil
+}
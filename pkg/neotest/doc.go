@@ -16,6 +16,17 @@ Higher-order methods provided in Executor and ContractInvoker hide the details
 of transaction creation for the most part, but there are lower-level methods as
 well that can be used for specific tasks.
 
+Contract methods can also be fuzzed with Go native fuzzing using Fuzzer created
+with ContractInvoker.NewFuzzer, it derives method arguments from the manifest
+ABI and reports unexpected FAULTs and invariant violations.
+
+Executor.Snapshot and Executor.Revert allow to capture the chain state and roll
+back to it, so that several test cases can share the same deployed fixture.
+
+Time-dependent contracts can be tested with Executor.SetNextBlockTime and
+Executor.AdvanceTime controlling the next block timestamp and Executor.FastForward
+adding lots of empty blocks quickly.
+
 It's recommended to have a separate folder/package for tests, because having
 them in the same package with the smart contract iself can lead to unxpected
 results if smart contract has any init() functions. If that's the case they
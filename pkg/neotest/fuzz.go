@@ -0,0 +1,273 @@
+package neotest
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+// maxFuzzLen is the maximum length of generated byte arrays and strings.
+const maxFuzzLen = 64
+
+// maxFuzzElements is the maximum number of generated array/map elements.
+const maxFuzzElements = 4
+
+// maxFuzzDepth is the maximum nesting level of generated Any arguments.
+const maxFuzzDepth = 2
+
+// FuzzInvariant is a callback checking contract invariants after every
+// successful (HALTed) fuzzed invocation. It gets the method invoked, its
+// arguments and the resulting stack and returns an error if the invariant is
+// violated.
+type FuzzInvariant func(method string, args []any, stack []stackitem.Item) error
+
+// Fuzzer performs fuzz testing of the deployed contract methods using Go native
+// fuzzing. Arguments are derived from the fuzzing input according to the
+// method parameter types from the contract manifest ABI. Any FAULT not
+// accepted by IsExpectedFault and any invariant violation is reported as a
+// test failure.
+type Fuzzer struct {
+	// Invoker is used to perform invocations, its signers are used for all
+	// transactions.
+	Invoker *ContractInvoker
+	// Manifest is the manifest of the contract being fuzzed.
+	Manifest *manifest.Manifest
+	// Persist makes Fuzzer commit every invocation in a new block instead of
+	// test-invoking it, this allows invariants to check the resulting chain
+	// state at the cost of speed.
+	Persist bool
+	// IsExpectedFault checks whether the FAULT with the given exception is a
+	// legitimate method reaction to the arguments given (like input
+	// validation failure). If nil, any FAULT is treated as unexpected.
+	IsExpectedFault func(method string, args []any, exception string) bool
+	// Invariants are checked after every successful invocation.
+	Invariants []FuzzInvariant
+}
+
+// fuzzData is a reader of the fuzzing input. It returns zeroes once the input
+// is exhausted, so that any input produces a valid set of arguments.
+type fuzzData struct {
+	b []byte
+}
+
+// NewFuzzer creates a new Fuzzer for the contract invoked by c with the given
+// manifest and invariants.
+func (c *ContractInvoker) NewFuzzer(m *manifest.Manifest, invariants ...FuzzInvariant) *Fuzzer {
+	return &Fuzzer{
+		Invoker:    c,
+		Manifest:   m,
+		Invariants: invariants,
+	}
+}
+
+// Fuzz adds seeds to the corpus of f and runs fuzzing for the specified
+// methods (all ABI methods not starting with an underscore if none given).
+// The first byte of the input selects the method to invoke when there are
+// several of them, the rest is used to generate arguments, see FuzzArgs.
+// Notice that the chain used shouldn't log into f, background logging
+// interferes with the fuzzing engine, so use a testing.TB with no-op Log/Logf
+// methods to create it.
+func (fz *Fuzzer) Fuzz(f *testing.F, methods []string, seeds ...[]byte) {
+	ms := fz.methods(f, methods)
+	if len(seeds) == 0 {
+		seeds = [][]byte{{}}
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fz.check(t, ms, data)
+	})
+}
+
+// Check invokes one of the specified methods (all ABI methods not starting
+// with an underscore if none given) with the arguments derived from data
+// exactly the same way Fuzz does it. It can be used to reproduce failures
+// found by the fuzzer in a regular test.
+func (fz *Fuzzer) Check(t testing.TB, methods []string, data []byte) {
+	fz.check(t, fz.methods(t, methods), data)
+}
+
+func (fz *Fuzzer) methods(t testing.TB, names []string) []manifest.Method {
+	var ms []manifest.Method
+	if len(names) == 0 {
+		for _, m := range fz.Manifest.ABI.Methods {
+			if len(m.Name) > 0 && m.Name[0] != '_' {
+				ms = append(ms, m)
+			}
+		}
+	}
+	for _, name := range names {
+		var found bool
+		for _, m := range fz.Manifest.ABI.Methods {
+			if m.Name == name {
+				ms = append(ms, m)
+				found = true
+			}
+		}
+		require.True(t, found, "method %s is missing from the manifest", name)
+	}
+	require.NotEqual(t, 0, len(ms), "no methods to fuzz")
+	return ms
+}
+
+func (fz *Fuzzer) check(t testing.TB, ms []manifest.Method, data []byte) {
+	var m = ms[0]
+	if len(ms) > 1 && len(data) > 0 {
+		m = ms[int(data[0])%len(ms)]
+		data = data[1:]
+	}
+	args := FuzzArgs(m.Parameters, data)
+
+	var (
+		exception string
+		stack     []stackitem.Item
+	)
+	if fz.Persist {
+		tx := fz.Invoker.PrepareInvoke(t, m.Name, args...)
+		fz.Invoker.AddNewBlock(t, tx)
+		aer := fz.Invoker.GetTxExecResult(t, tx.Hash())
+		if aer.VMState != vmstate.Halt {
+			exception = aer.FaultException
+		}
+		stack = aer.Stack
+	} else {
+		s, err := fz.Invoker.TestInvoke(t, m.Name, args...)
+		if err != nil {
+			exception = err.Error()
+		} else {
+			stack = s.ToArray()
+		}
+	}
+	if len(exception) != 0 {
+		if fz.IsExpectedFault == nil || !fz.IsExpectedFault(m.Name, args, exception) {
+			t.Fatalf("unexpected FAULT of %s(%s): %s", m.Name, formatFuzzArgs(args), exception)
+		}
+		return
+	}
+	for _, inv := range fz.Invariants {
+		if err := inv(m.Name, args, stack); err != nil {
+			t.Fatalf("invariant violated by %s(%s): %s", m.Name, formatFuzzArgs(args), err)
+		}
+	}
+}
+
+// FuzzArgs deterministically derives method arguments of the specified types
+// from the fuzzing input. Any input (including an empty one) produces a valid
+// set of arguments: integers, byte arrays, strings, hashes, public keys,
+// signatures, arrays and maps of limited size. The values returned are
+// suitable for ContractInvoker methods.
+func FuzzArgs(params []manifest.Parameter, data []byte) []any {
+	var (
+		d    = &fuzzData{b: data}
+		args = make([]any, len(params))
+	)
+	for i := range params {
+		args[i] = d.arg(params[i].Type, 0)
+	}
+	return args
+}
+
+func (d *fuzzData) byte() byte {
+	if len(d.b) == 0 {
+		return 0
+	}
+	b := d.b[0]
+	d.b = d.b[1:]
+	return b
+}
+
+func (d *fuzzData) bytes(n int) []byte {
+	res := make([]byte, n)
+	copy(res, d.b)
+	if n > len(d.b) {
+		n = len(d.b)
+	}
+	d.b = d.b[n:]
+	return res
+}
+
+func (d *fuzzData) arg(typ smartcontract.ParamType, depth int) any {
+	switch typ {
+	case smartcontract.BoolType:
+		return d.byte()&1 == 1
+	case smartcontract.IntegerType:
+		return bigint.FromBytes(d.bytes(int(d.byte()) % (bigint.MaxBytesLen + 1)))
+	case smartcontract.ByteArrayType:
+		return d.bytes(int(d.byte()) % (maxFuzzLen + 1))
+	case smartcontract.StringType:
+		return string(d.bytes(int(d.byte()) % (maxFuzzLen + 1)))
+	case smartcontract.Hash160Type:
+		u, _ := util.Uint160DecodeBytesBE(d.bytes(util.Uint160Size))
+		return u
+	case smartcontract.Hash256Type:
+		u, _ := util.Uint256DecodeBytesBE(d.bytes(util.Uint256Size))
+		return u
+	case smartcontract.PublicKeyType:
+		b := d.bytes(32)
+		// Valid keys are much more interesting, but invalid ones are allowed too.
+		if pk, err := keys.NewPrivateKeyFromBytes(b); err == nil {
+			return pk.PublicKey().Bytes()
+		}
+		return append([]byte{0x02}, b...)
+	case smartcontract.SignatureType:
+		return d.bytes(keys.SignatureLen)
+	case smartcontract.ArrayType:
+		n := int(d.byte()) % (maxFuzzElements + 1)
+		arr := make([]any, n)
+		for i := range arr {
+			arr[i] = d.arg(smartcontract.AnyType, depth+1)
+		}
+		return arr
+	case smartcontract.MapType:
+		n := int(d.byte()) % (maxFuzzElements + 1)
+		m := stackitem.NewMap()
+		for i := 0; i < n; i++ {
+			k := stackitem.NewByteArray(d.bytes(int(d.byte()) % (maxFuzzLen + 1)))
+			m.Add(k, stackitem.Make(d.arg(smartcontract.AnyType, depth+1)))
+		}
+		return m
+	case smartcontract.AnyType:
+		kinds := []smartcontract.ParamType{smartcontract.AnyType, smartcontract.BoolType,
+			smartcontract.IntegerType, smartcontract.ByteArrayType, smartcontract.Hash160Type}
+		if depth < maxFuzzDepth {
+			kinds = append(kinds, smartcontract.ArrayType, smartcontract.MapType)
+		}
+		k := kinds[int(d.byte())%len(kinds)]
+		if k == smartcontract.AnyType {
+			return nil
+		}
+		return d.arg(k, depth)
+	default: // InteropInterface and Void can't be passed via script.
+		return nil
+	}
+}
+
+func formatFuzzArgs(args []any) string {
+	var s string
+	for i, a := range args {
+		if i > 0 {
+			s += ", "
+		}
+		switch v := a.(type) {
+		case []byte:
+			s += fmt.Sprintf("%x", v)
+		case *big.Int:
+			s += v.String()
+		case stackitem.Item:
+			s += fmt.Sprintf("%v", v.Value())
+		default:
+			s += fmt.Sprintf("%v", v)
+		}
+	}
+	return s
+}
@@ -0,0 +1,144 @@
+package neotest_test
+
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+const fuzzSrc = `package foo
+func Div(a, b int) int {
+	if b == 0 {
+		panic("zero divisor")
+	}
+	return a / b
+}
+func Concat(a []byte, s string) []byte {
+	return append(a, []byte(s)...)
+}
+func Check(h []byte, arr []any) bool {
+	return len(h) == 20 && len(arr) < 5
+}`
+
+func TestFuzzArgs(t *testing.T) {
+	params := []manifest.Parameter{
+		manifest.NewParameter("b", smartcontract.BoolType),
+		manifest.NewParameter("i", smartcontract.IntegerType),
+		manifest.NewParameter("s", smartcontract.StringType),
+		manifest.NewParameter("h", smartcontract.Hash160Type),
+		manifest.NewParameter("k", smartcontract.PublicKeyType),
+		manifest.NewParameter("a", smartcontract.ArrayType),
+		manifest.NewParameter("m", smartcontract.MapType),
+		manifest.NewParameter("x", smartcontract.AnyType),
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		args := neotest.FuzzArgs(params, nil)
+		require.Equal(t, 8, len(args))
+		require.Equal(t, false, args[0])
+		require.Equal(t, big.NewInt(0), args[1])
+		require.Equal(t, "", args[2])
+		require.Equal(t, util.Uint160{}, args[3])
+		require.Equal(t, []any{}, args[5])
+		require.Nil(t, args[7])
+	})
+	t.Run("deterministic", func(t *testing.T) {
+		data := []byte{1, 2, 0x39, 0x05, 3, 'a', 'b', 'c', 0xff, 0xfe}
+		args := neotest.FuzzArgs(params, data)
+		require.Equal(t, args, neotest.FuzzArgs(params, data))
+		require.Equal(t, true, args[0])
+		require.Equal(t, big.NewInt(1337), args[1])
+		require.Equal(t, "abc", args[2])
+		require.Equal(t, util.Uint160{0xff, 0xfe}, args[3])
+		require.Equal(t, 33, len(args[4].([]byte)))
+		_, ok := args[6].(*stackitem.Map)
+		require.True(t, ok)
+	})
+}
+
+func newFuzzer(t testing.TB) *neotest.Fuzzer {
+	bc, acc := chain.NewSingle(quietTB{t})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(fuzzSrc), &compiler.Options{Name: "Fuzzed"})
+	e.DeployContract(t, ctr, nil)
+	return e.CommitteeInvoker(ctr.Hash).NewFuzzer(ctr.Manifest)
+}
+
+func TestFuzzer_Check(t *testing.T) {
+	fz := newFuzzer(t)
+
+	// Unexpected FAULT.
+	require.True(t, fails(t, func(tb testing.TB) { fz.Check(tb, []string{"div"}, nil) }))
+
+	fz.IsExpectedFault = func(method string, args []any, exception string) bool {
+		return strings.Contains(exception, "zero divisor")
+	}
+	fz.Check(t, []string{"div"}, nil)
+	fz.Check(t, []string{"div"}, []byte{1, 10, 1, 3})
+
+	// Invariant violation.
+	fz.Invariants = append(fz.Invariants, func(method string, args []any, stack []stackitem.Item) error {
+		if method == "check" && stack[0].Value() != true {
+			return errors.New("check failed")
+		}
+		return nil
+	})
+	fz.Check(t, []string{"check"}, []byte{20})
+	require.True(t, fails(t, func(tb testing.TB) { fz.Check(tb, []string{"check"}, []byte{19}) }))
+
+	// Method selector.
+	fz.Invariants = nil
+	fz.Check(t, nil, []byte{0, 1, 2})
+	fz.Persist = true
+	fz.Check(t, []string{"div", "concat"}, []byte{1, 2, 'a', 'b', 1, 'c'})
+}
+
+// failTB intercepts test failures.
+type failTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *failTB) Errorf(string, ...any) { f.failed = true }
+func (f *failTB) Fatalf(string, ...any) { f.failed = true; runtime.Goexit() }
+func (f *failTB) FailNow()              { f.failed = true; runtime.Goexit() }
+
+// fails runs f in a separate goroutine (as it can call FailNow) and returns
+// whether it has failed.
+func fails(t *testing.T, f func(tb testing.TB)) bool {
+	var (
+		tb   = &failTB{TB: t}
+		done = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		f(tb)
+	}()
+	<-done
+	return tb.failed
+}
+
+func FuzzFuzzer(f *testing.F) {
+	fz := newFuzzer(f)
+	fz.IsExpectedFault = func(method string, args []any, exception string) bool {
+		return method == "div" && strings.Contains(exception, "zero divisor")
+	}
+	fz.Fuzz(f, nil, []byte{0, 1, 10, 1, 3}, []byte{1, 3, 1, 2, 3, 1, 'x'})
+}
+
+// quietTB drops chain logs that break fuzzing.
+type quietTB struct{ testing.TB }
+
+func (quietTB) Logf(string, ...any) {}
+func (quietTB) Log(...any)          {}
@@ -0,0 +1,59 @@
+package neotest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// Snapshot captures the current chain state and returns its identifier that
+// can be used to Revert to this state later. It allows to share a deployed
+// fixture between several test cases without rebuilding the chain. Mempool
+// contents is not a part of the snapshot.
+func (e *Executor) Snapshot(t testing.TB) int {
+	st := storage.NewMemoryStore()
+	require.NoError(t, e.Chain.CopyStorage(st))
+	e.snapshots = append(e.snapshots, st)
+	return len(e.snapshots) - 1
+}
+
+// Revert rolls the chain back to the state captured by Snapshot with the given
+// identifier. The snapshot is left intact, so it's possible to revert to it
+// any number of times. Revert replaces Executor's Chain with a new instance
+// (every ContractInvoker created from this Executor uses it as well), this
+// chain is closed when t finishes or on the next Revert.
+func (e *Executor) Revert(t testing.TB, id int) {
+	require.True(t, id >= 0 && id < len(e.snapshots), "unknown snapshot %d", id)
+
+	// Snapshot is never changed, all the new chain data is kept in the
+	// upper layer.
+	st := snapshotLayer{storage.NewMemCachedStore(e.snapshots[id])}
+	bc, err := core.NewBlockchain(st, e.Chain.GetConfig(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	go bc.Run()
+
+	var once sync.Once
+	closeChain := func() { once.Do(bc.Close) }
+	t.Cleanup(closeChain)
+	if e.closeChain != nil {
+		e.closeChain()
+	}
+	e.closeChain = closeChain
+	e.Chain = bc
+}
+
+// snapshotLayer is a MemCachedStore over the snapshot that is never persisted
+// and doesn't close the snapshot.
+type snapshotLayer struct {
+	*storage.MemCachedStore
+}
+
+// Close implements the storage.Store interface, it drops the layer contents
+// only.
+func (s snapshotLayer) Close() error {
+	return s.MemoryStore.Close()
+}
@@ -0,0 +1,54 @@
+package neotest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/stretchr/testify/require"
+)
+
+const snapshotSrc = `package foo
+import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+func Put(v int) {
+	storage.Put(storage.GetContext(), "key", v)
+}
+func Get() int {
+	return storage.Get(storage.GetReadOnlyContext(), "key").(int)
+}`
+
+func TestExecutor_SnapshotRevert(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(snapshotSrc), &compiler.Options{Name: "Snapshot"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+	c.Invoke(t, nil, "put", 1)
+
+	height := e.Chain.BlockHeight()
+	id := e.Snapshot(t)
+
+	for i := 2; i < 5; i++ {
+		c.Invoke(t, nil, "put", i)
+		c.Invoke(t, i, "get")
+		e.Revert(t, id)
+		require.Equal(t, height, e.Chain.BlockHeight())
+		c.Invoke(t, 1, "get")
+	}
+
+	// Nested snapshot.
+	c.Invoke(t, nil, "put", 10)
+	id2 := e.Snapshot(t)
+	c.Invoke(t, nil, "put", 20)
+	e.Revert(t, id2)
+	c.Invoke(t, 10, "get")
+	e.Revert(t, id)
+	c.Invoke(t, 1, "get")
+
+	t.Run("subtest", func(t *testing.T) {
+		e.Revert(t, id2)
+		c.Invoke(t, 10, "get")
+	})
+}
@@ -0,0 +1,96 @@
+package neotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+// blockOverrides are header fields pinned for the block with the given index.
+// They're used by NewUnsignedBlock for every block (including dummy blocks
+// of test invocations) with this index and become stale once this block is
+// added to the chain.
+type blockOverrides struct {
+	index     uint32
+	timestamp *uint64
+	nonce     *uint64
+	prevHash  *util.Uint256
+}
+
+// nextBlock returns overrides for the next block resetting stale ones.
+func (e *Executor) nextBlock() *blockOverrides {
+	idx := e.Chain.BlockHeight() + 1
+	if e.next == nil || e.next.index != idx {
+		e.next = &blockOverrides{index: idx}
+	}
+	return e.next
+}
+
+// SetNextBlockTime sets the timestamp (in milliseconds) of the next block
+// created by NewUnsignedBlock, it applies to test invocations as well. The
+// timestamp must be bigger than the one of the current top block for the block
+// to be accepted by the chain. Subsequent blocks continue from this timestamp.
+func (e *Executor) SetNextBlockTime(ts uint64) {
+	e.nextBlock().timestamp = &ts
+}
+
+// AdvanceTime sets the timestamp of the next block to the current top block
+// timestamp plus d, it's a convenient wrapper over SetNextBlockTime.
+func (e *Executor) AdvanceTime(t testing.TB, d time.Duration) {
+	require.True(t, d > 0, "non-positive duration")
+	e.SetNextBlockTime(e.TopBlock(t).Timestamp + uint64(d.Milliseconds()))
+}
+
+// SetNextBlockNonce sets the nonce of the next block created by
+// NewUnsignedBlock.
+func (e *Executor) SetNextBlockNonce(nonce uint64) {
+	e.nextBlock().nonce = &nonce
+}
+
+// SetNextBlockPrevHash sets the previous block hash of the next block created
+// by NewUnsignedBlock. Blocks with a hash not matching the current top block
+// are rejected by the chain, so it's mostly useful for test invocations and
+// negative tests.
+func (e *Executor) SetNextBlockPrevHash(h util.Uint256) {
+	e.nextBlock().prevHash = &h
+}
+
+// applyOverrides sets pinned header fields of b if there are any.
+func (e *Executor) applyOverrides(b *block.Block) {
+	if e.next == nil || e.next.index != b.Index {
+		return
+	}
+	if e.next.timestamp != nil {
+		b.Timestamp = *e.next.timestamp
+	}
+	if e.next.nonce != nil {
+		b.Nonce = *e.next.nonce
+	}
+	if e.next.prevHash != nil {
+		b.PrevHash = *e.next.prevHash
+	}
+}
+
+// FastForward adds the specified number of empty blocks to the chain and
+// returns the last one. Unlike GenerateNewBlocks it doesn't sign blocks if the
+// chain doesn't verify them (see SkipBlockVerification configuration option),
+// which makes it much cheaper for long block sequences. Block timestamps are
+// incremented by a millisecond (starting from the one set by SetNextBlockTime
+// or AdvanceTime if any).
+func (e *Executor) FastForward(t testing.TB, count int) *block.Block {
+	var (
+		b    *block.Block
+		sign = !e.Chain.GetConfig().SkipBlockVerification
+	)
+	for i := 0; i < count; i++ {
+		b = e.NewUnsignedBlock(t)
+		if sign {
+			e.SignBlock(b)
+		}
+		require.NoError(t, e.Chain.AddBlock(b))
+	}
+	return b
+}
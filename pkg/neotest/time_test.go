@@ -0,0 +1,63 @@
+package neotest_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+const timeSrc = `package foo
+import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+func Time() int {
+	return runtime.GetTime()
+}`
+
+func TestExecutor_BlockTime(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(timeSrc), &compiler.Options{Name: "Time"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+
+	top := e.TopBlock(t)
+	ts := top.Timestamp + uint64(time.Hour.Milliseconds())
+	e.AdvanceTime(t, time.Hour)
+	e.SetNextBlockNonce(42)
+
+	// Test invocations see pinned values.
+	c.Invoke(t, ts, "time")
+	b := e.TopBlock(t)
+	require.Equal(t, ts, b.Timestamp)
+	require.Equal(t, uint64(42), b.Nonce)
+
+	// Overrides are applied only once.
+	c.Invoke(t, ts+1, "time")
+	require.Equal(t, uint64(0), e.TopBlock(t).Nonce)
+
+	e.SetNextBlockTime(ts + 1000)
+	b = e.FastForward(t, 10)
+	require.Equal(t, ts+1009, b.Timestamp)
+	require.Equal(t, e.Chain.BlockHeight(), b.Index)
+
+	e.SetNextBlockPrevHash(util.Uint256{1, 2, 3})
+	require.Equal(t, util.Uint256{1, 2, 3}, e.NewUnsignedBlock(t).PrevHash)
+	require.Error(t, e.Chain.AddBlock(e.SignBlock(e.NewUnsignedBlock(t))))
+}
+
+func TestExecutor_FastForwardUnsigned(t *testing.T) {
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.SkipBlockVerification = true
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	h := e.Chain.BlockHeight()
+	b := e.FastForward(t, 1000)
+	require.Equal(t, h+1000, b.Index)
+	require.Nil(t, b.Script.InvocationScript)
+}
@@ -303,8 +303,12 @@ func (a *Actor) SendRequestExactly(mainTx *transaction.Transaction, fbTx *transa
 		MainTransaction:     mainTx,
 		FallbackTransaction: fbTx,
 	}
+	sig, err := a.sender.SignHashableWithError(a.GetNetwork(), req)
+	if err != nil {
+		return mainHash, fbHash, vub, fmt.Errorf("failed to sign notary request: %w", err)
+	}
 	req.Witness = transaction.Witness{
-		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, a.sender.SignHashable(a.GetNetwork(), req)...),
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sig...),
 		VerificationScript: a.sender.GetVerificationScript(),
 	}
 	actualHash, err := a.rpc.SubmitP2PNotaryRequest(req)
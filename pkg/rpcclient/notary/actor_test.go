@@ -340,6 +340,24 @@ func TestSendRequestExactly(t *testing.T) {
 	require.Equal(t, mainTx.Hash(), mHash)
 	require.Equal(t, fbTx.Hash(), fbHash)
 	require.Equal(t, mainTx.ValidUntilBlock, vub)
+
+	// Signer failure.
+	acc0.Close()
+	acc0.SetSigner(failingSigner{key0.PublicKey()})
+	_, _, _, err = act.SendRequestExactly(mainTx, fbTx)
+	require.ErrorContains(t, err, "signer failure")
+}
+
+type failingSigner struct {
+	pub *keys.PublicKey
+}
+
+func (s failingSigner) PublicKey() *keys.PublicKey {
+	return s.pub
+}
+
+func (s failingSigner) SignHash(util.Uint256) ([]byte, error) {
+	return nil, errors.New("service unavailable")
 }
 
 func TestSendRequest(t *testing.T) {
@@ -725,6 +725,31 @@ func (c *Client) InvokeScriptWithState(stateOrBlock util.Uint256, script []byte,
 	return c.invokeSomething("invokescripthistoric", p, signers)
 }
 
+// InvokeScriptWithOverrides returns the result of the given script after
+// running it true the VM using the current chain state with the specified
+// contract scripts and storage items overridden.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithOverrides(script []byte, override *neorpc.StateOverride, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = []any{script, override}
+	return c.invokeSomething("invokescriptwithoverrides", p, signers)
+}
+
+// TraceTransaction re-executes the transaction with the specified hash using
+// the chain state it was originally executed with (including all preceding
+// transactions of the same block) and returns the invocation result. Optional
+// state override is applied right before the transaction execution.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) TraceTransaction(hash util.Uint256, override *neorpc.StateOverride) (*result.Invoke, error) {
+	var (
+		p    = []any{hash.StringLE(), override}
+		resp = new(result.Invoke)
+	)
+	if err := c.performRequest("tracetransaction", p, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // InvokeFunction returns the results after calling the smart contract scripthash
 // with the given operation and parameters.
 // NOTE: this is test invoke and will not affect the blockchain.
@@ -2414,6 +2414,68 @@ func TestClient_GetStorageHistoric(t *testing.T) {
 	require.ErrorIs(t, neorpc.ErrUnknownStorageItem, err)
 }
 
+func TestClient_TraceTransaction(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	// Every transaction should be reproduced exactly as it was executed.
+	for i := uint32(1); i <= chain.BlockHeight(); i++ {
+		b, err := chain.GetBlock(chain.GetHeaderHash(i))
+		require.NoError(t, err)
+		for _, tx := range b.Transactions {
+			aers, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+			require.NoError(t, err)
+			res, err := c.TraceTransaction(tx.Hash(), nil)
+			require.NoError(t, err)
+			require.Equal(t, aers[0].VMState.String(), res.State, tx.Hash().StringLE())
+			require.Equal(t, aers[0].GasConsumed, res.GasConsumed, tx.Hash().StringLE())
+			require.Equal(t, len(aers[0].Events), len(res.Notifications), tx.Hash().StringLE())
+		}
+	}
+
+	_, err = c.TraceTransaction(util.Uint256{1, 2, 3}, nil)
+	require.ErrorIs(t, err, neorpc.ErrUnknownTransaction)
+}
+
+func TestClient_InvokeScriptWithOverrides(t *testing.T) {
+	_, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	acc := util.Uint160{1, 2, 3}
+	script, err := smartcontract.CreateCallScript(h, "balanceOf", acc)
+	require.NoError(t, err)
+
+	res, err := c.InvokeScriptWithOverrides(script, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "HALT", res.State)
+	require.Equal(t, big.NewInt(0), res.Stack[0].Value())
+
+	res, err = c.InvokeScriptWithOverrides(script, &neorpc.StateOverride{
+		Contracts: []neorpc.ContractOverride{{
+			Hash:    h,
+			Storage: []neorpc.StorageOverride{{Key: acc.BytesBE(), Value: []byte{0x39, 0x05}}},
+		}},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "HALT", res.State)
+	require.Equal(t, big.NewInt(1337), res.Stack[0].Value())
+
+	// Overrides are not persisted.
+	res, err = c.InvokeScript(script, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), res.Stack[0].Value())
+}
+
 func TestClient_GetVersion_Hardforks(t *testing.T) {
 	_, _, httpSrv := initServerWithInMemoryChain(t)
 
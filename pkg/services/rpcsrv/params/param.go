@@ -413,6 +413,20 @@ func (p *Param) GetSignerWithWitness() (neorpc.SignerWithWitness, error) {
 	return c, nil
 }
 
+// GetStateOverride returns a neorpc.StateOverride value of the parameter. JSON
+// null is treated as no override and nil is returned for it.
+func (p *Param) GetStateOverride() (*neorpc.StateOverride, error) {
+	if p == nil || p.IsNull() {
+		return nil, nil
+	}
+	o := new(neorpc.StateOverride)
+	err := json.Unmarshal(p.RawMessage, o)
+	if err != nil {
+		return nil, fmt.Errorf("not a state override: %w", err)
+	}
+	return o, nil
+}
+
 // GetSignersWithWitnesses returns a slice of SignerWithWitness with CalledByEntry
 // scope from an array of Uint160 or an array of serialized transaction.Signer stored
 // in the parameter.
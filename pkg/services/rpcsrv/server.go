@@ -93,6 +93,7 @@ type (
 		GetStorageItem(id int32, key []byte) state.StorageItem
 		GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32) (*interop.Context, error)
 		GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*interop.Context, error)
+		GetTracedTxVM(h util.Uint256) (*interop.Context, error)
 		GetTokenLastUpdated(acc util.Uint160) (map[int32]uint32, error)
 		GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
 		HeaderHeight() uint32
@@ -249,6 +250,7 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"invokefunctionhistoric":       (*Server).invokeFunctionHistoric,
 	"invokescript":                 (*Server).invokescript,
 	"invokescripthistoric":         (*Server).invokescripthistoric,
+	"invokescriptwithoverrides":    (*Server).invokeScriptWithOverrides,
 	"invokecontractverify":         (*Server).invokeContractVerify,
 	"invokecontractverifyhistoric": (*Server).invokeContractVerifyHistoric,
 	"sendrawtransaction":           (*Server).sendrawtransaction,
@@ -256,6 +258,7 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"submitnotaryrequest":          (*Server).submitNotaryRequest,
 	"submitoracleresponse":         (*Server).submitOracleResponse,
 	"terminatesession":             (*Server).terminateSession,
+	"tracetransaction":             (*Server).traceTransaction,
 	"traverseiterator":             (*Server).traverseIterator,
 	"validateaddress":              (*Server).validateAddress,
 	"verifyproof":                  (*Server).verifyProof,
@@ -2135,7 +2138,7 @@ func (s *Server) invokeFunction(reqParams params.Params) (any, *neorpc.Error) {
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose, nil)
 }
 
 // invokeFunctionHistoric implements the `invokeFunctionHistoric` RPC call.
@@ -2151,7 +2154,7 @@ func (s *Server) invokeFunctionHistoric(reqParams params.Params) (any, *neorpc.E
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose, nil)
 }
 
 func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
@@ -2202,7 +2205,7 @@ func (s *Server) invokescript(reqParams params.Params) (any, *neorpc.Error) {
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose, nil)
 }
 
 // invokescripthistoric implements the `invokescripthistoric` RPC call.
@@ -2218,7 +2221,62 @@ func (s *Server) invokescripthistoric(reqParams params.Params) (any, *neorpc.Err
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose, nil)
+}
+
+// invokeScriptWithOverrides implements the `invokescriptwithoverrides` RPC call.
+func (s *Server) invokeScriptWithOverrides(reqParams params.Params) (any, *neorpc.Error) {
+	if len(reqParams) < 2 {
+		return nil, neorpc.ErrInvalidParams
+	}
+	override, err := reqParams[1].GetStateOverride()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	tx, verbose, respErr := s.getInvokeScriptParams(append(params.Params{reqParams[0]}, reqParams[2:]...))
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose, override)
+}
+
+// traceTransaction implements the `tracetransaction` RPC call.
+func (s *Server) traceTransaction(reqParams params.Params) (any, *neorpc.Error) {
+	if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("only latest state is supported: %s", errKeepOnlyLatestState))
+	}
+	txHash, err := reqParams.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.ErrInvalidParams
+	}
+	override, err := reqParams.Value(1).GetStateOverride()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	var verbose bool
+	if len(reqParams) > 2 {
+		verbose, err = reqParams[2].GetBoolean()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+	}
+	tx, height, err := s.chain.GetTransaction(txHash)
+	if err != nil || height == math.MaxUint32 {
+		return nil, neorpc.ErrUnknownTransaction
+	}
+	ic, err := s.chain.GetTracedTxVM(txHash)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to create traced VM: %s", err))
+	}
+	respErr := applyStateOverride(ic, override)
+	if respErr != nil {
+		return nil, respErr
+	}
+	if verbose {
+		ic.VM.EnableInvocationTree()
+	}
+	ic.VM.LoadScriptWithFlags(tx.Script, callflag.All)
+	return s.runInvocationContext(ic, tx.Script, nil)
 }
 
 func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
@@ -2256,7 +2314,7 @@ func (s *Server) invokeContractVerify(reqParams params.Params) (any, *neorpc.Err
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, false)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, false, nil)
 }
 
 // invokeContractVerifyHistoric implements the `invokecontractverifyhistoric` RPC call.
@@ -2272,7 +2330,7 @@ func (s *Server) invokeContractVerifyHistoric(reqParams params.Params) (any, *ne
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, &nextH, false)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, &nextH, false, nil)
 }
 
 func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Uint160, *transaction.Transaction, []byte, *neorpc.Error) {
@@ -2342,7 +2400,7 @@ func (s *Server) getHistoricParams(reqParams params.Params) (uint32, *neorpc.Err
 	return height + 1, nil
 }
 
-func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*interop.Context, *neorpc.Error) {
+func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool, override *neorpc.StateOverride) (*interop.Context, *neorpc.Error) {
 	var (
 		err error
 		ic  *interop.Context
@@ -2358,6 +2416,10 @@ func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contrac
 			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to create historic VM: %s", err))
 		}
 	}
+	respErr := applyStateOverride(ic, override)
+	if respErr != nil {
+		return nil, respErr
+	}
 	if verbose {
 		ic.VM.EnableInvocationTree()
 	}
@@ -2387,16 +2449,70 @@ func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contrac
 	return ic, nil
 }
 
+// applyStateOverride puts overridden contract scripts and storage items into
+// the DAO of the given interop context. Changes made by the subsequent
+// invocation are kept in a separate DAO layer, so that they can be told apart
+// from the overrides.
+func applyStateOverride(ic *interop.Context, override *neorpc.StateOverride) *neorpc.Error {
+	if override == nil {
+		return nil
+	}
+	for _, c := range override.Contracts {
+		cs, err := native.GetContract(ic.DAO, c.Hash)
+		if err != nil {
+			return neorpc.WrapErrorWithData(neorpc.ErrUnknownContract, fmt.Sprintf("%s: %s", c.Hash.StringLE(), err))
+		}
+		if c.Script != nil {
+			if cs.ID < 0 {
+				return neorpc.NewInvalidParamsError(fmt.Sprintf("can't override native contract %s script", c.Hash.StringLE()))
+			}
+			ovr := *cs
+			ovr.NEF.Script = c.Script
+			ovr.NEF.Checksum = ovr.NEF.CalculateChecksum()
+			err = native.PutContractState(ic.DAO, &ovr)
+			if err != nil {
+				return neorpc.NewInternalServerError(fmt.Sprintf("failed to override contract %s: %s", c.Hash.StringLE(), err))
+			}
+		}
+		for _, item := range c.Storage {
+			if item.Value == nil {
+				ic.DAO.DeleteStorageItem(cs.ID, item.Key)
+			} else {
+				ic.DAO.PutStorageItem(cs.ID, item.Key, item.Value)
+			}
+		}
+	}
+	ic.DAO = ic.DAO.GetPrivate()
+	return nil
+}
+
 // runScriptInVM runs the given script in a new test VM and returns the invocation
 // result. The script is either a simple script in case of `application` trigger,
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
-// contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*result.Invoke, *neorpc.Error) {
-	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, nextH, verbose)
+// contractScriptHash should be specified. Optional state override is applied
+// to the state before the invocation.
+func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool, override *neorpc.StateOverride) (*result.Invoke, *neorpc.Error) {
+	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, nextH, verbose, override)
 	if respErr != nil {
 		return nil, respErr
 	}
+	var rerun func() (*result.Invoke, *neorpc.Error)
+	// nextH == nil only when we're not using MPT-backed storage, therefore
+	// the second attempt won't be rerun.
+	if s.config.SessionBackedByMPT && nextH == nil {
+		rerun = func() (*result.Invoke, *neorpc.Error) {
+			return s.runScriptInVM(t, script, contractScriptHash, tx, &ic.Block.Index, verbose, override)
+		}
+	}
+	return s.runInvocationContext(ic, script, rerun)
+}
+
+// runInvocationContext runs the script loaded into the given interop context
+// and returns the invocation result. If iterator session is to be created,
+// then non-nil rerun is called instead to retry the invocation with
+// MPT-backed storage.
+func (s *Server) runInvocationContext(ic *interop.Context, script []byte, rerun func() (*result.Invoke, *neorpc.Error)) (*result.Invoke, *neorpc.Error) {
 	err := ic.VM.Run()
 	var faultException string
 	if err != nil {
@@ -2407,12 +2523,10 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 	var id uuid.UUID
 
 	if sess != nil {
-		// nextH == nil only when we're not using MPT-backed storage, therefore
-		// the second attempt won't stop here.
-		if s.config.SessionBackedByMPT && nextH == nil {
+		if rerun != nil {
 			ic.Finalize()
 			// Rerun with MPT-backed storage.
-			return s.runScriptInVM(t, script, contractScriptHash, tx, &ic.Block.Index, verbose)
+			return rerun()
 		}
 		id = uuid.New()
 		sessionID := id.String()
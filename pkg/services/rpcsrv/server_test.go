@@ -148,6 +148,14 @@ var rpcFunctionsWithUnsupportedStatesTestCases = map[string][]rpcTestCase{
 			errCode: neorpc.ErrUnsupportedStateCode,
 		},
 	},
+	"tracetransaction": {
+		{
+			name:    "unsupported state",
+			params:  `["` + deploymentTxHash + `"]`,
+			fail:    true,
+			errCode: neorpc.ErrUnsupportedStateCode,
+		},
+	},
 }
 
 var rpcTestCases = map[string][]rpcTestCase{
@@ -1866,6 +1874,120 @@ var rpcTestCases = map[string][]rpcTestCase{
 			errCode: neorpc.InvalidParamsCode,
 		},
 	},
+	"invokescriptwithoverrides": {
+		{
+			name: "positive, storage override",
+			params: func() string {
+				h, _ := util.Uint160DecodeStringLE(testContractHash)
+				acc := util.Uint160{1, 2, 3}
+				script, _ := smartcontract.CreateCallScript(h, "balanceOf", acc)
+				o := neorpc.StateOverride{Contracts: []neorpc.ContractOverride{{
+					Hash:    h,
+					Storage: []neorpc.StorageOverride{{Key: acc.BytesBE(), Value: []byte{0x39, 0x05}}},
+				}}}
+				bo, _ := json.Marshal(o)
+				return fmt.Sprintf(`["%s", %s]`, base64.StdEncoding.EncodeToString(script), bo)
+			}(),
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.Equal(t, "HALT", res.State)
+				require.Equal(t, 1, len(res.Stack))
+				require.Equal(t, big.NewInt(1337), res.Stack[0].Value())
+			},
+		},
+		{
+			name:   "positive, no override",
+			params: `["UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY=", null]`,
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.NotEqual(t, "", res.State)
+			},
+		},
+		{
+			name:    "no override",
+			params:  `["UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY="]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "bad override",
+			params:  `["UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY=", 42]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown contract",
+			params:  `["UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY=", {"contracts":[{"hash":"0x0000000000000000000000000000000000000001"}]}]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownContractCode,
+		},
+		{
+			name:    "native script",
+			params:  fmt.Sprintf(`["UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY=", {"contracts":[{"hash":"%s","script":"QA=="}]}]`, state.CreateNativeContractHash(nativenames.Neo).StringLE()),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
+	"tracetransaction": {
+		{
+			name:   "positive",
+			params: `["` + deploymentTxHash + `"]`,
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				h, err := util.Uint256DecodeStringLE(deploymentTxHash)
+				require.NoError(t, err)
+				aers, err := e.chain.GetAppExecResults(h, trigger.Application)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(aers))
+				require.Equal(t, aers[0].VMState.String(), res.State)
+				require.Equal(t, aers[0].GasConsumed, res.GasConsumed)
+				require.Equal(t, len(aers[0].Events), len(res.Notifications))
+				require.Nil(t, res.Diagnostics)
+			},
+		},
+		{
+			name:   "positive, verbose",
+			params: `["` + deploymentTxHash + `", null, true]`,
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.Equal(t, "HALT", res.State)
+				require.NotNil(t, res.Diagnostics)
+				require.NotEqual(t, 0, len(res.Diagnostics.Changes))
+			},
+		},
+		{
+			name:    "no params",
+			params:  `[]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid hash",
+			params:  `["notahex"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "unknown transaction",
+			params:  `["` + util.Uint256{1, 2, 3}.StringLE() + `"]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownTransactionCode,
+		},
+		{
+			name:    "bad override",
+			params:  `["` + deploymentTxHash + `", 42]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
 	"invokecontractverify": {
 		{
 			name:   "positive",
@@ -2,6 +2,7 @@ package context
 
 import (
 	"bytes"
+	"crypto/elliptic"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -12,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -166,6 +168,85 @@ func (c *ParameterContext) AddSignature(h util.Uint160, ctr *wallet.Contract, pu
 	return nil
 }
 
+// AddContract adds an item without signatures for the specified contract (if
+// there is no item for it yet). It allows to get the list of keys expected to
+// sign the item (for multisignature contracts) before collecting signatures.
+func (c *ParameterContext) AddContract(h util.Uint160, ctr *wallet.Contract) {
+	_ = c.getItemForContract(h, ctr)
+}
+
+// Merge adds signatures from the other context (that must be created for the
+// same verifiable item in the same network) to this one. All signatures
+// added are checked, an error is returned if any of them is invalid.
+func (c *ParameterContext) Merge(other *ParameterContext) error {
+	if c.Network != other.Network {
+		return fmt.Errorf("network mismatch: %s vs %s", c.Network, other.Network)
+	}
+	if !c.Verifiable.Hash().Equals(other.Verifiable.Hash()) {
+		return fmt.Errorf("verifiable item mismatch: %s vs %s", c.Verifiable.Hash().StringLE(), other.Verifiable.Hash().StringLE())
+	}
+	for h, oi := range other.Items {
+		item, ok := c.Items[h]
+		if !ok {
+			if oi.Script != nil && !hash.Hash160(oi.Script).Equals(h) {
+				return fmt.Errorf("script doesn't match %s", h.StringLE())
+			}
+			item = &Item{
+				Script:     oi.Script,
+				Parameters: make([]smartcontract.Parameter, len(oi.Parameters)),
+				Signatures: make(map[string][]byte),
+			}
+			for i := range oi.Parameters {
+				item.Parameters[i].Type = oi.Parameters[i].Type
+			}
+			c.Items[h] = item
+		}
+		if _, _, ok := vm.ParseMultiSigContract(item.Script); ok {
+			ctr := &wallet.Contract{
+				Script:     item.Script,
+				Parameters: make([]wallet.ContractParam, len(item.Parameters)),
+			}
+			for i := range item.Parameters {
+				ctr.Parameters[i].Type = item.Parameters[i].Type
+			}
+			for pubHex, sig := range oi.Signatures {
+				if item.Signatures[pubHex] != nil {
+					continue
+				}
+				pub, err := keys.NewPublicKeyFromString(pubHex)
+				if err != nil {
+					return fmt.Errorf("invalid public key %s: %w", pubHex, err)
+				}
+				if !pub.VerifyHashable(sig, uint32(c.Network), c.Verifiable) {
+					return fmt.Errorf("invalid signature for %s", pubHex)
+				}
+				if err := c.AddSignature(h, ctr, pub, sig); err != nil {
+					return fmt.Errorf("can't add signature for %s: %w", pubHex, err)
+				}
+			}
+			continue
+		}
+		if len(item.Parameters) != len(oi.Parameters) {
+			return fmt.Errorf("parameters mismatch for %s", h.StringLE())
+		}
+		var pub *keys.PublicKey
+		if pubBytes, ok := vm.ParseSignatureContract(item.Script); ok {
+			pub, _ = keys.NewPublicKeyFromBytes(pubBytes, elliptic.P256())
+		}
+		for i := range oi.Parameters {
+			if item.Parameters[i].Value != nil || oi.Parameters[i].Value == nil {
+				continue
+			}
+			if sig, ok := oi.Parameters[i].Value.([]byte); ok && pub != nil &&
+				!pub.VerifyHashable(sig, uint32(c.Network), c.Verifiable) {
+				return fmt.Errorf("invalid signature for %s", h.StringLE())
+			}
+			item.Parameters[i].Value = oi.Parameters[i].Value
+		}
+	}
+	return nil
+}
+
 func (c *ParameterContext) getItemForContract(h util.Uint160, ctr *wallet.Contract) *Item {
 	item, ok := c.Items[ctr.ScriptHash()]
 	if ok {
@@ -254,3 +335,160 @@ func (c *ParameterContext) UnmarshalJSON(data []byte) error {
 	c.Items = items
 	return nil
 }
+
+// Parameter value encoding types used in the binary context format.
+const (
+	binValueNil   byte = 0
+	binValueBytes byte = 1
+	binValueJSON  byte = 2
+)
+
+// binaryTransactionType is the binary format code of TransactionType.
+const binaryTransactionType byte = 0
+
+// EncodeBinary implements the io.Serializable interface. It's a compact
+// alternative to JSON representation (containing the same data) that is
+// mostly useful for constrained transports (like QR codes). Only transaction
+// contexts are supported.
+func (c *ParameterContext) EncodeBinary(w *io.BinWriter) {
+	if c.Type != TransactionType && c.Type != compatTransactionType {
+		w.Err = fmt.Errorf("unsupported type: %s", c.Type)
+		return
+	}
+	verif, err := c.Verifiable.EncodeHashableFields()
+	if err != nil {
+		w.Err = fmt.Errorf("failed to encode hashable fields: %w", err)
+		return
+	}
+	w.WriteB(binaryTransactionType)
+	w.WriteU32LE(uint32(c.Network))
+	w.WriteVarBytes(verif)
+
+	hashes := make([]util.Uint160, 0, len(c.Items))
+	for h := range c.Items {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Less(hashes[j]) })
+	w.WriteVarUint(uint64(len(hashes)))
+	for _, h := range hashes {
+		item := c.Items[h]
+		w.WriteBytes(h[:])
+		w.WriteVarBytes(item.Script)
+		w.WriteVarUint(uint64(len(item.Parameters)))
+		for i := range item.Parameters {
+			encodeParameter(w, &item.Parameters[i])
+		}
+		pubs := make([]string, 0, len(item.Signatures))
+		for k := range item.Signatures {
+			pubs = append(pubs, k)
+		}
+		sort.Strings(pubs)
+		w.WriteVarUint(uint64(len(pubs)))
+		for _, k := range pubs {
+			pub, err := hex.DecodeString(k)
+			if err != nil {
+				w.Err = fmt.Errorf("invalid public key %s: %w", k, err)
+				return
+			}
+			w.WriteVarBytes(pub)
+			w.WriteVarBytes(item.Signatures[k])
+		}
+	}
+}
+
+func encodeParameter(w *io.BinWriter, p *smartcontract.Parameter) {
+	switch v := p.Value.(type) {
+	case nil:
+		w.WriteB(byte(p.Type))
+		w.WriteB(binValueNil)
+	case []byte:
+		w.WriteB(byte(p.Type))
+		w.WriteB(binValueBytes)
+		w.WriteVarBytes(v)
+	default:
+		data, err := json.Marshal(p)
+		if err != nil {
+			w.Err = err
+			return
+		}
+		w.WriteB(byte(p.Type))
+		w.WriteB(binValueJSON)
+		w.WriteVarBytes(data)
+	}
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (c *ParameterContext) DecodeBinary(r *io.BinReader) {
+	if typ := r.ReadB(); r.Err == nil && typ != binaryTransactionType {
+		r.Err = fmt.Errorf("unsupported type: %d", typ)
+		return
+	}
+	c.Type = TransactionType
+	c.Network = netmode.Magic(r.ReadU32LE())
+	data := r.ReadVarBytes()
+	if r.Err != nil {
+		return
+	}
+	tx := new(transaction.Transaction)
+	if err := tx.DecodeHashableFields(data); err != nil {
+		r.Err = err
+		return
+	}
+	c.Verifiable = tx
+
+	n := r.ReadVarUint()
+	if n > transaction.MaxAttributes {
+		r.Err = errors.New("too many items")
+		return
+	}
+	c.Items = make(map[util.Uint160]*Item, n)
+	for i := 0; i < int(n) && r.Err == nil; i++ {
+		var h util.Uint160
+		r.ReadBytes(h[:])
+		item := &Item{
+			Script:     r.ReadVarBytes(),
+			Signatures: make(map[string][]byte),
+		}
+		if len(item.Script) == 0 {
+			item.Script = nil
+		}
+		np := r.ReadVarUint()
+		if np > vm.MaxMultisigKeys {
+			r.Err = errors.New("too many parameters")
+			return
+		}
+		item.Parameters = make([]smartcontract.Parameter, np)
+		for j := range item.Parameters {
+			decodeParameter(r, &item.Parameters[j])
+		}
+		ns := r.ReadVarUint()
+		if ns > vm.MaxMultisigKeys {
+			r.Err = errors.New("too many signatures")
+			return
+		}
+		for j := 0; j < int(ns) && r.Err == nil; j++ {
+			pub := r.ReadVarBytes(33) // Compressed public key.
+			sig := r.ReadVarBytes(keys.SignatureLen)
+			item.Signatures[hex.EncodeToString(pub)] = sig
+		}
+		c.Items[h] = item
+	}
+}
+
+func decodeParameter(r *io.BinReader, p *smartcontract.Parameter) {
+	p.Type = smartcontract.ParamType(r.ReadB())
+	switch enc := r.ReadB(); enc {
+	case binValueNil:
+	case binValueBytes:
+		p.Value = r.ReadVarBytes()
+	case binValueJSON:
+		data := r.ReadVarBytes()
+		if r.Err == nil {
+			r.Err = json.Unmarshal(data, p)
+		}
+	default:
+		if r.Err == nil {
+			r.Err = fmt.Errorf("unknown parameter value encoding %d", enc)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package context
 import (
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
@@ -158,6 +159,90 @@ func TestParameterContext_AddSignatureMultisig(t *testing.T) {
 	})
 }
 
+func TestParameterContext_Merge(t *testing.T) {
+	privs, pubs := getPrivateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, keys.PublicKeys(pubs).Copy())
+	require.NoError(t, err)
+	ctr := &wallet.Contract{
+		Script: script,
+		Parameters: []wallet.ContractParam{
+			newParam(smartcontract.SignatureType, "parameter0"),
+			newParam(smartcontract.SignatureType, "parameter1"),
+		},
+	}
+	tx := getContractTx(ctr.ScriptHash())
+	newCtx := func(i int) *ParameterContext {
+		c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		c.AddContract(ctr.ScriptHash(), ctr)
+		if i >= 0 {
+			sig := privs[i].SignHashable(uint32(c.Network), tx)
+			require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pubs[i], sig))
+		}
+		return c
+	}
+
+	t.Run("empty item", func(t *testing.T) {
+		c := newCtx(-1)
+		item := c.Items[ctr.ScriptHash()]
+		require.NotNil(t, item)
+		require.Equal(t, script, item.Script)
+		require.Equal(t, 2, len(item.Parameters))
+		require.Equal(t, 0, len(item.Signatures))
+	})
+	t.Run("network mismatch", func(t *testing.T) {
+		other := newCtx(0)
+		other.Network = netmode.MainNet
+		require.Error(t, newCtx(1).Merge(other))
+	})
+	t.Run("verifiable mismatch", func(t *testing.T) {
+		other := NewParameterContext(TransactionType, netmode.UnitTestNet, getContractTx(util.Uint160{1, 2, 3}))
+		require.Error(t, newCtx(1).Merge(other))
+	})
+	t.Run("script mismatch", func(t *testing.T) {
+		other := newCtx(0)
+		other.Items[util.Uint160{1, 2, 3}] = other.Items[ctr.ScriptHash()]
+		delete(other.Items, ctr.ScriptHash())
+		c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		require.Error(t, c.Merge(other))
+		require.Equal(t, 0, len(c.Items))
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		other := newCtx(-1)
+		other.Items[ctr.ScriptHash()].AddSignature(pubs[0], privs[1].SignHashable(uint32(other.Network), tx))
+		require.Error(t, newCtx(1).Merge(other))
+	})
+	t.Run("good", func(t *testing.T) {
+		c := newCtx(-1)
+		require.NoError(t, c.Merge(newCtx(2)))
+		_, err := c.GetCompleteTransaction()
+		require.Error(t, err)
+
+		require.NoError(t, c.Merge(newCtx(2))) // Duplicates are skipped.
+		require.NoError(t, c.Merge(newCtx(0)))
+		require.Equal(t, 2, len(c.Items[ctr.ScriptHash()].Signatures))
+
+		w, err := c.GetWitness(ctr.ScriptHash())
+		require.NoError(t, err)
+		v := newTestVM(w, tx)
+		require.NoError(t, v.Run())
+		require.Equal(t, true, v.Estack().Pop().Value())
+	})
+	t.Run("standard account", func(t *testing.T) {
+		priv := privs[0]
+		sctr := &wallet.Contract{
+			Script:     priv.PublicKey().GetVerificationScript(),
+			Parameters: []wallet.ContractParam{newParam(smartcontract.SignatureType, "parameter0")},
+		}
+		tx := getContractTx(sctr.ScriptHash())
+		c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		other := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+		sig := priv.SignHashable(uint32(other.Network), tx)
+		require.NoError(t, other.AddSignature(sctr.ScriptHash(), sctr, priv.PublicKey(), sig))
+		require.NoError(t, c.Merge(other))
+		require.Equal(t, sig, c.Items[sctr.ScriptHash()].Parameters[0].Value)
+	})
+}
+
 func newTestVM(w *transaction.Witness, tx *transaction.Transaction) *vm.VM {
 	ic := &interop.Context{Network: uint32(netmode.UnitTestNet), Container: tx, Functions: crypto.Interops}
 	v := ic.SpawnVM()
@@ -234,6 +319,48 @@ func TestParameterContext_MarshalJSON(t *testing.T) {
 	})
 }
 
+func TestParameterContext_EncodeBinary(t *testing.T) {
+	privs, pubs := getPrivateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, keys.PublicKeys(pubs).Copy())
+	require.NoError(t, err)
+	ctr := &wallet.Contract{
+		Script: script,
+		Parameters: []wallet.ContractParam{
+			newParam(smartcontract.SignatureType, "parameter0"),
+			newParam(smartcontract.SignatureType, "parameter1"),
+		},
+	}
+	tx := getContractTx(ctr.ScriptHash())
+	c := NewParameterContext(TransactionType, netmode.UnitTestNet, tx)
+	c.AddContract(ctr.ScriptHash(), ctr)
+	sig := privs[1].SignHashable(uint32(c.Network), tx)
+	require.NoError(t, c.AddSignature(ctr.ScriptHash(), ctr, pubs[1], sig))
+	c.Items[util.Uint160{1, 2, 3}] = &Item{
+		Parameters: []smartcontract.Parameter{
+			{Type: smartcontract.IntegerType, Value: big.NewInt(42)},
+			{Type: smartcontract.StringType, Value: "str"},
+			{Type: smartcontract.ByteArrayType, Value: []byte{1, 2, 3}},
+			{Type: smartcontract.SignatureType},
+		},
+		Signatures: make(map[string][]byte),
+	}
+	testserdes.EncodeDecodeBinary(t, c, new(ParameterContext))
+
+	data, err := testserdes.EncodeBinary(c)
+	require.NoError(t, err)
+	js, err := json.Marshal(c)
+	require.NoError(t, err)
+	require.Less(t, len(data)*2, len(js))
+
+	t.Run("unsupported type", func(t *testing.T) {
+		c := NewParameterContext("Neo.Network.P2P.Payloads.Block", netmode.UnitTestNet, tx)
+		_, err := testserdes.EncodeBinary(c)
+		require.Error(t, err)
+		data[0] = 1
+		require.Error(t, testserdes.DecodeBinary(data, new(ParameterContext)))
+	})
+}
+
 func TestSharpJSON(t *testing.T) {
 	input := []byte(`{"type":"Neo.Network.P2P.Payloads.Transaction","hash":"0x71b519998f41bbc1d37e383e01e2e6efe84d65abf3c7279820cc7c63daa29448","data":"AKTv6hJY8h4AAAAAAKwiUwEAAAAA0lEAAAFBO\u002BhSRSuucNKVX2lk7k5Wdr\u002BkOQEAMR8RwB8MEHNldEV4ZWNGZWVGYWN0b3IMFHvGgcCh9x1UNFe2i7qNX5/dTl7MQWJ9W1I=","items":{"0x39a4bf76564eee64695f95d270ae2b4552e83b41":{"script":"GwwhAwCbdUDhDyVi5f2PrJ6uwlFmpYsm5BI0j/WoaSe/rCKiDCEDAgXpzvrqWh38WAryDI1aokaLsBSPGl5GBfxiLIDmBLoMIQIUuvDO6jpm8X5\u002BHoOeol/YvtbNgua7bmglAYkGX0T/AQwhAzjSoai75eQ8YzNBYTMIaaXgqqUeYTSWGEp8xylL\u002BVafDCEDPY41\u002BM2aM4UigLbZMJPHKS7VzpDZDxSfotpQumFo384MIQI\u002BmzLqiblNBm5kmxJP1Q45bukTaejipq4bEcFw0CIlbQwhA0CNzUFjlvZHg6xYfqHhWTxX2f6ogMimoZIOkqJZR3gGDCEDScfvC0qvGB8KPhNQxSexNsxbQkmMuDq4iAwF7ZUWfhwMIQJWZM7wq8uneHrV\u002BxLzrzHFzcekeQaKoq2O54gEdov/6QwhA1tPm\u002BK4U\u002BButaCcFn4Di5a0gEI1lhUQQjJS8u49u6WDDCEDZQpoRGGmS/Rr7lYdmYGkxXrcbMvTqVErg3AUgLMCGKsMIQJqEKorTXY5xd6vpP8IFGfbELXQBDJ0mipe4dK/7SPhwAwhAn5FmyZLb34yWrSwuw\u002BmQQgftoUX/WE\u002BvXqUy3nTCB5PDCECiMrUQqh3lgx2tPaI9L4w92glbZo9okkrAYC5EkORi08MIQKkDFUnmPeWNglYF\u002ByIkk/Gy3CU5aPLBZqbO8keo78NPQwhAqeDS\u002BmzLimB0VfLW706y0LP0R6lw7ECJNekTpjFkQ8bDCECuixw9ZlvNXpDGYcFhZ\u002BuLP6hPhFyligAdys9WIqdSr0MIQLVeGqSFKij8XV9dZb9EPUkEgXiwNaDYvR2ZXm6xhiSSQwhA9jVjSJXymyxRSK3ZRPUeD99SBgBaViTeUwhhlFcbedvDCEC23nmnFGK6SVOMUtvX0tj6RTN1LJXTcL5I2wBwfwdiXMMIQLsFD8AuIUkyvNqASHC3gnu8FGd2\u002BHHEKAPDiZjIB7kwAAVQZ7Q3Do=","parameters":[{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"}],"signatures":{"03650a684461a64bf46bee561d9981a4c57adc6ccbd3a9512b83701480b30218ab":"QtjYFNpGOOnij\u002BLwNZLOO3fHNoVQas\u002B4\u002BAo6SdvEeP3C12ATXzgPjAZrd5mCDc3KYkce0wwveEuuoYA8mhraUA==","0288cad442a877960c76b4f688f4be30f768256d9a3da2492b0180b91243918b4f":"RmuTXfPokXWEL9RIM9DqUUsOH8iRMfrKTp6LdhdJ0KBW6rNSEuxxNOpSUMBEW1EE2CNh1c\u002BmElj2Ny3o89SzGQ==","035b4f9be2b853e06eb5a09c167e038b96b4804235961510423252f2ee3dbba583":"1VYiT\u002BPe/7syYDSOWaJ1jPyZ6JDPrdU9toDu0Cg9pRQAJW1KLSexiosLA73k7lQeVbq4YuNlWnY7U8CYIQ/ilA==","02a40c552798f79636095817ec88924fc6cb7094e5a3cb059a9b3bc91ea3bf0d3d":"/mXUPXp/tI6Y7LhudKzBE8K2soHcPgrr48YLrwgbTI4qypYpOzh\u002BNj03pkAvk8\u002B68kuefevNQb/pjmPRvs80DA=="}}},"network":877933390}`)
 	pc := ParameterContext{}
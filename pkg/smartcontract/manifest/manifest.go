@@ -24,6 +24,16 @@ const (
 	NEP11Payable = "NEP-11-Payable"
 	// NEP17Payable represents the name of contract interface which can receive NEP-17 tokens.
 	NEP17Payable = "NEP-17-Payable"
+	// NEP22StandardName represents the name of NEP-22 (contract update) smartcontract standard.
+	NEP22StandardName = "NEP-22"
+	// NEP24StandardName represents the name of NEP-24 (NFT royalty) smartcontract standard.
+	NEP24StandardName = "NEP-24"
+	// NEP26StandardName represents the name of NEP-26 smartcontract standard for
+	// contracts that can receive NEP-11 tokens.
+	NEP26StandardName = "NEP-26"
+	// NEP27StandardName represents the name of NEP-27 smartcontract standard for
+	// contracts that can receive NEP-17 tokens.
+	NEP27StandardName = "NEP-27"
 )
 
 // Manifest represens contract metadata.
@@ -3,6 +3,7 @@ package standard
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 )
@@ -23,10 +24,14 @@ var checks = map[string][]*Standard{
 	manifest.NEP17StandardName: {Nep17},
 	manifest.NEP11Payable:      {Nep11Payable},
 	manifest.NEP17Payable:      {Nep17Payable},
+	manifest.NEP22StandardName: {Nep22},
+	manifest.NEP24StandardName: {Nep24},
+	manifest.NEP26StandardName: {Nep26},
+	manifest.NEP27StandardName: {Nep27},
 }
 
-// Check checks if the manifest complies with all provided standards.
-// Currently, only NEP-17 is supported.
+// Check checks if the manifest complies with all provided standards. Unknown
+// standards are ignored.
 func Check(m *manifest.Manifest, standards ...string) error {
 	return check(m, true, standards...)
 }
@@ -54,6 +59,20 @@ func check(m *manifest.Manifest, checkNames bool, standards ...string) error {
 	return nil
 }
 
+// Compliant returns a sorted list of names of all known standards the given
+// manifest complies with (parameter names are not checked, just like CheckABI
+// does). It doesn't take into account standards declared by the manifest.
+func Compliant(m *manifest.Manifest) []string {
+	var res []string
+	for name := range checks {
+		if check(m, false, name) == nil {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
 // Comply if m has all methods and event from st manifest and they have the same signature.
 // Parameter names are checked to exactly match the ones in the given standard.
 func Comply(m *manifest.Manifest, st *Standard) error {
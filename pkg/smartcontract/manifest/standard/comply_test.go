@@ -141,6 +141,57 @@ func TestCheck(t *testing.T) {
 	require.NoError(t, CheckABI(m, manifest.NEP17StandardName))
 }
 
+func TestCheckExtensions(t *testing.T) {
+	for name, std := range map[string]*Standard{
+		manifest.NEP22StandardName: Nep22,
+		manifest.NEP26StandardName: Nep26,
+		manifest.NEP27StandardName: Nep27,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := manifest.NewManifest("Test")
+			require.Error(t, Check(m, name))
+
+			m.ABI.Methods = append(m.ABI.Methods, std.ABI.Methods...)
+			require.NoError(t, Check(m, name))
+
+			m.ABI.Methods[0].Parameters = append([]manifest.Parameter{}, m.ABI.Methods[0].Parameters...)
+			m.ABI.Methods[0].Parameters[0].Type = smartcontract.BoolType
+			require.ErrorIs(t, Check(m, name), ErrInvalidParameterType)
+		})
+	}
+}
+
+func TestCheckNep24(t *testing.T) {
+	m := manifest.NewManifest("Test")
+	m.ABI.Methods = append(m.ABI.Methods, Nep24.ABI.Methods...)
+	require.ErrorIs(t, Check(m, manifest.NEP24StandardName), ErrMethodMissing)
+	require.Empty(t, Compliant(m))
+
+	// NEP-11 methods are required as well.
+	m.ABI.Methods = append(m.ABI.Methods, DecimalTokenBase.ABI.Methods...)
+	m.ABI.Methods = append(m.ABI.Methods, Nep11Base.ABI.Methods...)
+	m.ABI.Events = append(m.ABI.Events, Nep11Base.ABI.Events...)
+	require.NoError(t, Check(m, manifest.NEP24StandardName))
+	require.Equal(t, []string{manifest.NEP24StandardName}, Compliant(m))
+
+	m.ABI.Methods[0].Parameters = append([]manifest.Parameter{}, m.ABI.Methods[0].Parameters...)
+	m.ABI.Methods[0].Parameters[0].Type = smartcontract.BoolType
+	require.ErrorIs(t, Check(m, manifest.NEP24StandardName), ErrInvalidParameterType)
+}
+
+func TestCompliant(t *testing.T) {
+	m := manifest.NewManifest("Test")
+	require.Empty(t, Compliant(m))
+
+	m.ABI.Methods = append(m.ABI.Methods, DecimalTokenBase.ABI.Methods...)
+	m.ABI.Methods = append(m.ABI.Methods, Nep17.ABI.Methods...)
+	m.ABI.Events = append(m.ABI.Events, Nep17.ABI.Events...)
+	m.ABI.Methods = append(m.ABI.Methods, Nep17Payable.ABI.Methods...)
+	m.ABI.Methods = append(m.ABI.Methods, Nep22.ABI.Methods...)
+	require.Equal(t, []string{manifest.NEP17StandardName, manifest.NEP17Payable,
+		manifest.NEP22StandardName, manifest.NEP27StandardName}, Compliant(m))
+}
+
 func TestOptional(t *testing.T) {
 	var m Standard
 	m.Optional = []manifest.Method{{
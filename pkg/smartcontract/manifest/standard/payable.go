@@ -39,3 +39,11 @@ var Nep17Payable = &Standard{
 		},
 	},
 }
+
+// Nep26 is a NEP-26 Standard for contracts receiving NEP-11 tokens, it's the
+// same as Nep11Payable.
+var Nep26 = Nep11Payable
+
+// Nep27 is a NEP-27 Standard for contracts receiving NEP-17 tokens, it's the
+// same as Nep17Payable.
+var Nep27 = Nep17Payable
@@ -0,0 +1,423 @@
+/*
+Package pybinding implements Python contract binding generator. Generated code
+depends on the neo-mamba SDK and provides typed methods returning
+ContractMethodResult for every contract method (that can then be used for
+invocations and test invocations via ChainFacade) as well as contract event
+decoders. It uses the same configuration as the rpcbinding package does, but
+ignores Go-specific type overrides.
+*/
+package pybinding
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/rpcbinding"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+const srcTmpl = `# Code generated by neo-go contract generate-rpcwrapper --lang py --manifest <file.json> --out <file.py> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+"""Wrappers for {{.ContractName}} contract based on neo-mamba SDK."""
+from __future__ import annotations
+
+from dataclasses import dataclass, fields, is_dataclass
+from typing import Any
+
+from neo3 import vm
+from neo3.api import noderpc
+from neo3.api.helpers import unwrap
+from neo3.api.wrappers import ContractMethodResult, GenericContract
+from neo3.core import cryptography, types
+{{- if .Hash}}
+
+# HASH contains contract hash.
+HASH = types.UInt160.from_string("{{.Hash}}")
+{{- end}}
+
+
+def _to_param(v: Any) -> Any:
+    """Converts structures into lists acceptable as contract parameters."""
+    if is_dataclass(v):
+        return [_to_param(getattr(v, f.name)) for f in fields(v)]
+    if isinstance(v, list):
+        return [_to_param(e) for e in v]
+    if isinstance(v, dict):
+        return {_to_param(k): _to_param(e) for k, e in v.items()}
+    return v
+
+
+def _check_len(items: list[noderpc.StackItem], n: int, what: str) -> list[noderpc.StackItem]:
+    if len(items) != n:
+        raise ValueError(f"wrong number of {what}: expected {n}, got {len(items)}")
+    return items
+{{- range $t := .NamedTypes}}
+
+
+@dataclass
+class {{.Name}}:
+    """{{.Name}} is a contract-specific {{.ManifestName}} type used by its methods."""
+{{- range .Fields}}
+    {{.Name}}: {{.Type}}
+{{- end}}
+
+    @classmethod
+    def from_stack_item(cls, item: noderpc.StackItem) -> {{.Name}}:
+        """Converts stack item into {{.Name}}."""
+        arr = _check_len(item.as_list(), {{len .Fields}}, "structure elements")
+        return cls(
+{{- range .Fields}}
+            {{.Decoder}},
+{{- end}}
+        )
+{{- end}}
+{{- range $e := .Events}}
+
+
+@dataclass
+class {{.Name}}:
+    """{{.Name}} represents "{{.ManifestName}}" event emitted by the contract."""
+{{- range .Fields}}
+    {{.Name}}: {{.Type}}
+{{- end}}
+
+    @classmethod
+    def from_notification(cls, n: noderpc.Notification) -> {{.Name}}:
+        """Converts notification into {{.Name}}."""
+        arr = _check_len(n.state.as_list(), {{len .Fields}}, "{{.ManifestName}} event parameters")
+        return cls(
+{{- range .Fields}}
+            {{.Decoder}},
+{{- end}}
+        )
+
+    @classmethod
+    def from_notifications(cls, notifications: list[noderpc.Notification], contract_hash: types.UInt160{{if $.Hash}} = HASH{{end}}) -> list[{{.Name}}]:
+        """Retrieves all events with "{{.ManifestName}}" name emitted by the contract from the provided notifications."""
+        return [cls.from_notification(n) for n in notifications if n.contract == contract_hash and n.event_name == "{{.ManifestName}}"]
+{{- end}}
+
+
+class Contract(GenericContract):
+    """Contract implements invocations of {{.ContractName}} contract methods.
+
+    Every method returns ContractMethodResult that can be passed to
+    ChainFacade.test_invoke or ChainFacade.invoke.
+    """
+
+    def __init__(self, contract_hash: types.UInt160{{if .Hash}} = HASH{{end}}):
+        super().__init__(contract_hash)
+{{- range $m := .Methods}}
+
+    def {{.Name}}(self{{range .Arguments}}, {{.Name}}: {{.Type}}{{end}}) -> ContractMethodResult[{{.ReturnType}}]:
+        """{{if .Safe}}Invokes{{else}}Creates a transaction invoking{{end}} ` + "`{{.NameABI}}`" + ` method of the contract."""
+        sb = vm.ScriptBuilder().emit_contract_call_with_args(self.hash, "{{.NameABI}}", [{{range $i, $a := .Arguments}}{{if $i}}, {{end}}{{.Encoder}}{{end}}])
+        return ContractMethodResult(sb.to_array(), {{.Decoder}})
+{{- end}}
+`
+
+type (
+	contractTmpl struct {
+		ContractName string
+		Hash         string
+		Methods      []methodTmpl
+		NamedTypes   []structTmpl
+		Events       []structTmpl
+	}
+
+	methodTmpl struct {
+		Name       string
+		NameABI    string
+		Safe       bool
+		Arguments  []fieldTmpl
+		ReturnType string
+		Decoder    string
+	}
+
+	structTmpl struct {
+		Name         string
+		ManifestName string
+		Fields       []fieldTmpl
+	}
+
+	fieldTmpl struct {
+		Name    string
+		Type    string
+		Decoder string
+		Encoder string
+	}
+)
+
+var srcTemplate = template.Must(template.New("generate").Parse(srcTmpl))
+
+// reserved contains Python keywords and names that can't be used as
+// parameter or field names.
+var reserved = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true, "class": true,
+	"continue": true, "def": true, "del": true, "elif": true, "else": true,
+	"except": true, "finally": true, "for": true, "from": true, "global": true,
+	"if": true, "import": true, "in": true, "is": true, "lambda": true,
+	"nonlocal": true, "not": true, "or": true, "pass": true, "raise": true,
+	"return": true, "try": true, "while": true, "with": true, "yield": true,
+	"self": true, "sb": true,
+}
+
+// Generate writes Python file containing smartcontract bindings to the
+// `cfg.Output`. It doesn't check manifest from Config for validity, incorrect
+// manifest can lead to unexpected results.
+func Generate(cfg binding.Config) error {
+	// Avoid changing *cfg.Manifest.
+	mfst := *cfg.Manifest
+	mfst.ABI.Methods = make([]manifest.Method, len(mfst.ABI.Methods))
+	copy(mfst.ABI.Methods, cfg.Manifest.ABI.Methods)
+	cfg.Manifest = &mfst
+
+	// OnNepXXPayment handlers normally can't be called directly.
+	for _, std := range []*standard.Standard{standard.Nep11Payable, standard.Nep17Payable} {
+		if standard.ComplyABI(cfg.Manifest, std) == nil {
+			mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, std)
+		}
+	}
+
+	ctr := contractTmpl{ContractName: cfg.Manifest.Name}
+	if !cfg.Hash.Equals(util.Uint160{}) {
+		ctr.Hash = "0x" + cfg.Hash.StringLE()
+	}
+	base := binding.TemplateFromManifest(cfg, func(string, smartcontract.ParamType, *binding.Config) (string, string) {
+		return "", ""
+	})
+	for _, m := range base.Methods {
+		abim := cfg.Manifest.ABI.GetMethod(m.NameABI, len(m.Arguments))
+		mtd := methodTmpl{
+			Name:       toSnakeCase(m.Name),
+			NameABI:    m.NameABI,
+			Safe:       abim.Safe,
+			ReturnType: "None",
+			Decoder:    "unwrap.as_none",
+		}
+		names := make(map[string]bool)
+		for _, p := range abim.Parameters {
+			name := toSnakeCase(p.Name)
+			for reserved[name] || names[name] {
+				name += "_"
+			}
+			names[name] = true
+			et := extendedType(cfg, abim.Name+"."+p.Name, p.Type)
+			mtd.Arguments = append(mtd.Arguments, fieldTmpl{
+				Name:    name,
+				Type:    pyType(et, true),
+				Encoder: encoder(et, name),
+			})
+		}
+		if abim.ReturnType != smartcontract.VoidType {
+			et := extendedType(cfg, abim.Name, abim.ReturnType)
+			mtd.ReturnType = pyType(et, false)
+			mtd.Decoder = "lambda res: " + decoder(et, "unwrap.item(res)", 0)
+		}
+		ctr.Methods = append(ctr.Methods, mtd)
+	}
+
+	for _, et := range cfg.NamedTypes {
+		t := structTmpl{
+			Name:         toTypeName(et.Name),
+			ManifestName: et.Name,
+		}
+		for i, f := range et.Fields {
+			t.Fields = append(t.Fields, fieldTmpl{
+				Name:    fieldName(f.Field),
+				Type:    pyType(f.ExtendedType, false),
+				Decoder: decoder(f.ExtendedType, "arr["+strconv.Itoa(i)+"]", 0),
+			})
+		}
+		if err := checkFields(t, "named type"); err != nil {
+			return err
+		}
+		ctr.NamedTypes = append(ctr.NamedTypes, t)
+	}
+	sort.Slice(ctr.NamedTypes, func(i, j int) bool {
+		return ctr.NamedTypes[i].Name < ctr.NamedTypes[j].Name
+	})
+
+	for _, e := range cfg.Manifest.ABI.Events {
+		eName := rpcbinding.ToEventBindingName(e.Name)
+		t := structTmpl{
+			Name:         eName,
+			ManifestName: e.Name,
+		}
+		for i, p := range e.Parameters {
+			pName := rpcbinding.ToParameterBindingName(p.Name)
+			et := extendedType(cfg, eName+"."+pName, p.Type)
+			t.Fields = append(t.Fields, fieldTmpl{
+				Name:    fieldName(pName),
+				Type:    pyType(et, false),
+				Decoder: decoder(et, "arr["+strconv.Itoa(i)+"]", 0),
+			})
+		}
+		if err := checkFields(t, "event"); err != nil {
+			return err
+		}
+		ctr.Events = append(ctr.Events, t)
+	}
+
+	return execute(cfg.Output, ctr)
+}
+
+// execute writes the resulting binding to the given writer.
+func execute(out io.Writer, ctr contractTmpl) error {
+	err := srcTemplate.Execute(out, ctr)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+func checkFields(t structTmpl, kind string) error {
+	names := make(map[string]bool)
+	for _, f := range t.Fields {
+		if names[f.Name] {
+			return fmt.Errorf("%s `%s` has two fields with identical resulting binding name `%s`", kind, t.ManifestName, f.Name)
+		}
+		names[f.Name] = true
+	}
+	return nil
+}
+
+func extendedType(cfg binding.Config, name string, typ smartcontract.ParamType) binding.ExtendedType {
+	et, ok := cfg.Types[name]
+	if !ok {
+		et = binding.ExtendedType{Base: typ}
+	}
+	return et
+}
+
+// pyType returns Python type hint for the given extended type. Values of
+// unknown type are passed as is and decoded into noderpc.StackItem.
+func pyType(et binding.ExtendedType, param bool) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "bool"
+	case smartcontract.IntegerType:
+		return "int"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "bytes"
+	case smartcontract.StringType:
+		return "str"
+	case smartcontract.Hash160Type:
+		return "types.UInt160"
+	case smartcontract.Hash256Type:
+		return "types.UInt256"
+	case smartcontract.PublicKeyType:
+		return "cryptography.ECPoint"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return toTypeName(et.Name)
+		}
+		return "list[" + pyType(valueType(et), param) + "]"
+	case smartcontract.MapType:
+		return "dict[" + pyType(binding.ExtendedType{Base: et.Key}, true) + ", " + pyType(valueType(et), param) + "]"
+	case smartcontract.VoidType:
+		return "None"
+	default: // Any and InteropInterface.
+		if param {
+			return "Any"
+		}
+		return "noderpc.StackItem"
+	}
+}
+
+// decoder returns an expression converting noderpc.StackItem v into the value
+// of the given type.
+func decoder(et binding.ExtendedType, v string, depth int) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return v + ".as_bool()"
+	case smartcontract.IntegerType:
+		return v + ".as_int()"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return v + ".as_bytes()"
+	case smartcontract.StringType:
+		return v + ".as_str()"
+	case smartcontract.Hash160Type:
+		return v + ".as_uint160()"
+	case smartcontract.Hash256Type:
+		return v + ".as_uint256()"
+	case smartcontract.PublicKeyType:
+		return v + ".as_public_key()"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return toTypeName(et.Name) + ".from_stack_item(" + v + ")"
+		}
+		e := "e" + strconv.Itoa(depth)
+		return "[" + decoder(valueType(et), e, depth+1) + " for " + e + " in " + v + ".as_list()]"
+	case smartcontract.MapType:
+		k, e := "k"+strconv.Itoa(depth), "e"+strconv.Itoa(depth)
+		return "{" + k + ": " + decoder(valueType(et), e, depth+1) + " for " + k + ", " + e + " in " + v + ".as_dict().items()}"
+	default:
+		return v
+	}
+}
+
+// encoder returns an expression converting value v of the given type into
+// contract parameter.
+func encoder(et binding.ExtendedType, v string) string {
+	switch et.Base {
+	case smartcontract.ArrayType, smartcontract.MapType:
+		return "_to_param(" + v + ")"
+	default:
+		return v
+	}
+}
+
+func valueType(et binding.ExtendedType) binding.ExtendedType {
+	if et.Value != nil {
+		return *et.Value
+	}
+	return binding.ExtendedType{Base: smartcontract.AnyType}
+}
+
+func dropStdMethods(meths []manifest.Method, std *standard.Standard) []manifest.Method {
+	var res = meths[:0]
+	for _, m := range meths {
+		if std.Manifest.ABI.GetMethod(m.Name, len(m.Parameters)) == nil {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+func fieldName(s string) string {
+	name := toSnakeCase(s)
+	if reserved[name] {
+		name += "_"
+	}
+	return name
+}
+
+// toSnakeCase converts camel case identifier into snake case.
+func toSnakeCase(s string) string {
+	var (
+		b    strings.Builder
+		prev rune
+	)
+	for _, r := range s {
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+		prev = r
+	}
+	return b.String()
+}
+
+func toTypeName(s string) string {
+	return strings.ReplaceAll(strings.ToUpper(s[0:1])+s[1:], ".", "")
+}
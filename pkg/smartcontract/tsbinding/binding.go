@@ -0,0 +1,569 @@
+/*
+Package tsbinding implements TypeScript contract binding generator. Generated
+code depends on the @cityofzion/neon-js SDK and provides typed methods for
+invocations and test invocations of contract methods as well as contract
+event decoders. It uses the same configuration as the rpcbinding package does,
+but ignores Go-specific type overrides.
+*/
+package tsbinding
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/rpcbinding"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+const srcTmpl = `// Code generated by neo-go contract generate-rpcwrapper --lang ts --manifest <file.json> --out <file.ts> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+// Wrappers for {{.ContractName}} contract based on @cityofzion/neon-js SDK.
+import { rpc, sc, tx, u } from "@cityofzion/neon-js";
+{{- if .Hash}}
+
+/** Hash contains contract hash. */
+export const Hash = "{{.Hash}}";
+{{- end}}
+{{- range $t := .NamedTypes}}
+
+/** {{.Name}} is a contract-specific {{.ManifestName}} type used by its methods. */
+export interface {{.Name}} {
+{{- range .Fields}}
+  {{.Name}}: {{.Type}};
+{{- end}}
+}
+{{- end}}
+{{- range $e := .Events}}
+
+/** {{.Name}} represents "{{.ManifestName}}" event emitted by the contract. */
+export interface {{.Name}} {
+{{- range .Fields}}
+  {{.Name}}: {{.Type}};
+{{- end}}
+}
+{{- end}}
+
+/**
+ * Contract implements invocations of {{.ContractName}} contract methods.
+ * Safe methods are test-invoked and return decoded results, for other methods
+ * invocation scripts can be created and test-invoked.
+ */
+export class Contract {
+  constructor(
+    public readonly client: rpc.RPCClient,
+    public readonly hash: string{{if .Hash}} = Hash{{end}},
+  ) {}
+{{- range $m := .Methods}}
+{{- if .Safe}}
+
+  /** {{.Name}} invokes ` + "`{{.NameABI}}`" + ` method of the contract. */
+  async {{.Name}}({{template "ARGS" .}}): Promise<{{.ReturnType}}> {
+    const res = await this.client.invokeFunction(this.hash, "{{.NameABI}}", [{{template "PARAMS" .}}]);
+    {{if .ReturnType}}return {{.Decoder}};{{else}}checkState(res);{{end}}
+  }
+{{- else}}
+
+  /** {{.Name}}Script creates a script invoking ` + "`{{.NameABI}}`" + ` method of the contract. */
+  {{.Name}}Script({{template "ARGS" .}}): string {
+    return sc.createScript({ scriptHash: this.hash, operation: "{{.NameABI}}", args: [{{template "PARAMS" .}}] });
+  }
+
+  /** {{.Name}}TestInvoke test-invokes ` + "`{{.NameABI}}`" + ` method of the contract with the given signers. */
+  {{.Name}}TestInvoke({{template "ARGS" .}}{{if .Arguments}}, {{end}}signers: tx.SignerLike[] = []): Promise<rpc.InvokeResult> {
+    return this.client.invokeFunction(this.hash, "{{.NameABI}}", [{{template "PARAMS" .}}], signers);
+  }
+{{- end}}
+{{- end}}
+}
+{{- range $t := .NamedTypes}}
+
+/** itemTo{{.Name}} converts stack item into {{.Name}}. */
+export function itemTo{{.Name}}(item: sc.StackItemJson): {{.Name}} {
+  const arr = toArray(item, (i) => i);
+  if (arr.length !== {{len .Fields}}) {
+    throw new Error(` + "`wrong number of structure elements: expected {{len .Fields}}, got ${arr.length}`" + `);
+  }
+  return {
+{{- range $i, $f := .Fields}}
+    {{.Name}}: {{.Decoder}},
+{{- end}}
+  };
+}
+
+/** {{.ParamName}}ToParam converts {{.Name}} into contract parameter. */
+export function {{.ParamName}}ToParam(v: {{.Name}}): sc.ContractParam {
+  return sc.ContractParam.array(
+{{- range $i, $f := .Fields}}
+    {{.Encoder}},
+{{- end}}
+  );
+}
+{{- end}}
+{{- range $e := .Events}}
+
+/** {{.FuncName}}FromApplicationLog retrieves a set of all emitted events with "{{.ManifestName}}" name from the provided application log. */
+export function {{.FuncName}}FromApplicationLog(log: rpc.ApplicationLogJson, hash: string{{if $.Hash}} = Hash{{end}}): {{.Name}}[] {
+  const res: {{.Name}}[] = [];
+  for (const ex of log.executions) {
+    for (const n of ex.notifications) {
+      if (n.eventname !== "{{.ManifestName}}" || !sameHash(n.contract, hash)) {
+        continue;
+      }
+      const arr = toArray(n.state, (i) => i);
+      if (arr.length !== {{len .Fields}}) {
+        throw new Error(` + "`wrong number of {{.ManifestName}} event parameters: expected {{len .Fields}}, got ${arr.length}`" + `);
+      }
+      res.push({
+{{- range $i, $f := .Fields}}
+        {{.Name}}: {{.Decoder}},
+{{- end}}
+      });
+    }
+  }
+  return res;
+}
+{{- end}}
+
+function sameHash(a: string, b: string): boolean {
+  return u.remove0xPrefix(a).toLowerCase() === u.remove0xPrefix(b).toLowerCase();
+}
+
+function checkState(res: rpc.InvokeResult): sc.StackItemJson {
+  if (res.state !== "HALT") {
+    throw new Error(` + "`invocation failed: ${res.exception}`" + `);
+  }
+  if (res.stack.length === 0) {
+    throw new Error("result stack is empty");
+  }
+  return res.stack[0];
+}
+
+function bytesHex(item: sc.StackItemJson): string {
+  if (item.type !== "ByteString" && item.type !== "Buffer") {
+    throw new Error(` + "`${item.type} is not a byte string`" + `);
+  }
+  return u.base642hex(item.value as string);
+}
+
+function toBool(item: sc.StackItemJson): boolean {
+  switch (item.type) {
+    case "Boolean":
+      return item.value as boolean;
+    case "Integer":
+      return BigInt(item.value as string) !== 0n;
+    default:
+      return /[^0]/.test(bytesHex(item));
+  }
+}
+
+function toInteger(item: sc.StackItemJson): bigint {
+  switch (item.type) {
+    case "Integer":
+      return BigInt(item.value as string);
+    case "Boolean":
+      return item.value ? 1n : 0n;
+    default:
+      return BigInt(u.BigInteger.fromTwos(bytesHex(item), true).toString());
+  }
+}
+
+function toBytes(item: sc.StackItemJson): Uint8Array {
+  return u.hexstring2ab(bytesHex(item));
+}
+
+function toUTF8String(item: sc.StackItemJson): string {
+  return new TextDecoder("utf-8", { fatal: true }).decode(toBytes(item));
+}
+
+function toHash(item: sc.StackItemJson, size: number): string {
+  const h = bytesHex(item);
+  if (h.length !== size * 2) {
+    throw new Error(` + "`wrong hash length: expected ${size}, got ${h.length / 2}`" + `);
+  }
+  return u.reverseHex(h);
+}
+
+function toPublicKey(item: sc.StackItemJson): string {
+  const k = bytesHex(item);
+  if (!u.isHex(k) || k.length !== 66) {
+    throw new Error("not a compressed public key");
+  }
+  return k;
+}
+
+function toArray<T>(item: sc.StackItemJson, f: (i: sc.StackItemJson) => T): T[] {
+  if (item.type !== "Array" && item.type !== "Struct") {
+    throw new Error(` + "`${item.type} is not an array`" + `);
+  }
+  return (item.value as sc.StackItemJson[]).map(f);
+}
+
+function toMap<K, V>(item: sc.StackItemJson, fk: (i: sc.StackItemJson) => K, fv: (i: sc.StackItemJson) => V): Map<K, V> {
+  if (item.type !== "Map") {
+    throw new Error(` + "`${item.type} is not a map`" + `);
+  }
+  const res = new Map<K, V>();
+  for (const e of item.value as { key: sc.StackItemJson; value: sc.StackItemJson }[]) {
+    res.set(fk(e.key), fv(e.value));
+  }
+  return res;
+}
+
+function bytesParam(v: Uint8Array): sc.ContractParam {
+  return sc.ContractParam.byteArray(u.HexString.fromHex(u.ab2hexstring(v)));
+}
+
+function mapParam(entries: sc.ContractParam[][]): sc.ContractParam {
+  return new sc.ContractParam({ type: "Map", value: entries.map(([key, value]) => ({ key, value })) });
+}
+
+function itemToParam(item: sc.StackItemJson): sc.ContractParam {
+  switch (item.type) {
+    case "Any":
+      return sc.ContractParam.any(null);
+    case "Boolean":
+      return sc.ContractParam.boolean(item.value as boolean);
+    case "Integer":
+      return sc.ContractParam.integer(item.value as string);
+    case "ByteString":
+    case "Buffer":
+      return bytesParam(toBytes(item));
+    case "Array":
+    case "Struct":
+      return sc.ContractParam.array(...toArray(item, itemToParam));
+    case "Map":
+      return mapParam([...toMap(item, itemToParam, itemToParam)]);
+    default:
+      throw new Error(` + "`${item.type} can't be converted to parameter`" + `);
+  }
+}
+`
+
+const argsTmpl = `{{define "ARGS"}}{{range $i, $a := .Arguments}}{{if $i}}, {{end}}{{.Name}}: {{.Type}}{{end}}{{end}}`
+
+const paramsTmpl = `{{define "PARAMS"}}{{range $i, $a := .Arguments}}{{if $i}}, {{end}}{{.Encoder}}{{end}}{{end}}`
+
+type (
+	contractTmpl struct {
+		ContractName string
+		Hash         string
+		Methods      []methodTmpl
+		NamedTypes   []structTmpl
+		Events       []structTmpl
+	}
+
+	methodTmpl struct {
+		Name       string
+		NameABI    string
+		Safe       bool
+		Arguments  []fieldTmpl
+		ReturnType string
+		Decoder    string
+	}
+
+	structTmpl struct {
+		Name         string
+		ParamName    string
+		FuncName     string
+		ManifestName string
+		Fields       []fieldTmpl
+	}
+
+	fieldTmpl struct {
+		Name    string
+		Type    string
+		Decoder string
+		Encoder string
+	}
+)
+
+var srcTemplate = template.Must(template.Must(template.Must(
+	template.New("generate").Parse(argsTmpl)).Parse(paramsTmpl)).Parse(srcTmpl))
+
+// reserved contains TypeScript reserved words that can't be used as
+// parameter names.
+var reserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "let": true, "static": true, "yield": true,
+	"await": true, "implements": true, "interface": true, "package": true,
+	"private": true, "protected": true, "public": true, "signers": true,
+}
+
+// Generate writes TypeScript file containing smartcontract bindings to the
+// `cfg.Output`. It doesn't check manifest from Config for validity, incorrect
+// manifest can lead to unexpected results.
+func Generate(cfg binding.Config) error {
+	// Avoid changing *cfg.Manifest.
+	mfst := *cfg.Manifest
+	mfst.ABI.Methods = make([]manifest.Method, len(mfst.ABI.Methods))
+	copy(mfst.ABI.Methods, cfg.Manifest.ABI.Methods)
+	cfg.Manifest = &mfst
+
+	// OnNepXXPayment handlers normally can't be called directly.
+	for _, std := range []*standard.Standard{standard.Nep11Payable, standard.Nep17Payable} {
+		if standard.ComplyABI(cfg.Manifest, std) == nil {
+			mfst.ABI.Methods = dropStdMethods(mfst.ABI.Methods, std)
+		}
+	}
+
+	ctr := contractTmpl{ContractName: cfg.Manifest.Name}
+	if !cfg.Hash.Equals(util.Uint160{}) {
+		ctr.Hash = cfg.Hash.StringLE()
+	}
+	base := binding.TemplateFromManifest(cfg, func(string, smartcontract.ParamType, *binding.Config) (string, string) {
+		return "", ""
+	})
+	for _, m := range base.Methods {
+		abim := cfg.Manifest.ABI.GetMethod(m.NameABI, len(m.Arguments))
+		mtd := methodTmpl{
+			Name:    lowerFirst(m.Name),
+			NameABI: m.NameABI,
+			Safe:    abim.Safe,
+		}
+		names := make(map[string]bool)
+		for _, p := range abim.Parameters {
+			name := p.Name
+			if reserved[name] {
+				name += "_"
+			}
+			for names[name] {
+				name += "_"
+			}
+			names[name] = true
+			et := extendedType(cfg, abim.Name+"."+p.Name, p.Type)
+			mtd.Arguments = append(mtd.Arguments, fieldTmpl{
+				Name:    name,
+				Type:    tsType(et, true),
+				Encoder: encoder(et, name, 0, true),
+			})
+		}
+		if abim.ReturnType != smartcontract.VoidType {
+			et := extendedType(cfg, abim.Name, abim.ReturnType)
+			mtd.ReturnType = tsType(et, false)
+			mtd.Decoder = decoder(et, "checkState(res)", 0)
+		}
+		ctr.Methods = append(ctr.Methods, mtd)
+	}
+
+	for _, et := range cfg.NamedTypes {
+		t := structTmpl{
+			Name:         toTypeName(et.Name),
+			ParamName:    lowerFirst(toTypeName(et.Name)),
+			ManifestName: et.Name,
+		}
+		for i, f := range et.Fields {
+			t.Fields = append(t.Fields, fieldTmpl{
+				Name:    f.Field,
+				Type:    tsType(f.ExtendedType, false),
+				Decoder: decoder(f.ExtendedType, "arr["+strconv.Itoa(i)+"]", 0),
+				Encoder: encoder(f.ExtendedType, "v."+f.Field, 0, false),
+			})
+		}
+		if err := checkFields(t, "named type"); err != nil {
+			return err
+		}
+		ctr.NamedTypes = append(ctr.NamedTypes, t)
+	}
+	sort.Slice(ctr.NamedTypes, func(i, j int) bool {
+		return ctr.NamedTypes[i].Name < ctr.NamedTypes[j].Name
+	})
+
+	for _, e := range cfg.Manifest.ABI.Events {
+		eName := rpcbinding.ToEventBindingName(e.Name)
+		t := structTmpl{
+			Name:         eName,
+			FuncName:     lowerFirst(eName) + "s",
+			ManifestName: e.Name,
+		}
+		for i, p := range e.Parameters {
+			pName := rpcbinding.ToParameterBindingName(p.Name)
+			et := extendedType(cfg, eName+"."+pName, p.Type)
+			t.Fields = append(t.Fields, fieldTmpl{
+				Name:    lowerFirst(pName),
+				Type:    tsType(et, false),
+				Decoder: decoder(et, "arr["+strconv.Itoa(i)+"]", 0),
+			})
+		}
+		if err := checkFields(t, "event"); err != nil {
+			return err
+		}
+		ctr.Events = append(ctr.Events, t)
+	}
+
+	return execute(cfg.Output, ctr)
+}
+
+// execute writes the resulting binding to the given writer.
+func execute(out io.Writer, ctr contractTmpl) error {
+	err := srcTemplate.Execute(out, ctr)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+func checkFields(t structTmpl, kind string) error {
+	names := make(map[string]bool)
+	for _, f := range t.Fields {
+		if names[f.Name] {
+			return fmt.Errorf("%s `%s` has two fields with identical resulting binding name `%s`", kind, t.ManifestName, f.Name)
+		}
+		names[f.Name] = true
+	}
+	return nil
+}
+
+func extendedType(cfg binding.Config, name string, typ smartcontract.ParamType) binding.ExtendedType {
+	et, ok := cfg.Types[name]
+	if !ok {
+		et = binding.ExtendedType{Base: typ}
+	}
+	return et
+}
+
+// tsType returns TypeScript type for the given extended type. Parameter types
+// differ from decoded ones for values of unknown type that are passed as
+// sc.ContractParam.
+func tsType(et binding.ExtendedType, param bool) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "boolean"
+	case smartcontract.IntegerType:
+		if param {
+			return "bigint | number"
+		}
+		return "bigint"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "Uint8Array"
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return "string"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return toTypeName(et.Name)
+		}
+		return tsType(valueType(et), param) + "[]"
+	case smartcontract.MapType:
+		return "Map<" + tsType(binding.ExtendedType{Base: et.Key}, false) + ", " + tsType(valueType(et), param) + ">"
+	case smartcontract.VoidType:
+		return ""
+	default: // Any and InteropInterface.
+		if param {
+			return "sc.ContractParam"
+		}
+		return "sc.StackItemJson"
+	}
+}
+
+// decoder returns an expression converting stack item v into the value of
+// the given type.
+func decoder(et binding.ExtendedType, v string, depth int) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "toBool(" + v + ")"
+	case smartcontract.IntegerType:
+		return "toInteger(" + v + ")"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "toBytes(" + v + ")"
+	case smartcontract.StringType:
+		return "toUTF8String(" + v + ")"
+	case smartcontract.Hash160Type:
+		return "toHash(" + v + ", 20)"
+	case smartcontract.Hash256Type:
+		return "toHash(" + v + ", 32)"
+	case smartcontract.PublicKeyType:
+		return "toPublicKey(" + v + ")"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return "itemTo" + toTypeName(et.Name) + "(" + v + ")"
+		}
+		e := "e" + strconv.Itoa(depth)
+		return "toArray(" + v + ", (" + e + ") => " + decoder(valueType(et), e, depth+1) + ")"
+	case smartcontract.MapType:
+		k, e := "k"+strconv.Itoa(depth), "e"+strconv.Itoa(depth)
+		return "toMap(" + v + ", (" + k + ") => " + decoder(binding.ExtendedType{Base: et.Key}, k, depth+1) +
+			", (" + e + ") => " + decoder(valueType(et), e, depth+1) + ")"
+	default:
+		return v
+	}
+}
+
+// encoder returns an expression converting value v of the given type into
+// sc.ContractParam. Values of unknown type are expected to be sc.ContractParam
+// for parameters and sc.StackItemJson otherwise.
+func encoder(et binding.ExtendedType, v string, depth int, param bool) string {
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "sc.ContractParam.boolean(" + v + ")"
+	case smartcontract.IntegerType:
+		return "sc.ContractParam.integer(" + v + ".toString())"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "bytesParam(" + v + ")"
+	case smartcontract.StringType:
+		return "sc.ContractParam.string(" + v + ")"
+	case smartcontract.Hash160Type:
+		return "sc.ContractParam.hash160(" + v + ")"
+	case smartcontract.Hash256Type:
+		return "sc.ContractParam.hash256(" + v + ")"
+	case smartcontract.PublicKeyType:
+		return "sc.ContractParam.publicKey(" + v + ")"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return lowerFirst(toTypeName(et.Name)) + "ToParam(" + v + ")"
+		}
+		e := "e" + strconv.Itoa(depth)
+		return "sc.ContractParam.array(..." + v + ".map((" + e + ") => " + encoder(valueType(et), e, depth+1, param) + "))"
+	case smartcontract.MapType:
+		k, e := "k"+strconv.Itoa(depth), "e"+strconv.Itoa(depth)
+		return "mapParam([..." + v + "].map(([" + k + ", " + e + "]) => [" +
+			encoder(binding.ExtendedType{Base: et.Key}, k, depth+1, false) + ", " +
+			encoder(valueType(et), e, depth+1, param) + "]))"
+	default:
+		if param {
+			return v
+		}
+		return "itemToParam(" + v + ")"
+	}
+}
+
+func valueType(et binding.ExtendedType) binding.ExtendedType {
+	if et.Value != nil {
+		return *et.Value
+	}
+	return binding.ExtendedType{Base: smartcontract.AnyType}
+}
+
+func dropStdMethods(meths []manifest.Method, std *standard.Standard) []manifest.Method {
+	var res = meths[:0]
+	for _, m := range meths {
+		if std.Manifest.ABI.GetMethod(m.Name, len(m.Parameters)) == nil {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+func toTypeName(s string) string {
+	return strings.ReplaceAll(upperFirst(s), ".", "")
+}
+
+func upperFirst(s string) string {
+	return strings.ToUpper(s[0:1]) + s[1:]
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[0:1]) + s[1:]
+}
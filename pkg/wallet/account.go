@@ -22,6 +22,9 @@ type Account struct {
 	// NEO private key.
 	privateKey *keys.PrivateKey
 
+	// External signer used instead of the private key (if set).
+	signer Signer
+
 	// Script hash corresponding to the Address.
 	scriptHash util.Uint160
 
@@ -44,6 +47,24 @@ type Account struct {
 
 	// Indicates whether the account is the default change account.
 	Default bool `json:"isDefault"`
+
+	// Extra contains additional account metadata. This field can be null.
+	Extra *AccountExtra `json:"extra,omitempty"`
+}
+
+// AccountExtra is an additional NEP-6 account metadata used by NeoGo.
+type AccountExtra struct {
+	// DerivationPath is a BIP-32 path used to derive the key of the HD
+	// account from the seed.
+	DerivationPath string `json:"derivationPath,omitempty"`
+
+	// Tags is a set of arbitrary user-defined tags used to group accounts.
+	Tags []string `json:"tags,omitempty"`
+
+	// Argon2id contains Argon2id KDF parameters if the account key is
+	// encrypted using them instead of wallet's scrypt parameters. It's a
+	// NeoGo extension of NEP-2, other wallets can't decrypt such keys.
+	Argon2id *keys.Argon2idParams `json:"argon2id,omitempty"`
 }
 
 // Contract represents a subset of the smartcontract to embed in the
@@ -147,10 +168,13 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 	if len(a.Contract.Parameters) == 0 {
 		return nil
 	}
-	if a.privateKey == nil {
+	if a.privateKey == nil && a.signer == nil {
 		return errors.New("account key is not available (need to decrypt?)")
 	}
-	sign := a.privateKey.SignHashable(uint32(net), t)
+	sign, err := a.signTx(net, t)
+	if err != nil {
+		return err
+	}
 
 	invoc := append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sign...)
 	if len(a.Contract.Parameters) == 1 {
@@ -163,18 +187,87 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 }
 
 // SignHashable signs the given Hashable item and returns the signature. If this
-// account can't sign (CanSign() returns false) nil is returned.
+// account can't sign (CanSign() returns false) or external signer fails, nil
+// is returned, use SignHashableWithError to get the reason.
 func (a *Account) SignHashable(net netmode.Magic, item hash.Hashable) []byte {
+	sig, err := a.SignHashableWithError(net, item)
+	if err != nil {
+		return nil
+	}
+	return sig
+}
+
+// SignHashableWithError is the same as SignHashable, but it returns an error if
+// the account can't sign or external signer fails.
+func (a *Account) SignHashableWithError(net netmode.Magic, item hash.Hashable) ([]byte, error) {
+	if !a.CanSign() {
+		return nil, errors.New("account can't sign (locked or no key)")
+	}
+	if t, ok := item.(*transaction.Transaction); ok {
+		return a.signTx(net, t)
+	}
+	return a.signHash(hash.NetSha256(uint32(net), item))
+}
+
+// SignHash signs the given digest and returns the signature. If this account
+// can't sign (CanSign() returns false) or external signer fails, nil is
+// returned.
+func (a *Account) SignHash(digest util.Uint256) []byte {
 	if !a.CanSign() {
 		return nil
 	}
-	return a.privateKey.SignHashable(uint32(net), item)
+	sig, err := a.signHash(digest)
+	if err != nil {
+		return nil
+	}
+	return sig
+}
+
+// signTx signs the transaction using the private key or an external signer if
+// the key is not available. Signers implementing TransactionSigner get the
+// transaction itself.
+func (a *Account) signTx(net netmode.Magic, t *transaction.Transaction) ([]byte, error) {
+	ts, ok := a.signer.(TransactionSigner)
+	if a.privateKey != nil || !ok {
+		return a.signHash(hash.NetSha256(uint32(net), t))
+	}
+	return checkSignerResult(ts.SignTx(net, t))
+}
+
+// signHash signs the digest using the private key or an external signer if
+// the key is not available.
+func (a *Account) signHash(digest util.Uint256) ([]byte, error) {
+	if a.privateKey != nil {
+		return a.privateKey.SignHash(digest), nil
+	}
+	return checkSignerResult(a.signer.SignHash(digest))
+}
+
+// checkSignerResult checks the signature returned by an external signer.
+func checkSignerResult(sig []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, fmt.Errorf("external signer failure: %w", err)
+	}
+	if len(sig) != keys.SignatureLen {
+		return nil, fmt.Errorf("external signer failure: invalid signature length %d", len(sig))
+	}
+	return sig, nil
 }
 
 // CanSign returns true when account is not locked and has a decrypted private
-// key inside, so it's ready to create real signatures.
+// key inside or an external signer attached, so it's ready to create real
+// signatures.
 func (a *Account) CanSign() bool {
-	return !a.Locked && a.privateKey != nil
+	return !a.Locked && (a.privateKey != nil || a.signer != nil)
+}
+
+// SetSigner attaches an external signer to the account, so that it can be
+// used to create signatures without decrypting the private key (decrypted key
+// takes precedence if it's available). Signer's key must be the one used by
+// the account contract (or one of multisignature contract keys). Use nil
+// signer to detach it.
+func (a *Account) SetSigner(s Signer) {
+	a.signer = s
 }
 
 // GetVerificationScript returns account's verification script.
@@ -182,20 +275,26 @@ func (a *Account) GetVerificationScript() []byte {
 	if a.Contract != nil {
 		return a.Contract.Script
 	}
-	return a.privateKey.PublicKey().GetVerificationScript()
+	return a.PublicKey().GetVerificationScript()
 }
 
 // Decrypt decrypts the EncryptedWIF with the given passphrase returning error
 // if anything goes wrong. After the decryption Account can be used to sign
 // things unless it's locked. Don't decrypt the key unless you want to sign
 // something and don't forget to call Close after use for maximum safety.
+// Accounts encrypted with Argon2id (see EncryptArgon2id) use parameters from
+// their Extra, scrypt parameters are ignored for them.
 func (a *Account) Decrypt(passphrase string, scrypt keys.ScryptParams) error {
 	var err error
 
 	if a.EncryptedWIF == "" {
 		return errors.New("no encrypted wif in the account")
 	}
-	a.privateKey, err = keys.NEP2Decrypt(a.EncryptedWIF, passphrase, scrypt)
+	if a.Extra != nil && a.Extra.Argon2id != nil {
+		a.privateKey, err = keys.NEP2DecryptArgon2id(a.EncryptedWIF, passphrase, *a.Extra.Argon2id)
+	} else {
+		a.privateKey, err = keys.NEP2Decrypt(a.EncryptedWIF, passphrase, scrypt)
+	}
 	if err != nil {
 		return err
 	}
@@ -211,6 +310,27 @@ func (a *Account) Encrypt(passphrase string, scrypt keys.ScryptParams) error {
 		return err
 	}
 	a.EncryptedWIF = wif
+	if a.Extra != nil {
+		a.Extra.Argon2id = nil
+		a.dropEmptyExtra()
+	}
+	return nil
+}
+
+// EncryptArgon2id encrypts the wallet's PrivateKey with the given passphrase
+// in the NEP-2 format using Argon2id with the given parameters for key
+// derivation. Parameters are stored in the account's Extra, so that Decrypt
+// can use them later.
+func (a *Account) EncryptArgon2id(passphrase string, params keys.Argon2idParams) error {
+	wif, err := keys.NEP2EncryptArgon2id(a.privateKey, passphrase, params)
+	if err != nil {
+		return err
+	}
+	a.EncryptedWIF = wif
+	if a.Extra == nil {
+		a.Extra = new(AccountExtra)
+	}
+	a.Extra.Argon2id = &params
 	return nil
 }
 
@@ -229,6 +349,9 @@ func (a *Account) PublicKey() *keys.PublicKey {
 	if !a.CanSign() {
 		return nil
 	}
+	if a.privateKey == nil {
+		return a.signer.PublicKey()
+	}
 	return a.privateKey.PublicKey()
 }
 
@@ -243,8 +366,8 @@ func (a *Account) ScriptHash() util.Uint160 {
 }
 
 // Close cleans up the private key used by Account and disassociates it from
-// Account. The Account can no longer sign anything after this call, but Decrypt
-// can make it usable again.
+// Account. The Account can no longer sign anything after this call (unless it
+// has an external signer attached), but Decrypt can make it usable again.
 func (a *Account) Close() {
 	if a.privateKey == nil {
 		return
@@ -280,11 +403,10 @@ func (a *Account) ConvertMultisig(m int, pubs []*keys.PublicKey) error {
 	if a.Locked {
 		return errors.New("account is locked")
 	}
-	if a.privateKey == nil {
+	if a.privateKey == nil && a.signer == nil {
 		return errors.New("account key is not available (need to decrypt?)")
 	}
-	accKey := a.privateKey.PublicKey()
-	return a.ConvertMultisigEncrypted(accKey, m, pubs)
+	return a.ConvertMultisigEncrypted(a.PublicKey(), m, pubs)
 }
 
 // ConvertMultisigEncrypted sets a's contract to an encrypted multisig contract
@@ -335,6 +457,105 @@ func NewAccountFromPrivateKey(p *keys.PrivateKey) *Account {
 	return a
 }
 
+// NewWatchOnlyAccount creates an account for the given script hash without
+// any key and verification script (NEP-6 watch-only account). It can be used
+// to track balances and history of the address, but not to sign anything.
+func NewWatchOnlyAccount(h util.Uint160) *Account {
+	return &Account{
+		scriptHash: h,
+		Address:    address.Uint160ToString(h),
+	}
+}
+
+// NewWatchOnlyAccountFromPublicKey creates a standard signature account for
+// the given public key without the private key. Unlike NewWatchOnlyAccount it
+// has a verification script, so it can be used as a transaction signer (with
+// signatures provided externally).
+func NewWatchOnlyAccountFromPublicKey(pub *keys.PublicKey) *Account {
+	return &Account{
+		scriptHash: pub.GetScriptHash(),
+		Address:    pub.Address(),
+		Contract: &Contract{
+			Script:     pub.GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}
+}
+
+// IsWatchOnly returns true if the account has no encrypted key, no decrypted
+// key and no external signer, so it can't sign anything.
+func (a *Account) IsWatchOnly() bool {
+	return a.EncryptedWIF == "" && a.privateKey == nil && a.signer == nil
+}
+
+// HasTag checks whether the account is tagged with the given tag.
+func (a *Account) HasTag(tag string) bool {
+	if a.Extra == nil {
+		return false
+	}
+	for _, t := range a.Extra.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag adds the given tag to the account (if it's not there yet).
+func (a *Account) AddTag(tag string) {
+	if a.HasTag(tag) {
+		return
+	}
+	if a.Extra == nil {
+		a.Extra = new(AccountExtra)
+	}
+	a.Extra.Tags = append(a.Extra.Tags, tag)
+}
+
+// RemoveTag removes the given tag from the account (if it's there).
+func (a *Account) RemoveTag(tag string) {
+	if !a.HasTag(tag) {
+		return
+	}
+	tags := a.Extra.Tags[:0]
+	for _, t := range a.Extra.Tags {
+		if t != tag {
+			tags = append(tags, t)
+		}
+	}
+	a.Extra.Tags = tags
+	if len(tags) == 0 {
+		a.Extra.Tags = nil
+		a.dropEmptyExtra()
+	}
+}
+
+// dropEmptyExtra sets account's Extra to nil if there is nothing in it.
+func (a *Account) dropEmptyExtra() {
+	if a.Extra.DerivationPath == "" && len(a.Extra.Tags) == 0 && a.Extra.Argon2id == nil {
+		a.Extra = nil
+	}
+}
+
+// NewAccountFromSigner creates a standard signature account for the key of the
+// given external signer. Such account has no encrypted key and signs everything
+// via the signer.
+func NewAccountFromSigner(s Signer) *Account {
+	pubKey := s.PublicKey()
+
+	a := &Account{
+		signer:     s,
+		scriptHash: pubKey.GetScriptHash(),
+		Address:    pubKey.Address(),
+		Contract: &Contract{
+			Script:     pubKey.GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}
+
+	return a
+}
+
 func getContractParams(n int) []ContractParam {
 	params := make([]ContractParam, n)
 	for i := range params {
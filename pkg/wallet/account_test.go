@@ -3,6 +3,7 @@ package wallet
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
@@ -11,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -41,6 +43,38 @@ func TestDecryptAccount(t *testing.T) {
 	require.Error(t, acc.Decrypt("qwerty", keys.NEP2ScryptParams()))
 }
 
+func TestAccountArgon2id(t *testing.T) {
+	acc, err := NewAccount()
+	require.NoError(t, err)
+	priv := acc.PrivateKey().Bytes()
+	params := keys.Argon2idParams{Time: 1, Memory: 64, Threads: 1}
+	acc.AddTag("hot")
+
+	require.NoError(t, acc.EncryptArgon2id("qwerty", params))
+	require.Equal(t, &params, acc.Extra.Argon2id)
+
+	data, err := json.Marshal(acc)
+	require.NoError(t, err)
+	actual := new(Account)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, acc.Extra, actual.Extra)
+
+	// Scrypt parameters are ignored for Argon2id accounts.
+	require.NoError(t, actual.Decrypt("qwerty", keys.ScryptParams{}))
+	require.Equal(t, priv, actual.PrivateKey().Bytes())
+	require.Error(t, actual.Decrypt("qwertz", keys.ScryptParams{}))
+
+	// Back to scrypt.
+	scrypt := keys.ScryptParams{N: 2, R: 1, P: 1}
+	require.NoError(t, acc.Encrypt("qwerty", scrypt))
+	require.Equal(t, &AccountExtra{Tags: []string{"hot"}}, acc.Extra)
+	acc.RemoveTag("hot")
+	require.Nil(t, acc.Extra)
+	acc.Close()
+	require.NoError(t, acc.Decrypt("qwerty", scrypt))
+	require.Equal(t, priv, acc.PrivateKey().Bytes())
+}
+
 func TestNewFromWif(t *testing.T) {
 	for _, testCase := range keytestcases.Arr {
 		acc, err := NewAccountFromWIF(testCase.Wif)
@@ -167,6 +201,138 @@ func TestContractSignTx(t *testing.T) {
 	require.Equal(t, 132, len(tx.Scripts[2].InvocationScript))
 }
 
+type testSigner struct {
+	priv *keys.PrivateKey
+	err  error
+}
+
+func (s testSigner) PublicKey() *keys.PublicKey {
+	return s.priv.PublicKey()
+}
+
+func (s testSigner) SignHash(digest util.Uint256) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.priv.SignHash(digest), nil
+}
+
+func TestAccountSigner(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	acc := NewAccountFromSigner(testSigner{priv: priv})
+	require.Equal(t, priv.Address(), acc.Address)
+	require.Nil(t, acc.PrivateKey())
+	require.True(t, acc.CanSign())
+	require.Equal(t, priv.PublicKey(), acc.PublicKey())
+	require.Equal(t, priv.PublicKey().GetVerificationScript(), acc.GetVerificationScript())
+
+	tx := &transaction.Transaction{
+		Script: []byte{1, 2, 3},
+		Signers: []transaction.Signer{{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}},
+	}
+	require.NoError(t, acc.SignTx(42, tx))
+	require.Equal(t, 1, len(tx.Scripts))
+	require.True(t, priv.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], 42, tx))
+	require.True(t, priv.PublicKey().VerifyHashable(acc.SignHashable(42, tx), 42, tx))
+
+	acc.Close() // Doesn't affect signer.
+	require.True(t, acc.CanSign())
+
+	acc.Locked = true
+	require.False(t, acc.CanSign())
+	require.Nil(t, acc.SignHashable(42, tx))
+	_, err = acc.SignHashableWithError(42, tx)
+	require.Error(t, err)
+	acc.Locked = false
+
+	acc.SetSigner(testSigner{priv: priv, err: errors.New("failure")})
+	require.Error(t, acc.SignTx(42, tx))
+	require.Nil(t, acc.SignHashable(42, tx))
+	_, err = acc.SignHashableWithError(42, tx)
+	require.ErrorContains(t, err, "failure")
+
+	acc.SetSigner(nil)
+	require.False(t, acc.CanSign())
+	require.Error(t, acc.SignTx(42, tx))
+
+	t.Run("multisig", func(t *testing.T) {
+		priv2, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		pubs := keys.PublicKeys{priv.PublicKey(), priv2.PublicKey()}
+
+		acc := NewAccountFromSigner(testSigner{priv: priv2})
+		require.NoError(t, acc.ConvertMultisig(1, pubs))
+		tx := &transaction.Transaction{
+			Script: []byte{1, 2, 3},
+			Signers: []transaction.Signer{{
+				Account: acc.ScriptHash(),
+				Scopes:  transaction.CalledByEntry,
+			}},
+		}
+		require.NoError(t, acc.SignTx(42, tx))
+		require.True(t, priv2.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], 42, tx))
+	})
+}
+
+func TestWatchOnlyAccount(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+
+	t.Run("by hash", func(t *testing.T) {
+		a := NewWatchOnlyAccount(pub.GetScriptHash())
+		require.Equal(t, pub.Address(), a.Address)
+		require.Equal(t, pub.GetScriptHash(), a.ScriptHash())
+		require.Nil(t, a.Contract)
+		require.True(t, a.IsWatchOnly())
+		require.False(t, a.CanSign())
+	})
+	t.Run("by public key", func(t *testing.T) {
+		a := NewWatchOnlyAccountFromPublicKey(pub)
+		require.Equal(t, pub.Address(), a.Address)
+		require.Equal(t, pub.GetVerificationScript(), a.Contract.Script)
+		require.True(t, a.IsWatchOnly())
+		require.False(t, a.CanSign())
+		require.Error(t, a.SignTx(0, &transaction.Transaction{Signers: []transaction.Signer{{Account: a.ScriptHash()}}}))
+	})
+	require.False(t, NewAccountFromPrivateKey(priv).IsWatchOnly())
+}
+
+func TestAccountTags(t *testing.T) {
+	a := NewWatchOnlyAccount(util.Uint160{1, 2, 3})
+	require.False(t, a.HasTag("cold"))
+	a.RemoveTag("cold")
+	require.Nil(t, a.Extra)
+
+	a.AddTag("cold")
+	a.AddTag("cold")
+	a.AddTag("customer")
+	require.True(t, a.HasTag("cold"))
+	require.Equal(t, []string{"cold", "customer"}, a.Extra.Tags)
+
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	actual := new(Account)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, a.Extra, actual.Extra)
+
+	a.RemoveTag("cold")
+	require.False(t, a.HasTag("cold"))
+	require.Equal(t, []string{"customer"}, a.Extra.Tags)
+	a.RemoveTag("customer")
+	require.Nil(t, a.Extra)
+
+	a.Extra = &AccountExtra{DerivationPath: "m/44'/888'/0'/0/0"}
+	a.AddTag("hd")
+	a.RemoveTag("hd")
+	require.Equal(t, &AccountExtra{DerivationPath: "m/44'/888'/0'/0/0"}, a.Extra)
+}
+
 func TestContract_ScriptHash(t *testing.T) {
 	script := []byte{0, 1, 2, 3}
 	c := &Contract{Script: script}
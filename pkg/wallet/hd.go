@@ -0,0 +1,236 @@
+package wallet
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// HD wallet constants.
+const (
+	// HardenedKeyStart is the first hardened child key index.
+	HardenedKeyStart uint32 = 1 << 31
+	// NeoCoinType is the SLIP-44 coin type registered for Neo.
+	NeoCoinType = 888
+	// DefaultDerivationPathPrefix is the BIP-44 derivation path prefix used
+	// for Neo accounts by default (account 0, external chain), the last
+	// component is the address index.
+	DefaultDerivationPathPrefix = "m/44'/888'/0'/0/"
+
+	masterKeySeed = "Nist256p1 seed"
+)
+
+// HDKey is an extended secp256r1 private key that can be used to derive
+// child keys according to BIP-32 (as specified for NIST P-256 curve by
+// SLIP-10).
+type HDKey struct {
+	key       *keys.PrivateKey
+	chainCode []byte
+}
+
+// NewMasterKey creates a master extended key from the given seed (typically
+// obtained via MnemonicToSeed).
+func NewMasterKey(seed []byte) (*HDKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length: %d", len(seed))
+	}
+	n := elliptic.P256().Params().N
+	data := seed
+	for {
+		i := hmacSHA512([]byte(masterKeySeed), data)
+		k := new(big.Int).SetBytes(i[:32])
+		if k.Sign() != 0 && k.Cmp(n) < 0 {
+			return newHDKey(i[:32], i[32:])
+		}
+		data = i
+	}
+}
+
+// Child derives a child key with the given index, indices starting from
+// HardenedKeyStart are hardened.
+func (k *HDKey) Child(index uint32) (*HDKey, error) {
+	var data []byte
+	if index >= HardenedKeyStart {
+		data = append([]byte{0}, k.key.Bytes()...)
+	} else {
+		data = k.key.PublicKey().Bytes()
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	n := elliptic.P256().Params().N
+	for {
+		i := hmacSHA512(k.chainCode, data)
+		il := new(big.Int).SetBytes(i[:32])
+		if il.Cmp(n) < 0 {
+			il.Add(il, new(big.Int).SetBytes(k.key.Bytes()))
+			il.Mod(il, n)
+			if il.Sign() != 0 {
+				return newHDKey(il.FillBytes(make([]byte, 32)), i[32:])
+			}
+		}
+		data = binary.BigEndian.AppendUint32(append([]byte{1}, i[32:]...), index)
+	}
+}
+
+// Derive derives a key using the given BIP-32 path (like "m/44'/888'/0'/0/0")
+// from the master key.
+func (k *HDKey) Derive(path string) (*HDKey, error) {
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	res := k
+	for _, i := range indices {
+		child, err := res.Child(i)
+		if res != k {
+			res.Destroy() // Intermediate keys are not needed.
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = child
+	}
+	return res, nil
+}
+
+// PrivateKey returns the private key of the extended key.
+func (k *HDKey) PrivateKey() *keys.PrivateKey {
+	return k.key
+}
+
+// ChainCode returns the chain code of the extended key.
+func (k *HDKey) ChainCode() []byte {
+	return k.chainCode
+}
+
+// Destroy wipes the key from memory, it can't be used after this call.
+func (k *HDKey) Destroy() {
+	k.key.Destroy()
+	for i := range k.chainCode {
+		k.chainCode[i] = 0
+	}
+}
+
+func newHDKey(key []byte, chainCode []byte) (*HDKey, error) {
+	priv, err := keys.NewPrivateKeyFromBytes(key)
+	if err != nil {
+		return nil, err
+	}
+	return &HDKey{
+		key:       priv,
+		chainCode: append([]byte{}, chainCode...),
+	}, nil
+}
+
+func hmacSHA512(key []byte, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// ParseDerivationPath parses BIP-32 derivation path (like "m/44'/888'/0'/0/0",
+// "h" can also be used to mark hardened indices) into a list of child indices.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with 'm'", path)
+	}
+	res := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		var hardened bool
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") {
+			hardened = true
+			p = p[:len(p)-1]
+		}
+		i, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || uint32(i) >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid derivation path %q: bad index %q", path, p)
+		}
+		if hardened {
+			i += uint64(HardenedKeyStart)
+		}
+		res = append(res, uint32(i))
+	}
+	return res, nil
+}
+
+// NewAccountFromSeed creates a new Account with a key derived from the seed
+// using the given path. The path is saved into the account metadata.
+func NewAccountFromSeed(seed []byte, path string) (*Account, error) {
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	k, err := master.Derive(path)
+	if err != nil {
+		master.Destroy()
+		return nil, err
+	}
+	if k != master {
+		master.Destroy()
+	}
+	acc := NewAccountFromPrivateKey(k.PrivateKey())
+	acc.Extra = &AccountExtra{DerivationPath: path}
+	return acc, nil
+}
+
+// NextDerivationPath returns the default derivation path for the next HD
+// account of the wallet, that is the one following the highest index used by
+// wallet accounts with DefaultDerivationPathPrefix.
+func (w *Wallet) NextDerivationPath() string {
+	var next uint64
+	for _, acc := range w.Accounts {
+		if acc.Extra == nil || !strings.HasPrefix(acc.Extra.DerivationPath, DefaultDerivationPathPrefix) {
+			continue
+		}
+		i, err := strconv.ParseUint(strings.TrimPrefix(acc.Extra.DerivationPath, DefaultDerivationPathPrefix), 10, 31)
+		if err == nil && i >= next {
+			next = i + 1
+		}
+	}
+	return DefaultDerivationPathPrefix + strconv.FormatUint(next, 10)
+}
+
+// CreateDerivedAccount creates a new account with the key derived from the
+// seed using the next derivation path (see NextDerivationPath), encrypts it
+// with the given passphrase, adds it to the wallet and saves the wallet. It
+// returns an error if the seed doesn't match existing HD accounts.
+func (w *Wallet) CreateDerivedAccount(seed []byte, name, passphrase string) (*Account, error) {
+	for _, a := range w.Accounts {
+		if a.Extra == nil || a.Extra.DerivationPath == "" {
+			continue
+		}
+		check, err := NewAccountFromSeed(seed, a.Extra.DerivationPath)
+		if err != nil {
+			return nil, err
+		}
+		check.Close()
+		if check.Address != a.Address {
+			return nil, errors.New("seed doesn't match existing HD accounts")
+		}
+		break
+	}
+	acc, err := NewAccountFromSeed(seed, w.NextDerivationPath())
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range w.Accounts {
+		if a.Address == acc.Address {
+			return nil, errors.New("derived account is already in the wallet")
+		}
+	}
+	acc.Label = name
+	if err := acc.Encrypt(passphrase, w.Scrypt); err != nil {
+		return nil, err
+	}
+	w.AddAccount(acc)
+	return acc, w.Save()
+}
@@ -0,0 +1,103 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHDKey(t *testing.T) {
+	// Test vector 1 for nist256p1 from SLIP-10.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	testCases := []struct {
+		path      string
+		chainCode string
+		private   string
+		public    string
+	}{
+		{
+			path:      "m",
+			chainCode: "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+			private:   "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+			public:    "0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8",
+		},
+		{
+			path:      "m/0'",
+			chainCode: "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+			private:   "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+			public:    "0384610f5ecffe8fda089363a41f56a5c7ffc1d81b59a612d0d649b2d22355590c",
+		},
+	}
+	master, err := NewMasterKey(seed)
+	require.NoError(t, err)
+	for _, tc := range testCases {
+		k, err := master.Derive(tc.path)
+		require.NoError(t, err)
+		require.Equal(t, tc.chainCode, hex.EncodeToString(k.ChainCode()), tc.path)
+		require.Equal(t, tc.private, hex.EncodeToString(k.PrivateKey().Bytes()), tc.path)
+		require.Equal(t, tc.public, hex.EncodeToString(k.PrivateKey().PublicKey().Bytes()), tc.path)
+	}
+
+	_, err = NewMasterKey([]byte{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	p, err := ParseDerivationPath("m/44'/888'/0h/0/1")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{44 + HardenedKeyStart, NeoCoinType + HardenedKeyStart, HardenedKeyStart, 0, 1}, p)
+
+	p, err = ParseDerivationPath("m")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{}, p)
+
+	for _, s := range []string{"", "44'/888'", "m/", "m/x", "m/-1", "m/2147483648"} {
+		_, err = ParseDerivationPath(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestCreateDerivedAccount(t *testing.T) {
+	seed, err := MnemonicToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	require.NoError(t, err)
+
+	w := checkWalletConstructor(t)
+	m, err := NewAccountFromSeed(seed, "m/0")
+	require.NoError(t, err)
+	w.AddAccount(m)
+	require.Equal(t, DefaultDerivationPathPrefix+"0", w.NextDerivationPath())
+
+	other, err := MnemonicToSeed("zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong", "")
+	require.NoError(t, err)
+	_, err = w.CreateDerivedAccount(other, "other", "pass")
+	require.Error(t, err)
+
+	acc, err := w.CreateDerivedAccount(seed, "first", "pass")
+	require.NoError(t, err)
+	require.Equal(t, DefaultDerivationPathPrefix+"0", acc.Extra.DerivationPath)
+	require.Equal(t, DefaultDerivationPathPrefix+"1", w.NextDerivationPath())
+
+	acc2, err := w.CreateDerivedAccount(seed, "second", "pass")
+	require.NoError(t, err)
+	require.Equal(t, DefaultDerivationPathPrefix+"1", acc2.Extra.DerivationPath)
+	require.NotEqual(t, acc.Address, acc2.Address)
+
+	// Recovery gives the same keys.
+	restored, err := NewAccountFromSeed(seed, DefaultDerivationPathPrefix+"1")
+	require.NoError(t, err)
+	require.Equal(t, acc2.Address, restored.Address)
+
+	// Metadata survives wallet reload.
+	w2, err := NewWalletFromFile(w.Path())
+	require.NoError(t, err)
+	require.Equal(t, DefaultDerivationPathPrefix+"2", w2.NextDerivationPath())
+	require.NoError(t, w2.Accounts[2].Decrypt("pass", keys.NEP2ScryptParams()))
+	require.Equal(t, acc2.PrivateKey().Bytes(), w2.Accounts[2].PrivateKey().Bytes())
+
+	// Same key can't be added twice.
+	w.Accounts[2] = &Account{Address: acc2.Address}
+	_, err = w.CreateDerivedAccount(seed, "second", "pass")
+	require.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// messageSaltLen is the length of the random salt (in bytes) used for message
+// signing.
+const messageSaltLen = 16
+
+// messagePrefix and messageSuffix are the bytes surrounding the signed message
+// data, they make it look like a transaction that can never be valid.
+var (
+	messagePrefix = []byte{0x01, 0x00, 0x01, 0xf0}
+	messageSuffix = []byte{0x00, 0x00}
+)
+
+// SignedMessage is an arbitrary message signed by some key according to the
+// Neo N3 convention used by NeoLine and O3 wallets (signMessageV2 and
+// signMessageWithoutSaltV2 methods of their dAPI), Neon wallet uses the same
+// format for WalletConnect. Its JSON representation is compatible with the one
+// used by NeoLine and O3.
+type SignedMessage struct {
+	// Message is the original message.
+	Message string `json:"message"`
+	// Salt is a hex-encoded random salt used for signing (if any), its
+	// hex representation is prepended to the message before signing.
+	Salt string `json:"salt,omitempty"`
+	// PublicKey is the public key of the signer.
+	PublicKey *keys.PublicKey `json:"publicKey"`
+	// Signature is the signature of the message (hex-encoded in JSON).
+	Signature []byte `json:"data"`
+}
+
+// signedMessageAux is an auxiliary struct for SignedMessage JSON marshaling.
+type signedMessageAux struct {
+	Message   string          `json:"message"`
+	Salt      string          `json:"salt,omitempty"`
+	PublicKey *keys.PublicKey `json:"publicKey"`
+	Signature string          `json:"data"`
+}
+
+// SignMessage signs the given message with the account key. A random salt is
+// used unless withoutSalt is set. The account must be able to sign (see
+// CanSign).
+func SignMessage(acc *Account, message string, withoutSalt bool) (*SignedMessage, error) {
+	if !acc.CanSign() {
+		return nil, errors.New("account key is not available (need to decrypt?)")
+	}
+	var salt string
+	if !withoutSalt {
+		b := make([]byte, messageSaltLen)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		salt = hex.EncodeToString(b)
+	}
+	sig := acc.SignHash(hash.Sha256(messagePayload(salt, message)))
+	if sig == nil {
+		return nil, errors.New("failed to sign message")
+	}
+	return &SignedMessage{
+		Message:   message,
+		Salt:      salt,
+		PublicKey: acc.PublicKey(),
+		Signature: sig,
+	}, nil
+}
+
+// Verify checks the message signature.
+func (m *SignedMessage) Verify() bool {
+	if m.PublicKey == nil {
+		return false
+	}
+	return m.PublicKey.Verify(m.Signature, hash.Sha256(messagePayload(m.Salt, m.Message)).BytesBE())
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m SignedMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(signedMessageAux{
+		Message:   m.Message,
+		Salt:      m.Salt,
+		PublicKey: m.PublicKey,
+		Signature: hex.EncodeToString(m.Signature),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *SignedMessage) UnmarshalJSON(data []byte) error {
+	aux := new(signedMessageAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	sig, err := hex.DecodeString(aux.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	*m = SignedMessage{
+		Message:   aux.Message,
+		Salt:      aux.Salt,
+		PublicKey: aux.PublicKey,
+		Signature: sig,
+	}
+	return nil
+}
+
+// messagePayload returns the data that is actually signed for the given salt
+// and message.
+func messagePayload(salt, message string) []byte {
+	w := io.NewBufBinWriter()
+	w.WriteBytes(messagePrefix)
+	w.WriteVarBytes([]byte(salt + message))
+	w.WriteBytes(messageSuffix)
+	return w.Bytes()
+}
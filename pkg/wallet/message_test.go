@@ -0,0 +1,103 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagePayload(t *testing.T) {
+	// Prefix, var-length salt+message and suffix.
+	require.Equal(t, "010001f00548656c6c6f0000", hex.EncodeToString(messagePayload("", "Hello")))
+	require.Equal(t, "010001f0066162486f6c610000", hex.EncodeToString(messagePayload("ab", "Hola")))
+}
+
+func TestSignMessage(t *testing.T) {
+	acc, err := NewAccount()
+	require.NoError(t, err)
+
+	for _, noSalt := range []bool{false, true} {
+		m, err := SignMessage(acc, "Hello, world!", noSalt)
+		require.NoError(t, err)
+		require.Equal(t, "Hello, world!", m.Message)
+		require.Equal(t, acc.PublicKey(), m.PublicKey)
+		if noSalt {
+			require.Equal(t, "", m.Salt)
+		} else {
+			require.Equal(t, 2*messageSaltLen, len(m.Salt))
+		}
+		require.True(t, m.Verify())
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		actual := new(SignedMessage)
+		require.NoError(t, json.Unmarshal(data, actual))
+		require.Equal(t, m, actual)
+		require.True(t, actual.Verify())
+
+		actual.Message = "Hello, World!"
+		require.False(t, actual.Verify())
+	}
+
+	t.Run("other key", func(t *testing.T) {
+		m, err := SignMessage(acc, "msg", false)
+		require.NoError(t, err)
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		m.PublicKey = priv.PublicKey()
+		require.False(t, m.Verify())
+		m.PublicKey = nil
+		require.False(t, m.Verify())
+	})
+	t.Run("locked", func(t *testing.T) {
+		acc.Locked = true
+		_, err := SignMessage(acc, "msg", false)
+		require.Error(t, err)
+	})
+	t.Run("bad JSON", func(t *testing.T) {
+		m := new(SignedMessage)
+		require.Error(t, json.Unmarshal([]byte(`{"message":"a","data":"zz"}`), m))
+		require.Error(t, json.Unmarshal([]byte(`{"message":"a","publicKey":"01"}`), m))
+	})
+}
+
+func TestSignedMessageVerifyExternal(t *testing.T) {
+	// Messages signed by Neon wallet (via WalletConnect), they use the same
+	// signMessageV2 format.
+	testCases := []struct {
+		pub     string
+		sig     string
+		salt    string
+		message string
+	}{
+		{
+			pub:     "02ce6228ba2cb2fc235be93aff9cd5fc0851702eb9791552f60db062f01e3d83f6",
+			sig:     "90ab1886ca0bece59b982d9ade8f5598065d651362fb9ce45ad66d0474b89c0b80913c8f0118a282acbdf200a429ba2d81bc52534a53ab41a2c6dfe2f0b4fb1b",
+			salt:    "d41e348afccc2f3ee45cd9f5128b16dc",
+			message: "Caralho, muleq, o baguiu eh issumermo taix ligado na miss\xe3o?", // Latin-1.
+		},
+		{
+			pub:     "03bd9108c0b49f657e9eee50d1399022bd1e436118e5b7529a1b7cd606652f578f",
+			sig:     "510caa8cb6db5dedf04d215a064208d64be7496916d890df59aee132db8f2b07532e06f7ea664c4a99e3bcb74b43a35eb9653891b5f8701d2aef9e7526703eaa",
+			salt:    "2c5b189569e92cce12e1c640f23e83ba",
+			message: "123456",
+		},
+		{
+			pub:     "03bd9108c0b49f657e9eee50d1399022bd1e436118e5b7529a1b7cd606652f578f",
+			sig:     "1e13f248962d8b3b60708b55ddf448d6d6a28c6b43887212a38b00bf6bab695e61261e54451c6e3d5f1f000e5534d166c7ca30f662a296d3a9aafa6d8c173c01",
+			salt:    "58c86b2e74215b4f36b47d731236be3b",
+			message: "",
+		},
+	}
+	for _, tc := range testCases {
+		pub, err := keys.NewPublicKeyFromString(tc.pub)
+		require.NoError(t, err)
+		sig, err := hex.DecodeString(tc.sig)
+		require.NoError(t, err)
+		m := &SignedMessage{Message: tc.message, Salt: tc.salt, PublicKey: pub, Signature: sig}
+		require.True(t, m.Verify(), tc.message)
+	}
+}
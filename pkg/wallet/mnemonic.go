@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// bip39English is the BIP-39 English word list.
+//
+//go:embed bip39_english.txt
+var bip39English string
+
+var (
+	mnemonicWords   = strings.Fields(bip39English)
+	mnemonicIndices = func() map[string]int {
+		m := make(map[string]int, len(mnemonicWords))
+		for i, w := range mnemonicWords {
+			m[w] = i
+		}
+		return m
+	}()
+)
+
+// Mnemonic-related constants.
+const (
+	// MnemonicMinEntropy is the minimal allowed mnemonic entropy size in bits
+	// (resulting in 12 words).
+	MnemonicMinEntropy = 128
+	// MnemonicMaxEntropy is the maximal allowed mnemonic entropy size in bits
+	// (resulting in 24 words).
+	MnemonicMaxEntropy = 256
+
+	mnemonicSaltPrefix = "mnemonic"
+	mnemonicIterations = 2048
+	mnemonicSeedLen    = 64
+)
+
+// ErrInvalidMnemonic is returned when mnemonic phrase has invalid length,
+// contains unknown words or has wrong checksum.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// NewMnemonic generates a new random BIP-39 mnemonic phrase with the given
+// entropy size in bits, it must be a multiple of 32 between
+// MnemonicMinEntropy and MnemonicMaxEntropy.
+func NewMnemonic(bits int) (string, error) {
+	if bits < MnemonicMinEntropy || bits > MnemonicMaxEntropy || bits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy size: %d", bits)
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy)
+}
+
+// MnemonicFromEntropy returns BIP-39 mnemonic phrase for the given entropy.
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if bits < MnemonicMinEntropy || bits > MnemonicMaxEntropy || bits%32 != 0 {
+		return "", fmt.Errorf("invalid entropy size: %d", bits)
+	}
+	csBits := bits / 32
+	h := sha256.Sum256(entropy)
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, uint(csBits))
+	data.Or(data, big.NewInt(int64(h[0]>>(8-csBits))))
+
+	words := make([]string, (bits+csBits)/11)
+	mask := big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		idx := new(big.Int).And(data, mask)
+		words[i] = mnemonicWords[idx.Int64()]
+		data.Rsh(data, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy checks the given BIP-39 mnemonic phrase and returns the
+// entropy it encodes.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("%w: %d words", ErrInvalidMnemonic, len(words))
+	}
+	data := new(big.Int)
+	for _, w := range words {
+		idx, ok := mnemonicIndices[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, w)
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(idx)))
+	}
+	csBits := len(words) / 3
+	cs := new(big.Int).And(data, big.NewInt(int64(1<<csBits-1)))
+	data.Rsh(data, uint(csBits))
+
+	entropy := make([]byte, (len(words)*11-csBits)/8)
+	data.FillBytes(entropy)
+	h := sha256.Sum256(entropy)
+	if cs.Int64() != int64(h[0]>>(8-csBits)) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	return entropy, nil
+}
+
+// MnemonicToSeed checks the given BIP-39 mnemonic phrase and returns a seed
+// derived from it and the (optional) passphrase.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	phrase := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	salt := norm.NFKD.String(mnemonicSaltPrefix + passphrase)
+	return pbkdf2.Key([]byte(phrase), []byte(salt), mnemonicIterations, mnemonicSeedLen, sha512.New), nil
+}
@@ -0,0 +1,335 @@
+/*
+Package remotesigner implements wallet.Signer using a remote signing service
+speaking a simple HTTP/JSON protocol, so that private keys can be kept by a
+separate service and never loaded into the process memory.
+
+The protocol consists of two requests (relative to the service endpoint):
+
+	GET keys
+	  -> {"keys": ["<hex-encoded compressed public key>", ...]}
+	POST sign {"key": "<hex-encoded public key>", "hash": "<hex-encoded digest>",
+	           "network": <magic>, "transaction": "<hex-encoded transaction>"}
+	  -> {"signature": "<hex-encoded 64-byte signature>"}
+
+Digest is a big-endian 32-byte hash to be signed (it's the network-specific
+hash of a transaction or any other signed item, see hash.NetSha256) and the
+signature is the one returned by keys.PrivateKey.SignHash. When a transaction
+is signed, its network magic and hashable fields (see
+transaction.Transaction.EncodeHashableFields) are sent as well, so the service
+can check that the digest matches them and apply its policy to the
+transaction contents before signing. Other items (like blocks or arbitrary
+hashes signed via Signer.SignHash) only have the digest sent without
+"network" and "transaction" fields, the service can't know what it signs
+then, so it's up to the service to refuse such blind signing requests.
+
+Errors are returned with non-2xx HTTP status code and {"error": "<message>"}
+body. NewHandler provides a reference implementation of the service that can
+be used for testing.
+*/
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// DefaultTimeout is the default timeout used for remote signer requests.
+const DefaultTimeout = 10 * time.Second
+
+// Options contains remote signer client parameters.
+type Options struct {
+	// Timeout is a request timeout, DefaultTimeout is used if not set.
+	Timeout time.Duration
+	// Header contains additional HTTP headers sent with every request (like
+	// authentication tokens).
+	Header http.Header
+}
+
+// Client is a remote signing service client.
+type Client struct {
+	endpoint *url.URL
+	cli      http.Client
+	header   http.Header
+}
+
+// Signer is a wallet.Signer for a single key of the remote signing service.
+type Signer struct {
+	c   *Client
+	pub *keys.PublicKey
+}
+
+type (
+	keysResponse struct {
+		Keys []string `json:"keys"`
+	}
+	signRequest struct {
+		Key         string `json:"key"`
+		Hash        string `json:"hash"`
+		Network     uint32 `json:"network,omitempty"`
+		Transaction string `json:"transaction,omitempty"`
+	}
+	signResponse struct {
+		Signature string `json:"signature"`
+	}
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// New creates a new remote signer client for the given service endpoint.
+func New(endpoint string, opts Options) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signer endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid remote signer endpoint: unsupported scheme %q", u.Scheme)
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	return &Client{
+		endpoint: u,
+		cli:      http.Client{Timeout: opts.Timeout},
+		header:   opts.Header,
+	}, nil
+}
+
+// Keys returns the list of public keys available in the service.
+func (c *Client) Keys() ([]*keys.PublicKey, error) {
+	var resp keysResponse
+	if err := c.do(http.MethodGet, "keys", nil, &resp); err != nil {
+		return nil, err
+	}
+	res := make([]*keys.PublicKey, len(resp.Keys))
+	for i := range resp.Keys {
+		pub, err := keys.NewPublicKeyFromString(resp.Keys[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid key #%d: %w", i, err)
+		}
+		res[i] = pub
+	}
+	return res, nil
+}
+
+// SignHash signs the digest with the given key of the service and returns the
+// signature. The service gets nothing but the digest, so it signs blindly,
+// use SignTx for transactions.
+func (c *Client) SignHash(pub *keys.PublicKey, digest util.Uint256) ([]byte, error) {
+	return c.sign(signRequest{
+		Key:  hex.EncodeToString(pub.Bytes()),
+		Hash: hex.EncodeToString(digest.BytesBE()),
+	})
+}
+
+// SignTx signs the transaction for the given network with the given key of
+// the service and returns the signature. The transaction and network magic
+// are sent along with the digest, so that the service can check them.
+func (c *Client) SignTx(pub *keys.PublicKey, net netmode.Magic, tx *transaction.Transaction) ([]byte, error) {
+	data, err := tx.EncodeHashableFields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	return c.sign(signRequest{
+		Key:         hex.EncodeToString(pub.Bytes()),
+		Hash:        hex.EncodeToString(hash.NetSha256(uint32(net), tx).BytesBE()),
+		Network:     uint32(net),
+		Transaction: hex.EncodeToString(data),
+	})
+}
+
+// sign performs the signing request and checks the signature returned.
+func (c *Client) sign(req signRequest) ([]byte, error) {
+	var resp signResponse
+	if err := c.do(http.MethodPost, "sign", req, &resp); err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if len(sig) != keys.SignatureLen {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	return sig, nil
+}
+
+// Signer returns a wallet.Signer for the given key of the service. It doesn't
+// check whether the key is available, signing fails if it's not.
+func (c *Client) Signer(pub *keys.PublicKey) *Signer {
+	return &Signer{c: c, pub: pub}
+}
+
+// Attach attaches signers to all wallet accounts that are standard signature
+// or multisignature accounts using keys available in the service (the first
+// matching key is used for multisignature accounts). It returns the number of
+// accounts signers were attached to.
+func (c *Client) Attach(w *wallet.Wallet) (int, error) {
+	pubs, err := c.Keys()
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for _, acc := range w.Accounts {
+		if acc.Contract == nil {
+			continue
+		}
+		var accKeys [][]byte
+		if pub, ok := vm.ParseSignatureContract(acc.Contract.Script); ok {
+			accKeys = [][]byte{pub}
+		} else if _, mpubs, ok := vm.ParseMultiSigContract(acc.Contract.Script); ok {
+			accKeys = mpubs
+		}
+	keyLoop:
+		for _, k := range accKeys {
+			for _, pub := range pubs {
+				if bytes.Equal(k, pub.Bytes()) {
+					acc.SetSigner(c.Signer(pub))
+					n++
+					break keyLoop
+				}
+			}
+		}
+	}
+	return n, nil
+}
+
+// do performs a request and decodes the response into res.
+func (c *Client) do(method string, path string, req any, res any) error {
+	var body io.Reader
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	r, err := http.NewRequest(method, c.endpoint.JoinPath(path).String(), body)
+	if err != nil {
+		return err
+	}
+	for k, v := range c.header {
+		r.Header[k] = v
+	}
+	if req != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.cli.Do(r)
+	if err != nil {
+		return fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e errorResponse
+		if json.NewDecoder(resp.Body).Decode(&e) == nil && e.Error != "" {
+			return fmt.Errorf("remote signer error (%s): %s", resp.Status, e.Error)
+		}
+		return fmt.Errorf("remote signer error: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return fmt.Errorf("invalid remote signer response: %w", err)
+	}
+	return nil
+}
+
+// PublicKey implements wallet.Signer interface.
+func (s *Signer) PublicKey() *keys.PublicKey {
+	return s.pub
+}
+
+// SignHash implements wallet.Signer interface.
+func (s *Signer) SignHash(digest util.Uint256) ([]byte, error) {
+	return s.c.SignHash(s.pub, digest)
+}
+
+// SignTx implements wallet.TransactionSigner interface.
+func (s *Signer) SignTx(net netmode.Magic, tx *transaction.Transaction) ([]byte, error) {
+	return s.c.SignTx(s.pub, net, tx)
+}
+
+// NewHandler returns an http.Handler implementing the remote signer protocol
+// with the given keys. It's mostly useful for testing, real services are
+// expected to keep keys in a more secure way and apply some signing policy.
+// If the transaction is given in the request, the handler checks that the
+// digest matches it, but it signs plain digests as well.
+func NewHandler(privs ...*keys.PrivateKey) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		resp := keysResponse{Keys: make([]string, len(privs))}
+		for i := range privs {
+			resp.Keys[i] = hex.EncodeToString(privs[i].PublicKey().Bytes())
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+			return
+		}
+		digest, err := util.Uint256DecodeStringBE(req.Hash)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid hash: %w", err))
+			return
+		}
+		if req.Transaction != "" {
+			data, err := hex.DecodeString(req.Transaction)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid transaction: %w", err))
+				return
+			}
+			tx := new(transaction.Transaction)
+			if err := tx.DecodeHashableFields(data); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid transaction: %w", err))
+				return
+			}
+			if !hash.NetSha256(req.Network, tx).Equals(digest) {
+				writeError(w, http.StatusBadRequest, errors.New("hash doesn't match the transaction"))
+				return
+			}
+		}
+		for i := range privs {
+			if hex.EncodeToString(privs[i].PublicKey().Bytes()) == req.Key {
+				writeJSON(w, http.StatusOK, signResponse{
+					Signature: hex.EncodeToString(privs[i].SignHash(digest)),
+				})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, errors.New("unknown key"))
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorResponse{Error: err.Error()})
+}
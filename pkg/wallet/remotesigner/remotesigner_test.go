@@ -0,0 +1,167 @@
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	priv1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	priv2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	unknown, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	var (
+		auth    string
+		lastReq signRequest
+	)
+	h := NewHandler(priv1, priv2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			lastReq = signRequest{}
+			_ = json.Unmarshal(body, &lastReq)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err = New("ftp://localhost", Options{})
+	require.Error(t, err)
+
+	c, err := New(srv.URL+"/", Options{Header: http.Header{"Authorization": []string{"Bearer token"}}})
+	require.NoError(t, err)
+
+	pubs, err := c.Keys()
+	require.NoError(t, err)
+	require.Equal(t, []*keys.PublicKey{priv1.PublicKey(), priv2.PublicKey()}, pubs)
+	require.Equal(t, "Bearer token", auth)
+
+	digest := util.Uint256{1, 2, 3}
+	sig, err := c.SignHash(priv2.PublicKey(), digest)
+	require.NoError(t, err)
+	require.True(t, priv2.PublicKey().Verify(sig, digest.BytesBE()))
+	require.Zero(t, lastReq.Network)
+	require.Empty(t, lastReq.Transaction)
+
+	_, err = c.Signer(unknown.PublicKey()).SignHash(digest)
+	require.ErrorContains(t, err, "unknown key")
+
+	t.Run("bad endpoint", func(t *testing.T) {
+		c, err := New(srv.URL+"/nothing/", Options{})
+		require.NoError(t, err)
+		_, err = c.Keys()
+		require.Error(t, err)
+	})
+
+	t.Run("attach", func(t *testing.T) {
+		w := wallet.NewInMemoryWallet()
+
+		// Accounts without private keys.
+		simple := &wallet.Account{Address: priv1.Address(), Contract: &wallet.Contract{
+			Script:     priv1.PublicKey().GetVerificationScript(),
+			Parameters: []wallet.ContractParam{{Type: smartcontract.SignatureType}},
+		}}
+		script, err := smartcontract.CreateMultiSigRedeemScript(1, keys.PublicKeys{unknown.PublicKey(), priv2.PublicKey()})
+		require.NoError(t, err)
+		multi := &wallet.Account{Address: address.Uint160ToString(hash.Hash160(script)), Contract: &wallet.Contract{
+			Script:     script,
+			Parameters: []wallet.ContractParam{{Type: smartcontract.SignatureType}},
+		}}
+		other := &wallet.Account{Address: unknown.Address(), Contract: &wallet.Contract{
+			Script:     unknown.PublicKey().GetVerificationScript(),
+			Parameters: []wallet.ContractParam{{Type: smartcontract.SignatureType}},
+		}}
+		w.AddAccount(simple)
+		w.AddAccount(multi)
+		w.AddAccount(other)
+		w.AddAccount(&wallet.Account{Address: unknown.Address()}) // No contract.
+
+		n, err := c.Attach(w)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.True(t, simple.CanSign())
+		require.True(t, multi.CanSign())
+		require.False(t, other.CanSign())
+		require.Equal(t, priv2.PublicKey(), multi.PublicKey())
+
+		tx := &transaction.Transaction{
+			Script: []byte{1, 2, 3},
+			Signers: []transaction.Signer{
+				{Account: simple.ScriptHash()},
+				{Account: multi.ScriptHash()},
+			},
+		}
+		require.NoError(t, simple.SignTx(42, tx))
+		require.NoError(t, multi.SignTx(42, tx))
+		data, err := tx.EncodeHashableFields()
+		require.NoError(t, err)
+		require.Equal(t, uint32(42), lastReq.Network)
+		require.Equal(t, hex.EncodeToString(data), lastReq.Transaction)
+		require.True(t, priv1.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], 42, tx))
+		require.True(t, priv2.PublicKey().VerifyHashable(tx.Scripts[1].InvocationScript[2:], 42, tx))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	srv := httptest.NewServer(NewHandler(priv))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Post(srv.URL+"/keys", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	c, err := New(srv.URL, Options{})
+	require.NoError(t, err)
+	var res signResponse
+	err = c.do(http.MethodPost, "sign", signRequest{Key: "00", Hash: "bad"}, &res)
+	require.ErrorContains(t, err, "invalid hash")
+
+	tx := &transaction.Transaction{
+		Script:  []byte{1, 2, 3},
+		Signers: []transaction.Signer{{Account: priv.GetScriptHash()}},
+	}
+	data, err := tx.EncodeHashableFields()
+	require.NoError(t, err)
+	req := signRequest{
+		Key:         hex.EncodeToString(priv.PublicKey().Bytes()),
+		Hash:        hex.EncodeToString(hash.NetSha256(42, tx).BytesBE()),
+		Network:     42,
+		Transaction: "bad",
+	}
+	err = c.do(http.MethodPost, "sign", req, &res)
+	require.ErrorContains(t, err, "invalid transaction")
+
+	req.Transaction = hex.EncodeToString(data)
+	req.Network = 43
+	err = c.do(http.MethodPost, "sign", req, &res)
+	require.ErrorContains(t, err, "hash doesn't match the transaction")
+
+	req.Network = 42
+	require.NoError(t, c.do(http.MethodPost, "sign", req, &res))
+	sig, err := hex.DecodeString(res.Signature)
+	require.NoError(t, err)
+	require.True(t, priv.PublicKey().VerifyHashable(sig, 42, tx))
+}
@@ -0,0 +1,32 @@
+package wallet
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Signer is an external signer that keeps the private key out of the process
+// memory (like a separate signing service or hardware device). It can be
+// attached to an Account via SetSigner (or used to create one via
+// NewAccountFromSigner), Account then uses it to create signatures, so it
+// can be used by Actor and other components that need signing accounts.
+type Signer interface {
+	// PublicKey returns the public key of the signer.
+	PublicKey() *keys.PublicKey
+	// SignHash signs the given digest (see keys.PrivateKey.SignHash) and
+	// returns the signature.
+	SignHash(digest util.Uint256) ([]byte, error)
+}
+
+// TransactionSigner is a Signer that can sign transactions given the
+// transaction itself instead of its digest, so that it can check what it
+// signs. Account uses it for transactions (SignTx and SignHashable) if the
+// signer implements it, other items are signed via SignHash.
+type TransactionSigner interface {
+	Signer
+	// SignTx signs the network-specific hash of the transaction (see
+	// hash.NetSha256) and returns the signature.
+	SignTx(net netmode.Magic, tx *transaction.Transaction) ([]byte, error)
+}